@@ -0,0 +1,61 @@
+// Copyright JAMF Software, LLC
+
+package cert
+
+import (
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+)
+
+// ReloadableCA represents a CA bundle able to reflect the bundle file changes.
+type ReloadableCA struct {
+	mu sync.Mutex
+
+	file string
+
+	interval   time.Duration
+	lastReload time.Time
+
+	pool  *x509.CertPool
+	clock clock.Clock
+}
+
+func NewCA(caFile string) (*ReloadableCA, error) {
+	r := &ReloadableCA{
+		file:     caFile,
+		interval: 1 * time.Minute,
+		clock:    clock.New(),
+	}
+	return r, r.reload()
+}
+
+// CertPool returns a pool of the certificates of the watched bundle. In case of a reload failure it returns the last correctly loaded value.
+func (w *ReloadableCA) CertPool() *x509.CertPool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	// If interval passed since the last check, attempt bundle reload.
+	if w.lastReload.Add(w.interval).Before(w.clock.Now()) {
+		// After the initial load we are not interested in errors.
+		_ = w.reload()
+	}
+	return w.pool
+}
+
+func (w *ReloadableCA) reload() error {
+	bts, err := os.ReadFile(w.file)
+	if err != nil {
+		return err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(bts) {
+		return fmt.Errorf("no certificates found in %s", w.file)
+	}
+	w.pool = pool
+	w.lastReload = w.clock.Now()
+	return nil
+}
@@ -0,0 +1,52 @@
+// Copyright JAMF Software, LLC
+
+package cert
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloadableCA_CertPool(t *testing.T) {
+	r := require.New(t)
+	testCAFile := filepath.Join(t.TempDir(), "ca.crt")
+	mc := clock.NewMock()
+
+	t.Log("load valid bundle")
+	_, validCertFile, _ := createValidTLSPairInDir(t.TempDir())
+	mustCopyFile(validCertFile, testCAFile)
+	w := &ReloadableCA{interval: 1 * time.Second, clock: mc, file: testCAFile}
+	r.NoError(w.reload())
+	pool := w.CertPool()
+	r.NotNil(pool)
+
+	t.Log("keep the last valid bundle")
+	mustCopyFile(invalidCertFile, testCAFile)
+	mc.Add(w.interval * 2)
+	r.True(pool.Equal(w.CertPool()))
+	r.NoError(os.Remove(testCAFile))
+	mc.Add(w.interval * 2)
+	r.True(pool.Equal(w.CertPool()))
+
+	t.Log("reload changed bundle")
+	_, validCertFile2, _ := createValidTLSPairInDir(t.TempDir())
+	mustCopyFile(validCertFile2, testCAFile)
+	mc.Add(w.interval * 2)
+	r.False(pool.Equal(w.CertPool()))
+}
+
+func TestNewCA(t *testing.T) {
+	r := require.New(t)
+	_, err := NewCA(invalidCertFile)
+	r.Error(err)
+	_, err = NewCA(filepath.Join(t.TempDir(), "missing.crt"))
+	r.Error(err)
+	_, validCertFile, _ := createValidTLSPairInDir(t.TempDir())
+	_, err = NewCA(validCertFile)
+	r.NoError(err)
+}
@@ -5,18 +5,26 @@ package cmd
 import (
 	"context"
 	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
 	"runtime"
 	"strconv"
 	"sync"
 	"time"
 
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_auth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/jamf/regatta/cert"
 	rl "github.com/jamf/regatta/log"
 	"github.com/jamf/regatta/regattaserver"
 	"github.com/jamf/regatta/storage/table"
+	"github.com/jamf/regatta/tracing"
 	dbl "github.com/lni/dragonboat/v4/logger"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -28,23 +36,190 @@ import (
 
 var histogramBuckets = []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5}
 
-func createAPIServer(cert *cert.Reloadable) *regattaserver.RegattaServer {
+const (
+	// tracingFileMaxSize size of the file of the file tracing exporter after which the file is rotated.
+	tracingFileMaxSize = 100 * 1024 * 1024
+	// tracingFileMaxBackups number of the rotated files of the file tracing exporter to keep.
+	tracingFileMaxBackups = 3
+)
+
+// createAPITLSConfig returns the TLS config of the API server, the client certificates are verified by the reloaded
+// client CA bundle depending on the client auth mode.
+func createAPITLSConfig(c *cert.Reloadable) (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: c.GetCertificate,
+	}
+	switch mode := viper.GetString("api.client-auth"); mode {
+	case "none":
+		return config, nil
+	case "request":
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+	case "require":
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	default:
+		return nil, fmt.Errorf("unknown client auth mode %q", mode)
+	}
+	ca, err := cert.NewCA(viper.GetString("api.client-ca-filename"))
+	if err != nil {
+		return nil, err
+	}
+	config.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		cc := config.Clone()
+		cc.GetConfigForClient = nil
+		cc.ClientCAs = ca.CertPool()
+		return cc, nil
+	}
+	return config, nil
+}
+
+func createAPIServer(tlsConfig *tls.Config, interceptors apiInterceptors) *regattaserver.RegattaServer {
+	streamInterceptors := append([]grpc.StreamServerInterceptor{grpc_prometheus.StreamServerInterceptor}, interceptors.stream...)
+	unaryInterceptors := append([]grpc.UnaryServerInterceptor{grpc_prometheus.UnaryServerInterceptor}, interceptors.unary...)
 	return regattaserver.NewServer(
 		viper.GetString("api.address"),
 		viper.GetBool("api.reflection-api"),
-		grpc.Creds(credentials.NewTLS(&tls.Config{
-			MinVersion:     tls.VersionTLS12,
-			GetCertificate: cert.GetCertificate,
-		})),
+		grpc.Creds(credentials.NewTLS(tlsConfig)),
 		grpc.KeepaliveParams(keepalive.ServerParameters{
 			MaxConnectionAge: 60 * time.Second,
 		}),
-		grpc.StreamInterceptor(grpc_prometheus.StreamServerInterceptor),
-		grpc.UnaryInterceptor(grpc_prometheus.UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
 	)
 }
 
-func createMaintenanceServer(cert *cert.Reloadable) *regattaserver.RegattaServer {
+// apiInterceptors are the interceptors of the API requests applied by both the API server and the gateway.
+type apiInterceptors struct {
+	unary  []grpc.UnaryServerInterceptor
+	stream []grpc.StreamServerInterceptor
+}
+
+// createAPIInterceptors returns the interceptors of the API requests enabled by the configuration, the requests are authorized
+// before they are audited and before the rate limits are applied so that both the audit records and the limits are kept per user.
+func createAPIInterceptors(auth regattaserver.AuthService, tables regattaserver.TableService, auditor *regattaserver.Auditor) (apiInterceptors, error) {
+	var i apiInterceptors
+	if viper.GetString("tracing.exporter") != "none" {
+		i.unary = append(i.unary, tracing.UnaryServerInterceptor())
+		i.stream = append(i.stream, tracing.StreamServerInterceptor())
+	}
+	if viper.GetBool("api.auth-enabled") {
+		authz := regattaserver.NewAuthorizer(auth)
+		authz.Forwarders = viper.GetStringSlice("api.auth-forwarders")
+		i.unary = append(i.unary, authz.UnaryServerInterceptor())
+		i.stream = append(i.stream, authz.StreamServerInterceptor())
+	}
+	if auditor != nil {
+		i.unary = append(i.unary, auditor.UnaryServerInterceptor())
+		i.stream = append(i.stream, auditor.StreamServerInterceptor())
+	}
+	cfg := regattaserver.RateLimitConfig{
+		Requests:        viper.GetFloat64("api.rate-limit-requests"),
+		RequestsBurst:   viper.GetInt("api.rate-limit-requests-burst"),
+		WriteBytes:      viper.GetFloat64("api.rate-limit-write-bytes"),
+		WriteBytesBurst: viper.GetInt("api.rate-limit-write-bytes-burst"),
+	}
+	overrides, err := parseRateLimitTables(cfg)
+	if err != nil {
+		return i, err
+	}
+	if cfg.Requests > 0 || cfg.WriteBytes > 0 || len(overrides) > 0 {
+		limiter := regattaserver.NewRateLimiter(tables, cfg, overrides)
+		prometheus.MustRegister(limiter)
+		i.unary = append(i.unary, limiter.UnaryServerInterceptor())
+		i.stream = append(i.stream, limiter.StreamServerInterceptor())
+	}
+	return i, nil
+}
+
+// parseRateLimitTables returns the rate limits of the tables listed in the api.rate-limit-table-* flags,
+// the limits not set for the table are taken from cfg. The bursts default to the rates of the table.
+func parseRateLimitTables(cfg regattaserver.RateLimitConfig) (map[string]regattaserver.RateLimitConfig, error) {
+	tables := make(map[string]regattaserver.RateLimitConfig)
+	get := func(name string) regattaserver.RateLimitConfig {
+		if tc, ok := tables[name]; ok {
+			return tc
+		}
+		return cfg
+	}
+	for name, v := range viper.GetStringMapString("api.rate-limit-table-requests") {
+		r, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid request rate limit of table %q: %w", name, err)
+		}
+		tc := get(name)
+		tc.Requests, tc.RequestsBurst = r, 0
+		tables[name] = tc
+	}
+	for name, v := range viper.GetStringMapString("api.rate-limit-table-write-bytes") {
+		r, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid write bytes limit of table %q: %w", name, err)
+		}
+		tc := get(name)
+		tc.WriteBytes, tc.WriteBytesBurst = r, 0
+		tables[name] = tc
+	}
+	for name, v := range viper.GetStringMapString("api.rate-limit-table-requests-burst") {
+		b, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid request burst of table %q: %w", name, err)
+		}
+		tc := get(name)
+		tc.RequestsBurst = b
+		tables[name] = tc
+	}
+	for name, v := range viper.GetStringMapString("api.rate-limit-table-write-bytes-burst") {
+		b, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid write bytes burst of table %q: %w", name, err)
+		}
+		tc := get(name)
+		tc.WriteBytesBurst = b
+		tables[name] = tc
+	}
+	return tables, nil
+}
+
+// createAuditor returns the auditor of the mutating requests if the audit file is set, nil otherwise.
+func createAuditor() (*regattaserver.Auditor, error) {
+	filename := viper.GetString("audit.filename")
+	if filename == "" {
+		return nil, nil
+	}
+	f, err := rl.NewRotatingFile(filename, viper.GetInt64("audit.max-size"), viper.GetInt("audit.max-backups"))
+	if err != nil {
+		return nil, err
+	}
+	return regattaserver.NewAuditor(f), nil
+}
+
+// createTracer returns the tracer exporting the spans by the configured exporter, nil if the tracing is disabled.
+func createTracer() (*tracing.Tracer, error) {
+	var w io.Writer
+	switch exporter := viper.GetString("tracing.exporter"); exporter {
+	case "none":
+		return nil, nil
+	case "stdout":
+		w = os.Stdout
+	case "file":
+		f, err := rl.NewRotatingFile(viper.GetString("tracing.filename"), tracingFileMaxSize, tracingFileMaxBackups)
+		if err != nil {
+			return nil, err
+		}
+		w = f
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", exporter)
+	}
+	return tracing.NewTracer(tracing.NewWriterExporter(w), viper.GetFloat64("tracing.sample-ratio")), nil
+}
+
+func createMaintenanceServer(cert *cert.Reloadable, auditor *regattaserver.Auditor) *regattaserver.RegattaServer {
+	streamInterceptors := []grpc.StreamServerInterceptor{grpc_prometheus.StreamServerInterceptor, grpc_auth.StreamServerInterceptor(authFunc(viper.GetString("maintenance.token")))}
+	unaryInterceptors := []grpc.UnaryServerInterceptor{grpc_prometheus.UnaryServerInterceptor, grpc_auth.UnaryServerInterceptor(authFunc(viper.GetString("maintenance.token")))}
+	if auditor != nil {
+		streamInterceptors = append(streamInterceptors, auditor.StreamServerInterceptor())
+		unaryInterceptors = append(unaryInterceptors, auditor.UnaryServerInterceptor())
+	}
 	// Create regatta maintenance server
 	return regattaserver.NewServer(
 		viper.GetString("maintenance.address"),
@@ -53,8 +228,48 @@ func createMaintenanceServer(cert *cert.Reloadable) *regattaserver.RegattaServer
 			MinVersion:     tls.VersionTLS12,
 			GetCertificate: cert.GetCertificate,
 		})),
-		grpc.ChainStreamInterceptor(grpc_prometheus.StreamServerInterceptor, grpc_auth.StreamServerInterceptor(authFunc(viper.GetString("maintenance.token")))),
-		grpc.ChainUnaryInterceptor(grpc_prometheus.UnaryServerInterceptor, grpc_auth.UnaryServerInterceptor(authFunc(viper.GetString("maintenance.token")))),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+	)
+}
+
+// serveGateway serves the gateway by its own TLS server, the returned function stops the gateway server.
+func serveGateway(gw *regattaserver.Gateway, log *zap.SugaredLogger) func() {
+	c, err := cert.New(viper.GetString("api.cert-filename"), viper.GetString("api.key-filename"))
+	if err != nil {
+		log.Panicf("cannot load gateway certificate: %v", err)
+	}
+	tlsConfig, err := createAPITLSConfig(c)
+	if err != nil {
+		log.Panicf("cannot create gateway TLS config: %v", err)
+	}
+	gs := regattaserver.NewGatewayServer(viper.GetString("gateway.address"), gw, tlsConfig)
+	go func() {
+		if err := gs.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			log.Panicf("gateway listenAndServe failed: %v", err)
+		}
+	}()
+	return gs.Shutdown
+}
+
+// apiGateway returns the view of the gateway applying the API interceptors.
+func apiGateway(gw *regattaserver.Gateway, interceptors apiInterceptors) *regattaserver.Gateway {
+	if len(interceptors.unary) == 0 && len(interceptors.stream) == 0 {
+		return gw
+	}
+	return gw.WithInterceptors(grpc_middleware.ChainUnaryServer(interceptors.unary...), grpc_middleware.ChainStreamServer(interceptors.stream...))
+}
+
+// maintenanceGateway returns the view of the gateway checking the maintenance API token and auditing the requests by the auditor if set.
+func maintenanceGateway(gw *regattaserver.Gateway, auditor *regattaserver.Auditor) *regattaserver.Gateway {
+	token := viper.GetString("maintenance.token")
+	unary, stream := grpc_auth.UnaryServerInterceptor(authFunc(token)), grpc_auth.StreamServerInterceptor(authFunc(token))
+	if auditor == nil {
+		return gw.WithInterceptors(unary, stream)
+	}
+	return gw.WithInterceptors(
+		grpc_middleware.ChainUnaryServer(unary, auditor.UnaryServerInterceptor()),
+		grpc_middleware.ChainStreamServer(stream, auditor.StreamServerInterceptor()),
 	)
 }
 
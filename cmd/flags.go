@@ -15,10 +15,13 @@ var (
 	rootFlagSet         = pflag.NewFlagSet("root", pflag.ContinueOnError)
 	apiFlagSet          = pflag.NewFlagSet("api", pflag.ContinueOnError)
 	restFlagSet         = pflag.NewFlagSet("rest", pflag.ContinueOnError)
+	gatewayFlagSet      = pflag.NewFlagSet("gateway", pflag.ContinueOnError)
 	raftFlagSet         = pflag.NewFlagSet("raft", pflag.ContinueOnError)
 	memberlistFlagSet   = pflag.NewFlagSet("memberlist", pflag.ContinueOnError)
 	storageFlagSet      = pflag.NewFlagSet("storage", pflag.ContinueOnError)
 	maintenanceFlagSet  = pflag.NewFlagSet("maintenance", pflag.ContinueOnError)
+	auditFlagSet        = pflag.NewFlagSet("audit", pflag.ContinueOnError)
+	tracingFlagSet      = pflag.NewFlagSet("tracing", pflag.ContinueOnError)
 	experimentalFlagSet = pflag.NewFlagSet("experimental", pflag.ContinueOnError)
 )
 
@@ -32,11 +35,32 @@ func init() {
 	apiFlagSet.String("api.cert-filename", "hack/server.crt", "Path to the API server certificate.")
 	apiFlagSet.String("api.key-filename", "hack/server.key", "Path to the API server private key file.")
 	apiFlagSet.Bool("api.reflection-api", false, "Whether reflection API is enabled. Should be disabled in production.")
+	apiFlagSet.String("api.client-auth", "none", `Client certificate verification mode of the API server, one of none, request or require.
+If set to request the client certificates are verified if presented, if set to require the clients must present a verified certificate.`)
+	apiFlagSet.String("api.client-ca-filename", "", "Path to the bundle of CA certificates the API client certificates are verified by, the bundle is reloaded periodically.")
+	apiFlagSet.Float64("api.rate-limit-requests", 0, "Maximum number of API requests per second of a single client to a single table, if zero (default) the requests are not limited.")
+	apiFlagSet.Int("api.rate-limit-requests-burst", 0, "Number of API requests of a single client to a single table allowed to exceed the rate at once, defaults to the rate.")
+	apiFlagSet.Float64("api.rate-limit-write-bytes", 0, "Maximum size of the write requests in bytes per second of a single client to a single table, if zero (default) the writes are not limited.")
+	apiFlagSet.Int("api.rate-limit-write-bytes-burst", 0, "Size of the write requests in bytes of a single client to a single table allowed to exceed the rate at once, defaults to the rate.")
+	apiFlagSet.StringToString("api.rate-limit-table-requests", map[string]string{}, `Maximum number of API requests per second of a single client to the table overriding --api.rate-limit-requests for the listed tables.
+Zero rate disables the limit of the table. Example: "--api.rate-limit-table-requests=orders=100,sessions=0".`)
+	apiFlagSet.StringToString("api.rate-limit-table-requests-burst", map[string]string{}, "Number of API requests of a single client to the table allowed to exceed the rate at once overriding --api.rate-limit-requests-burst for the listed tables, defaults to the rate of the table.")
+	apiFlagSet.StringToString("api.rate-limit-table-write-bytes", map[string]string{}, `Maximum size of the write requests in bytes per second of a single client to the table overriding --api.rate-limit-write-bytes for the listed tables.
+Zero rate disables the limit of the table. Example: "--api.rate-limit-table-write-bytes=orders=1048576".`)
+	apiFlagSet.StringToString("api.rate-limit-table-write-bytes-burst", map[string]string{}, "Size of the write requests in bytes of a single client to the table allowed to exceed the rate at once overriding --api.rate-limit-write-bytes-burst for the listed tables, defaults to the rate of the table.")
+	apiFlagSet.Bool("api.auth-enabled", false, `Whether the API requests are authenticated and authorized by the users and roles managed by the maintenance API.
+The clients authenticate by the bearer token or by the client certificate common name.`)
+	apiFlagSet.StringSlice("api.auth-forwarders", nil, `Common names of the client certificates of the follower clusters trusted to forward the writes
+on behalf of the users authenticated by the client certificates in the follower clusters.`)
 
 	// REST API flags
 	restFlagSet.String("rest.address", ":8079", "REST API server address.")
 	restFlagSet.Duration("rest.read-timeout", time.Second*5, "Maximum duration for reading the entire request.")
 
+	// Gateway flags
+	gatewayFlagSet.Bool("gateway.enabled", false, "Whether the HTTP/JSON gateway of the KV and maintenance APIs is enabled.")
+	gatewayFlagSet.String("gateway.address", ":8446", "Gateway server address, the gateway is served over TLS with the API server certificate.")
+
 	// Raft flags
 	raftFlagSet.Duration("raft.rtt", 50*time.Millisecond,
 		`RTTMillisecond defines the average Round Trip Time (RTT) between two NodeHost instances.
@@ -106,6 +130,19 @@ At least one reachable Regatta instance is required to successfully bootstrap th
 	maintenanceFlagSet.String("maintenance.cert-filename", "hack/replication/server.crt", "Path to the API server certificate.")
 	maintenanceFlagSet.String("maintenance.key-filename", "hack/replication/server.key", "Path to the API server private key file.")
 	maintenanceFlagSet.String("maintenance.token", "", "Token to check for maintenance API access, if left empty (default) no token is checked.")
+
+	// Audit flags
+	auditFlagSet.String("audit.filename", "", `Path to the audit log recording the mutating requests of the API and maintenance API as newline delimited JSON,
+if left empty (default) the requests are not audited.`)
+	auditFlagSet.Int64("audit.max-size", 100*1024*1024, "Size of the audit log in bytes after which the log is rotated, if zero the log is never rotated.")
+	auditFlagSet.Int("audit.max-backups", 10, "Number of the rotated audit logs to keep.")
+
+	// Tracing flags
+	tracingFlagSet.String("tracing.exporter", "none", `Exporter of the trace spans of the API requests, one of none, stdout or file.
+The spans are exported as newline delimited JSON, the file exporter writes into the tracing.filename file rotated every 100MB.`)
+	tracingFlagSet.String("tracing.filename", "", "Path to the file the spans are exported into by the file exporter.")
+	tracingFlagSet.Float64("tracing.sample-ratio", 0.1, `Fraction of the API requests traced, the requests carrying the W3C traceparent metadata are traced
+if the trace is sampled by the caller.`)
 }
 
 func initConfig(set *pflag.FlagSet) {
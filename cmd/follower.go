@@ -3,9 +3,11 @@
 package cmd
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
+	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
@@ -20,6 +22,7 @@ import (
 	"github.com/jamf/regatta/regattaserver"
 	"github.com/jamf/regatta/replication"
 	"github.com/jamf/regatta/storage"
+	"github.com/jamf/regatta/tracing"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -27,6 +30,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
 )
 
 func init() {
@@ -34,10 +38,13 @@ func init() {
 	followerCmd.PersistentFlags().AddFlagSet(rootFlagSet)
 	followerCmd.PersistentFlags().AddFlagSet(apiFlagSet)
 	followerCmd.PersistentFlags().AddFlagSet(restFlagSet)
+	followerCmd.PersistentFlags().AddFlagSet(gatewayFlagSet)
 	followerCmd.PersistentFlags().AddFlagSet(raftFlagSet)
 	followerCmd.PersistentFlags().AddFlagSet(memberlistFlagSet)
 	followerCmd.PersistentFlags().AddFlagSet(storageFlagSet)
 	followerCmd.PersistentFlags().AddFlagSet(maintenanceFlagSet)
+	followerCmd.PersistentFlags().AddFlagSet(auditFlagSet)
+	followerCmd.PersistentFlags().AddFlagSet(tracingFlagSet)
 	followerCmd.PersistentFlags().AddFlagSet(experimentalFlagSet)
 
 	// Replication flags
@@ -55,6 +62,13 @@ func init() {
 	followerCmd.PersistentFlags().Uint64("replication.max-recv-message-size-bytes", 8*1024*1024, "The maximum size of single replication message allowed to receive.")
 	followerCmd.PersistentFlags().Uint64("replication.max-recovery-in-flight", 1, "The maximum number of recovery goroutines allowed to run in this instance.")
 	followerCmd.PersistentFlags().Uint64("replication.max-snapshot-recv-bytes-per-second", 0, "Maximum bytes per second received by the snapshot API client, default value 0 means unlimited.")
+	followerCmd.PersistentFlags().Bool("replication.forward-writes", false, "Whether the writes are forwarded to the leader cluster API instead of being rejected.")
+	followerCmd.PersistentFlags().String("replication.leader-api-address", "localhost:8443", "Address of the leader API the writes are forwarded to.")
+	followerCmd.PersistentFlags().String("replication.leader-api-ca-filename", "", "Path to the CA cert file of the leader API the writes are forwarded to, required if the writes are forwarded.")
+	followerCmd.PersistentFlags().String("replication.leader-api-cert-filename", "", `Path to the client certificate presented to the leader API the writes are forwarded to, no certificate is presented if empty.
+The leader cluster trusts the users of the forwarded writes authenticated by the client certificates if the common name of the certificate is listed in its --api.auth-forwarders.`)
+	followerCmd.PersistentFlags().String("replication.leader-api-key-filename", "", "Path to the private key file of the client certificate presented to the leader API.")
+	followerCmd.PersistentFlags().Bool("replication.forward-wait", false, "Whether the forwarded writes wait until the revision returned by the leader cluster is replicated to this cluster.")
 }
 
 var followerCmd = &cobra.Command{
@@ -75,6 +89,9 @@ func validateFollowerConfig() error {
 	if !viper.IsSet("raft.address") {
 		return errors.New("raft address must be set")
 	}
+	if viper.GetBool("replication.forward-writes") && viper.GetString("replication.leader-api-ca-filename") == "" {
+		return errors.New("leader API CA file must be set to forward writes")
+	}
 	return nil
 }
 
@@ -89,6 +106,15 @@ func follower(_ *cobra.Command, _ []string) {
 
 	autoSetMaxprocs(log)
 
+	tracer, err := createTracer()
+	if err != nil {
+		log.Panicf("cannot create tracer: %v", err)
+	}
+	if tracer != nil {
+		tracing.SetTracer(tracer)
+		defer tracer.Close()
+	}
+
 	// Check signals
 	shutdown := make(chan os.Signal, 1)
 	signal.Notify(shutdown, os.Interrupt, syscall.SIGTERM)
@@ -194,6 +220,11 @@ func follower(_ *cobra.Command, _ []string) {
 
 	// Start servers
 	{
+		gw := regattaserver.NewGateway()
+		auditor, err := createAuditor()
+		if err != nil {
+			log.Panicf("cannot open audit file: %v", err)
+		}
 		{
 			grpc_prometheus.EnableHandlingTimeHistogram(grpc_prometheus.WithHistogramBuckets(histogramBuckets))
 			// Create regatta API server
@@ -203,11 +234,39 @@ func follower(_ *cobra.Command, _ []string) {
 				log.Panicf("cannot load certificate: %v", err)
 			}
 			// Create server
-			regatta := createAPIServer(c)
-			regattapb.RegisterKVServer(regatta, &regattaserver.ReadonlyKVServer{
+			interceptors, err := createAPIInterceptors(engine, engine, auditor)
+			if err != nil {
+				log.Panicf("cannot create API interceptors: %v", err)
+			}
+			tlsConfig, err := createAPITLSConfig(c)
+			if err != nil {
+				log.Panicf("cannot create API TLS config: %v", err)
+			}
+			regatta := createAPIServer(tlsConfig, interceptors)
+			kv := &regattaserver.ReadonlyKVServer{
 				KVServer: regattaserver.KVServer{
 					Storage: engine,
 				},
+			}
+			if viper.GetBool("replication.forward-writes") {
+				conn, err := createForwardConn()
+				if err != nil {
+					log.Panicf("cannot create forward conn: %v", err)
+				}
+				defer func() {
+					_ = conn.Close()
+				}()
+				kv.Leader = regattapb.NewKVClient(conn)
+				if viper.GetBool("replication.forward-wait") {
+					kv.Replication = engine
+				}
+			}
+			regattapb.RegisterKVServer(regatta, kv)
+			regattapb.RegisterKVServer(apiGateway(gw, interceptors), kv)
+			regattapb.RegisterLeaseServer(regatta, &regattaserver.ReadonlyLeaseServer{
+				LeaseServer: regattaserver.LeaseServer{
+					Storage: engine,
+				},
 			})
 			// Start server
 			go func() {
@@ -226,8 +285,9 @@ func follower(_ *cobra.Command, _ []string) {
 				log.Panicf("cannot load maintenance certificate: %v", err)
 			}
 
-			maintenance := createMaintenanceServer(c)
+			maintenance := createMaintenanceServer(c, auditor)
 			regattapb.RegisterMaintenanceServer(maintenance, &regattaserver.ResetServer{Tables: engine})
+			regattapb.RegisterMaintenanceServer(maintenanceGateway(gw, auditor), &regattaserver.ResetServer{Tables: engine})
 			// Start server
 			go func() {
 				log.Infof("regatta maintenance listening at %s", maintenance.Addr)
@@ -238,6 +298,10 @@ func follower(_ *cobra.Command, _ []string) {
 			defer maintenance.Shutdown()
 		}
 
+		if viper.GetBool("gateway.enabled") {
+			defer serveGateway(gw, log)()
+		}
+
 		// Create REST server
 		hs := regattaserver.NewRESTServer(viper.GetString("rest.address"), viper.GetDuration("rest.read-timeout"))
 		go func() {
@@ -253,6 +317,58 @@ func follower(_ *cobra.Command, _ []string) {
 	log.Info("shutting down...")
 }
 
+func createForwardConn() (*grpc.ClientConn, error) {
+	caBytes, err := os.ReadFile(viper.GetString("replication.leader-api-ca-filename"))
+	if err != nil {
+		return nil, err
+	}
+	cp := x509.NewCertPool()
+	if !cp.AppendCertsFromPEM(caBytes) {
+		return nil, fmt.Errorf("no certificates found in %s", viper.GetString("replication.leader-api-ca-filename"))
+	}
+	tlsConfig := &tls.Config{
+		RootCAs:    cp,
+		MinVersion: tls.VersionTLS12,
+	}
+	if viper.GetString("replication.leader-api-cert-filename") != "" {
+		c, err := cert.New(viper.GetString("replication.leader-api-cert-filename"), viper.GetString("replication.leader-api-key-filename"))
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.GetClientCertificate = c.GetClientCertificate
+	}
+	creds := credentials.NewTLS(tlsConfig)
+
+	return grpc.Dial(viper.GetString("replication.leader-api-address"),
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultServiceConfig(`{"loadBalancingConfig": [{"round_robin":{}}]}`),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                viper.GetDuration("replication.keepalive-time"),
+			Timeout:             viper.GetDuration("replication.keepalive-timeout"),
+			PermitWithoutStream: true,
+		}),
+		grpc.WithChainUnaryInterceptor(tracing.UnaryClientInterceptor(), func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			return invoker(forwardAuthorization(ctx), method, req, reply, cc, opts...)
+		}),
+		grpc.WithChainStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return streamer(tracing.Inject(forwardAuthorization(ctx)), desc, cc, method, opts...)
+		}),
+	)
+}
+
+// forwardAuthorization passes the bearer token of the client to the leader cluster, the users are replicated
+// from the leader cluster so the forwarded writes are authorized the same way. The users authenticated by the client
+// certificate are passed by name, the leader cluster trusts the name if this cluster is one of its forwarders.
+func forwardAuthorization(ctx context.Context) context.Context {
+	if vals := metadata.ValueFromIncomingContext(ctx, "authorization"); len(vals) > 0 {
+		return metadata.AppendToOutgoingContext(ctx, "authorization", vals[0])
+	}
+	if user, ok := regattaserver.UserFromContext(ctx); ok {
+		return metadata.AppendToOutgoingContext(ctx, regattaserver.ForwardedUserHeader, user)
+	}
+	return ctx
+}
+
 func createReplicationConn(cp *x509.CertPool, cer *cert.Reloadable) (*grpc.ClientConn, error) {
 	creds := credentials.NewTLS(&tls.Config{
 		RootCAs:              cp,
@@ -21,6 +21,7 @@ import (
 	"github.com/jamf/regatta/regattaserver"
 	"github.com/jamf/regatta/storage"
 	serrors "github.com/jamf/regatta/storage/errors"
+	"github.com/jamf/regatta/tracing"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
@@ -34,15 +35,22 @@ func init() {
 	leaderCmd.PersistentFlags().AddFlagSet(rootFlagSet)
 	leaderCmd.PersistentFlags().AddFlagSet(apiFlagSet)
 	leaderCmd.PersistentFlags().AddFlagSet(restFlagSet)
+	leaderCmd.PersistentFlags().AddFlagSet(gatewayFlagSet)
 	leaderCmd.PersistentFlags().AddFlagSet(raftFlagSet)
 	leaderCmd.PersistentFlags().AddFlagSet(memberlistFlagSet)
 	leaderCmd.PersistentFlags().AddFlagSet(storageFlagSet)
 	leaderCmd.PersistentFlags().AddFlagSet(maintenanceFlagSet)
+	leaderCmd.PersistentFlags().AddFlagSet(auditFlagSet)
+	leaderCmd.PersistentFlags().AddFlagSet(tracingFlagSet)
 	leaderCmd.PersistentFlags().AddFlagSet(experimentalFlagSet)
 
 	// Tables flags
 	leaderCmd.PersistentFlags().StringSlice("tables.names", nil, "Create Regatta tables with given names.")
 	leaderCmd.PersistentFlags().StringSlice("tables.delete", nil, "Delete Regatta tables with given names.")
+	leaderCmd.PersistentFlags().Uint64("tables.history-retention", 100000, "Number of the most recent revisions whose history is kept for historical reads, the older history is compacted. Zero disables the automatic compaction.")
+	leaderCmd.PersistentFlags().Duration("tables.heartbeat-interval", 0, `How often are the heartbeats proposed into the tables, the heartbeats bound the staleness of the reads requesting max staleness.
+If zero (default) the heartbeats are disabled and the reads requesting max staleness fail. The heartbeat timestamps are compared with the clocks
+of the follower clusters, the clocks of all the clusters must be synchronized (e.g. by NTP) as the clock skew adds to the staleness.`)
 
 	// Replication flags
 	leaderCmd.PersistentFlags().Bool("replication.enabled", true, "Whether replication API is enabled.")
@@ -84,6 +92,15 @@ func leader(_ *cobra.Command, _ []string) {
 
 	autoSetMaxprocs(log)
 
+	tracer, err := createTracer()
+	if err != nil {
+		log.Panicf("cannot create tracer: %v", err)
+	}
+	if tracer != nil {
+		tracing.SetTracer(tracer)
+		defer tracer.Close()
+	}
+
 	// Check signals
 	shutdown := make(chan os.Signal, 1)
 	signal.Notify(shutdown, os.Interrupt, syscall.SIGTERM)
@@ -106,6 +123,9 @@ func leader(_ *cobra.Command, _ []string) {
 		MaxReceiveQueueSize: viper.GetUint64("raft.max-recv-queue-size"),
 		MaxSendQueueSize:    viper.GetUint64("raft.max-send-queue-size"),
 		LogCacheSize:        viper.GetInt("replication.log-cache-size"),
+		ExpireLeases:        true,
+		HistoryRetention:    viper.GetUint64("tables.history-retention"),
+		HeartbeatInterval:   viper.GetDuration("tables.heartbeat-interval"),
 		Gossip: storage.GossipConfig{
 			BindAddress:      viper.GetString("memberlist.address"),
 			AdvertiseAddress: viper.GetString("memberlist.advertise-address"),
@@ -180,6 +200,11 @@ func leader(_ *cobra.Command, _ []string) {
 
 	// Start servers
 	{
+		gw := regattaserver.NewGateway()
+		auditor, err := createAuditor()
+		if err != nil {
+			log.Panicf("cannot open audit file: %v", err)
+		}
 		grpc_prometheus.EnableHandlingTimeHistogram(grpc_prometheus.WithHistogramBuckets(histogramBuckets))
 		// Create regatta API server
 		{
@@ -189,10 +214,35 @@ func leader(_ *cobra.Command, _ []string) {
 				log.Panicf("cannot load certificate: %v", err)
 			}
 			// Create server
-			regatta := createAPIServer(c)
-			regattapb.RegisterKVServer(regatta, &regattaserver.KVServer{
+			interceptors, err := createAPIInterceptors(engine, engine, auditor)
+			if err != nil {
+				log.Panicf("cannot create API interceptors: %v", err)
+			}
+			tlsConfig, err := createAPITLSConfig(c)
+			if err != nil {
+				log.Panicf("cannot create API TLS config: %v", err)
+			}
+			regatta := createAPIServer(tlsConfig, interceptors)
+			kv := &regattaserver.KVServer{
+				Storage:   engine,
+				Tables:    engine,
+				LogReader: engine.LogReader,
+			}
+			regattapb.RegisterKVServer(regatta, kv)
+			regattapb.RegisterKVServer(apiGateway(gw, interceptors), kv)
+			regattapb.RegisterLeaseServer(regatta, &regattaserver.LeaseServer{
 				Storage: engine,
 			})
+			regattapb.RegisterLockServer(regatta, &regattaserver.LockServer{
+				Storage:   engine,
+				Tables:    engine,
+				LogReader: engine.LogReader,
+			})
+			regattapb.RegisterElectionServer(regatta, &regattaserver.ElectionServer{
+				Storage:   engine,
+				Tables:    engine,
+				LogReader: engine.LogReader,
+			})
 			// Start server
 			go func() {
 				log.Infof("regatta listening at %s", regatta.Addr)
@@ -221,7 +271,7 @@ func leader(_ *cobra.Command, _ []string) {
 				logger,
 				viper.GetUint64("replication.max-send-message-size-bytes"),
 			)
-			regattapb.RegisterMetadataServer(replication, &regattaserver.MetadataServer{Tables: engine})
+			regattapb.RegisterMetadataServer(replication, &regattaserver.MetadataServer{Tables: engine, Auth: engine})
 			regattapb.RegisterSnapshotServer(replication, &regattaserver.SnapshotServer{Tables: engine})
 			regattapb.RegisterLogServer(replication, ls)
 			// Start server
@@ -241,9 +291,10 @@ func leader(_ *cobra.Command, _ []string) {
 				log.Panicf("cannot load maintenance certificate: %v", err)
 			}
 
-			maintenance := createMaintenanceServer(c)
+			maintenance := createMaintenanceServer(c, auditor)
 			regattapb.RegisterMetadataServer(maintenance, &regattaserver.MetadataServer{Tables: engine})
-			regattapb.RegisterMaintenanceServer(maintenance, &regattaserver.BackupServer{Tables: engine})
+			regattapb.RegisterMaintenanceServer(maintenance, &regattaserver.BackupServer{Tables: engine, Auth: engine})
+			regattapb.RegisterMaintenanceServer(maintenanceGateway(gw, auditor), &regattaserver.BackupServer{Tables: engine, Auth: engine})
 			// Start server
 			go func() {
 				log.Infof("regatta maintenance listening at %s", maintenance.Addr)
@@ -254,6 +305,10 @@ func leader(_ *cobra.Command, _ []string) {
 			defer maintenance.Shutdown()
 		}
 
+		if viper.GetBool("gateway.enabled") {
+			defer serveGateway(gw, log)()
+		}
+
 		// Create REST server
 		hs := regattaserver.NewRESTServer(viper.GetString("rest.address"), viper.GetDuration("rest.read-timeout"))
 		go func() {
@@ -0,0 +1,88 @@
+// Copyright JAMF Software, LLC
+
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotatingFile_Write(t *testing.T) {
+	r := require.New(t)
+	path := filepath.Join(t.TempDir(), "audit.log")
+	r.NoError(os.WriteFile(path, []byte("0000\n"), 0o600))
+
+	t.Log("append to the existing file")
+	f, err := NewRotatingFile(path, 10, 2)
+	r.NoError(err)
+	_, err = f.Write([]byte("1111\n"))
+	r.NoError(err)
+	requireFile(t, path, "0000\n1111\n")
+
+	t.Log("rotate the full file")
+	for _, line := range []string{"2222\n", "3333\n", "4444\n", "5555\n", "6666\n"} {
+		_, err = f.Write([]byte(line))
+		r.NoError(err)
+	}
+	requireFile(t, path, "6666\n")
+	requireFile(t, path+".1", "4444\n5555\n")
+	requireFile(t, path+".2", "2222\n3333\n")
+	r.NoFileExists(path + ".3")
+
+	t.Log("write larger than the max size")
+	_, err = f.Write([]byte("777777777777\n"))
+	r.NoError(err)
+	requireFile(t, path, "777777777777\n")
+	requireFile(t, path+".1", "6666\n")
+
+	r.NoError(f.Close())
+	_, err = f.Write([]byte("8888\n"))
+	r.ErrorIs(err, os.ErrClosed)
+}
+
+func TestRotatingFile_NoBackups(t *testing.T) {
+	r := require.New(t)
+	path := filepath.Join(t.TempDir(), "audit.log")
+	f, err := NewRotatingFile(path, 5, 0)
+	r.NoError(err)
+	defer f.Close()
+	for _, line := range []string{"1111\n", "2222\n"} {
+		_, err = f.Write([]byte(line))
+		r.NoError(err)
+	}
+	requireFile(t, path, "2222\n")
+	r.NoFileExists(path + ".1")
+}
+
+func TestRotatingFile_RotationFailed(t *testing.T) {
+	r := require.New(t)
+	path := filepath.Join(t.TempDir(), "audit.log")
+	f, err := NewRotatingFile(path, 5, 1)
+	r.NoError(err)
+	defer f.Close()
+	_, err = f.Write([]byte("1111\n"))
+	r.NoError(err)
+
+	t.Log("the oldest backup cannot be removed")
+	r.NoError(os.MkdirAll(filepath.Join(path+".1", "dir"), 0o700))
+	_, err = f.Write([]byte("2222\n"))
+	r.NoError(err)
+	requireFile(t, path, "1111\n2222\n")
+
+	t.Log("the rotation is retried by the next write")
+	r.NoError(os.RemoveAll(path + ".1"))
+	_, err = f.Write([]byte("3333\n"))
+	r.NoError(err)
+	requireFile(t, path, "3333\n")
+	requireFile(t, path+".1", "1111\n2222\n")
+}
+
+func requireFile(t *testing.T, path, content string) {
+	t.Helper()
+	bts, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, content, string(bts))
+}
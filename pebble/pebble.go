@@ -129,6 +129,15 @@ func WithTableCache(cache *pebble.TableCache) Option {
 	}}
 }
 
+// WithCompression sets the compression of the data blocks in all the levels.
+func WithCompression(compression pebble.Compression) Option {
+	return &funcOption{func(options *pebble.Options) {
+		for i := range options.Levels {
+			options.Levels[i].Compression = compression
+		}
+	}}
+}
+
 func WithLogger(logger pebble.Logger) Option {
 	return &funcOption{func(options *pebble.Options) {
 		options.Logger = logger
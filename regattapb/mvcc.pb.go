@@ -26,37 +26,110 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// SortOrder is the order of the key-value pairs of a range by the key.
+type SortOrder int32
+
+const (
+	// ASCEND returns the key-value pairs from the lowest key up.
+	SortOrder_ASCEND SortOrder = 0
+	// DESCEND returns the key-value pairs from the highest key down.
+	SortOrder_DESCEND SortOrder = 1
+)
+
+// Enum value maps for SortOrder.
+var (
+	SortOrder_name = map[int32]string{
+		0: "ASCEND",
+		1: "DESCEND",
+	}
+	SortOrder_value = map[string]int32{
+		"ASCEND":  0,
+		"DESCEND": 1,
+	}
+)
+
+func (x SortOrder) Enum() *SortOrder {
+	p := new(SortOrder)
+	*p = x
+	return p
+}
+
+func (x SortOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_mvcc_proto_enumTypes[0].Descriptor()
+}
+
+func (SortOrder) Type() protoreflect.EnumType {
+	return &file_mvcc_proto_enumTypes[0]
+}
+
+func (x SortOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortOrder.Descriptor instead.
+func (SortOrder) EnumDescriptor() ([]byte, []int) {
+	return file_mvcc_proto_rawDescGZIP(), []int{0}
+}
+
 type Command_CommandType int32
 
 const (
-	Command_PUT          Command_CommandType = 0
-	Command_DELETE       Command_CommandType = 1
-	Command_DUMMY        Command_CommandType = 2
-	Command_PUT_BATCH    Command_CommandType = 3
-	Command_DELETE_BATCH Command_CommandType = 4
-	Command_TXN          Command_CommandType = 5
-	Command_SEQUENCE     Command_CommandType = 6
+	Command_PUT              Command_CommandType = 0
+	Command_DELETE           Command_CommandType = 1
+	Command_DUMMY            Command_CommandType = 2
+	Command_PUT_BATCH        Command_CommandType = 3
+	Command_DELETE_BATCH     Command_CommandType = 4
+	Command_TXN              Command_CommandType = 5
+	Command_SEQUENCE         Command_CommandType = 6
+	Command_LEASE_GRANT      Command_CommandType = 7
+	Command_LEASE_REVOKE     Command_CommandType = 8
+	Command_LEASE_KEEP_ALIVE Command_CommandType = 9
+	Command_COMPACT          Command_CommandType = 10
+	Command_PUT_CHUNK        Command_CommandType = 11
+	Command_PUT_LARGE        Command_CommandType = 12
+	Command_DISCARD_CHUNKS   Command_CommandType = 13
+	Command_REQUEST_RESULT   Command_CommandType = 14
 )
 
 // Enum value maps for Command_CommandType.
 var (
 	Command_CommandType_name = map[int32]string{
-		0: "PUT",
-		1: "DELETE",
-		2: "DUMMY",
-		3: "PUT_BATCH",
-		4: "DELETE_BATCH",
-		5: "TXN",
-		6: "SEQUENCE",
+		0:  "PUT",
+		1:  "DELETE",
+		2:  "DUMMY",
+		3:  "PUT_BATCH",
+		4:  "DELETE_BATCH",
+		5:  "TXN",
+		6:  "SEQUENCE",
+		7:  "LEASE_GRANT",
+		8:  "LEASE_REVOKE",
+		9:  "LEASE_KEEP_ALIVE",
+		10: "COMPACT",
+		11: "PUT_CHUNK",
+		12: "PUT_LARGE",
+		13: "DISCARD_CHUNKS",
+		14: "REQUEST_RESULT",
 	}
 	Command_CommandType_value = map[string]int32{
-		"PUT":          0,
-		"DELETE":       1,
-		"DUMMY":        2,
-		"PUT_BATCH":    3,
-		"DELETE_BATCH": 4,
-		"TXN":          5,
-		"SEQUENCE":     6,
+		"PUT":              0,
+		"DELETE":           1,
+		"DUMMY":            2,
+		"PUT_BATCH":        3,
+		"DELETE_BATCH":     4,
+		"TXN":              5,
+		"SEQUENCE":         6,
+		"LEASE_GRANT":      7,
+		"LEASE_REVOKE":     8,
+		"LEASE_KEEP_ALIVE": 9,
+		"COMPACT":          10,
+		"PUT_CHUNK":        11,
+		"PUT_LARGE":        12,
+		"DISCARD_CHUNKS":   13,
+		"REQUEST_RESULT":   14,
 	}
 )
 
@@ -71,11 +144,11 @@ func (x Command_CommandType) String() string {
 }
 
 func (Command_CommandType) Descriptor() protoreflect.EnumDescriptor {
-	return file_mvcc_proto_enumTypes[0].Descriptor()
+	return file_mvcc_proto_enumTypes[1].Descriptor()
 }
 
 func (Command_CommandType) Type() protoreflect.EnumType {
-	return &file_mvcc_proto_enumTypes[0]
+	return &file_mvcc_proto_enumTypes[1]
 }
 
 func (x Command_CommandType) Number() protoreflect.EnumNumber {
@@ -123,11 +196,11 @@ func (x Compare_CompareResult) String() string {
 }
 
 func (Compare_CompareResult) Descriptor() protoreflect.EnumDescriptor {
-	return file_mvcc_proto_enumTypes[1].Descriptor()
+	return file_mvcc_proto_enumTypes[2].Descriptor()
 }
 
 func (Compare_CompareResult) Type() protoreflect.EnumType {
-	return &file_mvcc_proto_enumTypes[1]
+	return &file_mvcc_proto_enumTypes[2]
 }
 
 func (x Compare_CompareResult) Number() protoreflect.EnumNumber {
@@ -136,22 +209,34 @@ func (x Compare_CompareResult) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Compare_CompareResult.Descriptor instead.
 func (Compare_CompareResult) EnumDescriptor() ([]byte, []int) {
-	return file_mvcc_proto_rawDescGZIP(), []int{5, 0}
+	return file_mvcc_proto_rawDescGZIP(), []int{9, 0}
 }
 
 type Compare_CompareTarget int32
 
 const (
-	Compare_VALUE Compare_CompareTarget = 0
+	Compare_VALUE   Compare_CompareTarget = 0
+	Compare_VERSION Compare_CompareTarget = 1
+	Compare_CREATE  Compare_CompareTarget = 2
+	Compare_MOD     Compare_CompareTarget = 3
+	Compare_LEASE   Compare_CompareTarget = 4
 )
 
 // Enum value maps for Compare_CompareTarget.
 var (
 	Compare_CompareTarget_name = map[int32]string{
 		0: "VALUE",
+		1: "VERSION",
+		2: "CREATE",
+		3: "MOD",
+		4: "LEASE",
 	}
 	Compare_CompareTarget_value = map[string]int32{
-		"VALUE": 0,
+		"VALUE":   0,
+		"VERSION": 1,
+		"CREATE":  2,
+		"MOD":     3,
+		"LEASE":   4,
 	}
 )
 
@@ -166,11 +251,11 @@ func (x Compare_CompareTarget) String() string {
 }
 
 func (Compare_CompareTarget) Descriptor() protoreflect.EnumDescriptor {
-	return file_mvcc_proto_enumTypes[2].Descriptor()
+	return file_mvcc_proto_enumTypes[3].Descriptor()
 }
 
 func (Compare_CompareTarget) Type() protoreflect.EnumType {
-	return &file_mvcc_proto_enumTypes[2]
+	return &file_mvcc_proto_enumTypes[3]
 }
 
 func (x Compare_CompareTarget) Number() protoreflect.EnumNumber {
@@ -179,7 +264,53 @@ func (x Compare_CompareTarget) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Compare_CompareTarget.Descriptor instead.
 func (Compare_CompareTarget) EnumDescriptor() ([]byte, []int) {
-	return file_mvcc_proto_rawDescGZIP(), []int{5, 1}
+	return file_mvcc_proto_rawDescGZIP(), []int{9, 1}
+}
+
+type Event_EventType int32
+
+const (
+	Event_PUT    Event_EventType = 0
+	Event_DELETE Event_EventType = 1
+)
+
+// Enum value maps for Event_EventType.
+var (
+	Event_EventType_name = map[int32]string{
+		0: "PUT",
+		1: "DELETE",
+	}
+	Event_EventType_value = map[string]int32{
+		"PUT":    0,
+		"DELETE": 1,
+	}
+)
+
+func (x Event_EventType) Enum() *Event_EventType {
+	p := new(Event_EventType)
+	*p = x
+	return p
+}
+
+func (x Event_EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Event_EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_mvcc_proto_enumTypes[4].Descriptor()
+}
+
+func (Event_EventType) Type() protoreflect.EnumType {
+	return &file_mvcc_proto_enumTypes[4]
+}
+
+func (x Event_EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Event_EventType.Descriptor instead.
+func (Event_EventType) EnumDescriptor() ([]byte, []int) {
+	return file_mvcc_proto_rawDescGZIP(), []int{12, 0}
 }
 
 type Command struct {
@@ -217,6 +348,33 @@ type Command struct {
 	Sequence []*Command `protobuf:"bytes,10,rep,name=sequence,proto3" json:"sequence,omitempty"`
 	// count if to count number of records affected by a command.
 	Count bool `protobuf:"varint,11,opt,name=count,proto3" json:"count,omitempty"`
+	// lease is the subject of the LEASE_GRANT, LEASE_REVOKE and LEASE_KEEP_ALIVE commands.
+	// The batch of a LEASE_REVOKE command holds the keys attached to the lease when the revocation was proposed,
+	// the revocation is applied only if the keys still match.
+	Lease *Lease `protobuf:"bytes,12,opt,name=lease,proto3,oneof" json:"lease,omitempty"`
+	// timestamp is the wall clock time (unix nanoseconds) of the proposal, the expiration of leases is computed from it.
+	// The timestamp of a DUMMY command is a heartbeat, the replica which applied it has applied all the commands proposed before the time.
+	Timestamp int64 `protobuf:"varint,13,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// compact_revision is the revision of the COMPACT command, the history of the keys older than the revision is discarded.
+	CompactRevision int64 `protobuf:"varint,14,opt,name=compact_revision,json=compactRevision,proto3" json:"compact_revision,omitempty"`
+	// chunk is the part of a large value stored by the PUT_CHUNK command. The DISCARD_CHUNKS command discards
+	// all the chunks of the large value identified by the chunk id.
+	Chunk *Chunk `protobuf:"bytes,15,opt,name=chunk,proto3,oneof" json:"chunk,omitempty"`
+	// large_value references the chunks of the value of the PUT_LARGE command, the kv holds the key and the lease.
+	// The value becomes visible only once the PUT_LARGE command is applied.
+	LargeValue *LargeValue `protobuf:"bytes,16,opt,name=large_value,json=largeValue,proto3,oneof" json:"large_value,omitempty"`
+	// request_id is the client supplied ID of the request the command was proposed for. The command is not applied
+	// if a command with the same request ID was applied within the deduplication window, the result of the original
+	// command is returned instead.
+	RequestId []byte `protobuf:"bytes,17,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// request_result is the stored result of a command applied with the request ID set by the REQUEST_RESULT command,
+	// the command restores the deduplication window from a snapshot.
+	RequestResult *RequestResult `protobuf:"bytes,18,opt,name=request_result,json=requestResult,proto3,oneof" json:"request_result,omitempty"`
+	// traceparent is the W3C trace context of the traced request the command was proposed for, the state machine records
+	// the span of applying the command as a part of the trace. The Raft log entry is the only data shared with the state
+	// machines of all the replicas, so the trace context is persisted along with the command. It is set only for the sampled
+	// requests, adding 55 bytes to their entries, and it is dropped from the commands replicated to the follower clusters.
+	Traceparent string `protobuf:"bytes,19,opt,name=traceparent,proto3" json:"traceparent,omitempty"`
 }
 
 func (x *Command) Reset() {
@@ -321,6 +479,282 @@ func (x *Command) GetCount() bool {
 	return false
 }
 
+func (x *Command) GetLease() *Lease {
+	if x != nil {
+		return x.Lease
+	}
+	return nil
+}
+
+func (x *Command) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *Command) GetCompactRevision() int64 {
+	if x != nil {
+		return x.CompactRevision
+	}
+	return 0
+}
+
+func (x *Command) GetChunk() *Chunk {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+func (x *Command) GetLargeValue() *LargeValue {
+	if x != nil {
+		return x.LargeValue
+	}
+	return nil
+}
+
+func (x *Command) GetRequestId() []byte {
+	if x != nil {
+		return x.RequestId
+	}
+	return nil
+}
+
+func (x *Command) GetRequestResult() *RequestResult {
+	if x != nil {
+		return x.RequestResult
+	}
+	return nil
+}
+
+func (x *Command) GetTraceparent() string {
+	if x != nil {
+		return x.Traceparent
+	}
+	return ""
+}
+
+// RequestResult is the result of a command applied with the request ID, it is kept to deduplicate the retries of the request.
+type RequestResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// request_id is the client supplied ID of the request.
+	RequestId []byte `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// revision is the revision the command was applied at.
+	Revision uint64 `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	// result is the result code of the command.
+	Result uint64 `protobuf:"varint,3,opt,name=result,proto3" json:"result,omitempty"`
+	// command_result is the result of the command returned to the client. The key-value pairs are dropped from the result
+	// larger than 1KiB, the retry of such a command returns only the revision and the counts.
+	CommandResult *CommandResult `protobuf:"bytes,4,opt,name=command_result,json=commandResult,proto3" json:"command_result,omitempty"`
+	// command_hash is the SHA-256 hash of the command, a retry with the same request ID has to carry the same command.
+	CommandHash []byte `protobuf:"bytes,5,opt,name=command_hash,json=commandHash,proto3" json:"command_hash,omitempty"`
+}
+
+func (x *RequestResult) Reset() {
+	*x = RequestResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mvcc_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestResult) ProtoMessage() {}
+
+func (x *RequestResult) ProtoReflect() protoreflect.Message {
+	mi := &file_mvcc_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestResult.ProtoReflect.Descriptor instead.
+func (*RequestResult) Descriptor() ([]byte, []int) {
+	return file_mvcc_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RequestResult) GetRequestId() []byte {
+	if x != nil {
+		return x.RequestId
+	}
+	return nil
+}
+
+func (x *RequestResult) GetRevision() uint64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *RequestResult) GetResult() uint64 {
+	if x != nil {
+		return x.Result
+	}
+	return 0
+}
+
+func (x *RequestResult) GetCommandResult() *CommandResult {
+	if x != nil {
+		return x.CommandResult
+	}
+	return nil
+}
+
+func (x *RequestResult) GetCommandHash() []byte {
+	if x != nil {
+		return x.CommandHash
+	}
+	return nil
+}
+
+// Chunk is a part of a value larger than the max size of a single value.
+type Chunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// id identifies the large value the chunk belongs to.
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// index is the position of the chunk within the large value.
+	Index uint32 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	// data is the data of the chunk.
+	Data []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *Chunk) Reset() {
+	*x = Chunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mvcc_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Chunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Chunk) ProtoMessage() {}
+
+func (x *Chunk) ProtoReflect() protoreflect.Message {
+	mi := &file_mvcc_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Chunk.ProtoReflect.Descriptor instead.
+func (*Chunk) Descriptor() ([]byte, []int) {
+	return file_mvcc_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Chunk) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Chunk) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *Chunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// LargeValue references the chunks of a value larger than the max size of a single value.
+type LargeValue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// id identifies the chunks of the large value.
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// chunks is the number of chunks of the large value.
+	Chunks uint32 `protobuf:"varint,2,opt,name=chunks,proto3" json:"chunks,omitempty"`
+	// size is the size of the large value in bytes.
+	Size uint64 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+}
+
+func (x *LargeValue) Reset() {
+	*x = LargeValue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mvcc_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LargeValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LargeValue) ProtoMessage() {}
+
+func (x *LargeValue) ProtoReflect() protoreflect.Message {
+	mi := &file_mvcc_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LargeValue.ProtoReflect.Descriptor instead.
+func (*LargeValue) Descriptor() ([]byte, []int) {
+	return file_mvcc_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LargeValue) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *LargeValue) GetChunks() uint32 {
+	if x != nil {
+		return x.Chunks
+	}
+	return 0
+}
+
+func (x *LargeValue) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
 type CommandResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -330,25 +764,93 @@ type CommandResult struct {
 	Responses []*ResponseOp `protobuf:"bytes,1,rep,name=responses,proto3" json:"responses,omitempty"`
 	// revision is the key-value store revision when the request was applied.
 	Revision uint64 `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	// lease is the state of the lease after the LEASE_GRANT or LEASE_KEEP_ALIVE command was applied.
+	Lease *Lease `protobuf:"bytes,3,opt,name=lease,proto3" json:"lease,omitempty"`
 }
 
 func (x *CommandResult) Reset() {
 	*x = CommandResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mvcc_proto_msgTypes[1]
+		mi := &file_mvcc_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommandResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommandResult) ProtoMessage() {}
+
+func (x *CommandResult) ProtoReflect() protoreflect.Message {
+	mi := &file_mvcc_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommandResult.ProtoReflect.Descriptor instead.
+func (*CommandResult) Descriptor() ([]byte, []int) {
+	return file_mvcc_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CommandResult) GetResponses() []*ResponseOp {
+	if x != nil {
+		return x.Responses
+	}
+	return nil
+}
+
+func (x *CommandResult) GetRevision() uint64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *CommandResult) GetLease() *Lease {
+	if x != nil {
+		return x.Lease
+	}
+	return nil
+}
+
+type Lease struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ID is the lease ID.
+	ID int64 `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	// TTL is the time-to-live of the lease in seconds.
+	TTL int64 `protobuf:"varint,2,opt,name=TTL,proto3" json:"TTL,omitempty"`
+	// expires_at is the wall clock time (unix nanoseconds) when the lease expires unless it is kept alive.
+	ExpiresAt int64 `protobuf:"varint,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+}
+
+func (x *Lease) Reset() {
+	*x = Lease{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mvcc_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CommandResult) String() string {
+func (x *Lease) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CommandResult) ProtoMessage() {}
+func (*Lease) ProtoMessage() {}
 
-func (x *CommandResult) ProtoReflect() protoreflect.Message {
-	mi := &file_mvcc_proto_msgTypes[1]
+func (x *Lease) ProtoReflect() protoreflect.Message {
+	mi := &file_mvcc_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -359,21 +861,28 @@ func (x *CommandResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CommandResult.ProtoReflect.Descriptor instead.
-func (*CommandResult) Descriptor() ([]byte, []int) {
-	return file_mvcc_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use Lease.ProtoReflect.Descriptor instead.
+func (*Lease) Descriptor() ([]byte, []int) {
+	return file_mvcc_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *CommandResult) GetResponses() []*ResponseOp {
+func (x *Lease) GetID() int64 {
 	if x != nil {
-		return x.Responses
+		return x.ID
 	}
-	return nil
+	return 0
 }
 
-func (x *CommandResult) GetRevision() uint64 {
+func (x *Lease) GetTTL() int64 {
 	if x != nil {
-		return x.Revision
+		return x.TTL
+	}
+	return 0
+}
+
+func (x *Lease) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
 	}
 	return 0
 }
@@ -398,7 +907,7 @@ type Txn struct {
 func (x *Txn) Reset() {
 	*x = Txn{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mvcc_proto_msgTypes[2]
+		mi := &file_mvcc_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -411,7 +920,7 @@ func (x *Txn) String() string {
 func (*Txn) ProtoMessage() {}
 
 func (x *Txn) ProtoReflect() protoreflect.Message {
-	mi := &file_mvcc_proto_msgTypes[2]
+	mi := &file_mvcc_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -424,7 +933,7 @@ func (x *Txn) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Txn.ProtoReflect.Descriptor instead.
 func (*Txn) Descriptor() ([]byte, []int) {
-	return file_mvcc_proto_rawDescGZIP(), []int{2}
+	return file_mvcc_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Txn) GetCompare() []*Compare {
@@ -460,13 +969,15 @@ type RequestOp struct {
 	//	*RequestOp_RequestRange
 	//	*RequestOp_RequestPut
 	//	*RequestOp_RequestDeleteRange
+	//	*RequestOp_RequestIncrement
+	//	*RequestOp_RequestTxn
 	Request isRequestOp_Request `protobuf_oneof:"request"`
 }
 
 func (x *RequestOp) Reset() {
 	*x = RequestOp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mvcc_proto_msgTypes[3]
+		mi := &file_mvcc_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -479,7 +990,7 @@ func (x *RequestOp) String() string {
 func (*RequestOp) ProtoMessage() {}
 
 func (x *RequestOp) ProtoReflect() protoreflect.Message {
-	mi := &file_mvcc_proto_msgTypes[3]
+	mi := &file_mvcc_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -492,7 +1003,7 @@ func (x *RequestOp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestOp.ProtoReflect.Descriptor instead.
 func (*RequestOp) Descriptor() ([]byte, []int) {
-	return file_mvcc_proto_rawDescGZIP(), []int{3}
+	return file_mvcc_proto_rawDescGZIP(), []int{7}
 }
 
 func (m *RequestOp) GetRequest() isRequestOp_Request {
@@ -523,6 +1034,20 @@ func (x *RequestOp) GetRequestDeleteRange() *RequestOp_DeleteRange {
 	return nil
 }
 
+func (x *RequestOp) GetRequestIncrement() *RequestOp_Increment {
+	if x, ok := x.GetRequest().(*RequestOp_RequestIncrement); ok {
+		return x.RequestIncrement
+	}
+	return nil
+}
+
+func (x *RequestOp) GetRequestTxn() *Txn {
+	if x, ok := x.GetRequest().(*RequestOp_RequestTxn); ok {
+		return x.RequestTxn
+	}
+	return nil
+}
+
 type isRequestOp_Request interface {
 	isRequestOp_Request()
 }
@@ -539,12 +1064,26 @@ type RequestOp_RequestDeleteRange struct {
 	RequestDeleteRange *RequestOp_DeleteRange `protobuf:"bytes,3,opt,name=request_delete_range,json=requestDeleteRange,proto3,oneof"`
 }
 
+type RequestOp_RequestIncrement struct {
+	RequestIncrement *RequestOp_Increment `protobuf:"bytes,4,opt,name=request_increment,json=requestIncrement,proto3,oneof"`
+}
+
+type RequestOp_RequestTxn struct {
+	// request_txn is a nested transaction, its compare is evaluated against the state
+	// of the table after the preceding operations of the enclosing transaction are applied.
+	RequestTxn *Txn `protobuf:"bytes,5,opt,name=request_txn,json=requestTxn,proto3,oneof"`
+}
+
 func (*RequestOp_RequestRange) isRequestOp_Request() {}
 
 func (*RequestOp_RequestPut) isRequestOp_Request() {}
 
 func (*RequestOp_RequestDeleteRange) isRequestOp_Request() {}
 
+func (*RequestOp_RequestIncrement) isRequestOp_Request() {}
+
+func (*RequestOp_RequestTxn) isRequestOp_Request() {}
+
 type ResponseOp struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -557,13 +1096,15 @@ type ResponseOp struct {
 	//	*ResponseOp_ResponseRange
 	//	*ResponseOp_ResponsePut
 	//	*ResponseOp_ResponseDeleteRange
+	//	*ResponseOp_ResponseIncrement
+	//	*ResponseOp_ResponseTxn
 	Response isResponseOp_Response `protobuf_oneof:"response"`
 }
 
 func (x *ResponseOp) Reset() {
 	*x = ResponseOp{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mvcc_proto_msgTypes[4]
+		mi := &file_mvcc_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -576,7 +1117,7 @@ func (x *ResponseOp) String() string {
 func (*ResponseOp) ProtoMessage() {}
 
 func (x *ResponseOp) ProtoReflect() protoreflect.Message {
-	mi := &file_mvcc_proto_msgTypes[4]
+	mi := &file_mvcc_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -589,7 +1130,7 @@ func (x *ResponseOp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResponseOp.ProtoReflect.Descriptor instead.
 func (*ResponseOp) Descriptor() ([]byte, []int) {
-	return file_mvcc_proto_rawDescGZIP(), []int{4}
+	return file_mvcc_proto_rawDescGZIP(), []int{8}
 }
 
 func (m *ResponseOp) GetResponse() isResponseOp_Response {
@@ -620,6 +1161,20 @@ func (x *ResponseOp) GetResponseDeleteRange() *ResponseOp_DeleteRange {
 	return nil
 }
 
+func (x *ResponseOp) GetResponseIncrement() *ResponseOp_Increment {
+	if x, ok := x.GetResponse().(*ResponseOp_ResponseIncrement); ok {
+		return x.ResponseIncrement
+	}
+	return nil
+}
+
+func (x *ResponseOp) GetResponseTxn() *ResponseOp_Txn {
+	if x, ok := x.GetResponse().(*ResponseOp_ResponseTxn); ok {
+		return x.ResponseTxn
+	}
+	return nil
+}
+
 type isResponseOp_Response interface {
 	isResponseOp_Response()
 }
@@ -636,12 +1191,24 @@ type ResponseOp_ResponseDeleteRange struct {
 	ResponseDeleteRange *ResponseOp_DeleteRange `protobuf:"bytes,3,opt,name=response_delete_range,json=responseDeleteRange,proto3,oneof"`
 }
 
+type ResponseOp_ResponseIncrement struct {
+	ResponseIncrement *ResponseOp_Increment `protobuf:"bytes,4,opt,name=response_increment,json=responseIncrement,proto3,oneof"`
+}
+
+type ResponseOp_ResponseTxn struct {
+	ResponseTxn *ResponseOp_Txn `protobuf:"bytes,5,opt,name=response_txn,json=responseTxn,proto3,oneof"`
+}
+
 func (*ResponseOp_ResponseRange) isResponseOp_Response() {}
 
 func (*ResponseOp_ResponsePut) isResponseOp_Response() {}
 
 func (*ResponseOp_ResponseDeleteRange) isResponseOp_Response() {}
 
+func (*ResponseOp_ResponseIncrement) isResponseOp_Response() {}
+
+func (*ResponseOp_ResponseTxn) isResponseOp_Response() {}
+
 // Compare property `target` for every KV from DB in [key, range_end) with target_union using the operation `result`. e.g. `DB[key].target result target_union.target`,
 // that means that for asymmetric operations LESS and GREATER the target property of the key from the DB is the left-hand side of the comparison.
 // Examples:
@@ -649,6 +1216,8 @@ func (*ResponseOp_ResponseDeleteRange) isResponseOp_Response() {}
 // * `DB[key][value] GREATER target_union.value`
 // * `DB[key...range_end][value] GREATER target_union.value`
 // * `DB[key][value] LESS target_union.value`
+// * `DB[key][create_revision] EQUAL 0` (the key does not exist)
+// If the key does not exist the comparison of the VALUE target is false, other targets are compared as 0.
 type Compare struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -663,6 +1232,10 @@ type Compare struct {
 	// Types that are assignable to TargetUnion:
 	//
 	//	*Compare_Value
+	//	*Compare_CreateRevision
+	//	*Compare_ModRevision
+	//	*Compare_Version
+	//	*Compare_Lease
 	TargetUnion isCompare_TargetUnion `protobuf_oneof:"target_union"`
 	// range_end compares the given target to all keys in the range [key, range_end).
 	// See RangeRequest for more details on key ranges.
@@ -672,7 +1245,7 @@ type Compare struct {
 func (x *Compare) Reset() {
 	*x = Compare{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mvcc_proto_msgTypes[5]
+		mi := &file_mvcc_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -685,7 +1258,7 @@ func (x *Compare) String() string {
 func (*Compare) ProtoMessage() {}
 
 func (x *Compare) ProtoReflect() protoreflect.Message {
-	mi := &file_mvcc_proto_msgTypes[5]
+	mi := &file_mvcc_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -698,7 +1271,7 @@ func (x *Compare) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Compare.ProtoReflect.Descriptor instead.
 func (*Compare) Descriptor() ([]byte, []int) {
-	return file_mvcc_proto_rawDescGZIP(), []int{5}
+	return file_mvcc_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *Compare) GetResult() Compare_CompareResult {
@@ -736,6 +1309,34 @@ func (x *Compare) GetValue() []byte {
 	return nil
 }
 
+func (x *Compare) GetCreateRevision() int64 {
+	if x, ok := x.GetTargetUnion().(*Compare_CreateRevision); ok {
+		return x.CreateRevision
+	}
+	return 0
+}
+
+func (x *Compare) GetModRevision() int64 {
+	if x, ok := x.GetTargetUnion().(*Compare_ModRevision); ok {
+		return x.ModRevision
+	}
+	return 0
+}
+
+func (x *Compare) GetVersion() int64 {
+	if x, ok := x.GetTargetUnion().(*Compare_Version); ok {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Compare) GetLease() int64 {
+	if x, ok := x.GetTargetUnion().(*Compare_Lease); ok {
+		return x.Lease
+	}
+	return 0
+}
+
 func (x *Compare) GetRangeEnd() []byte {
 	if x != nil {
 		return x.RangeEnd
@@ -752,8 +1353,135 @@ type Compare_Value struct {
 	Value []byte `protobuf:"bytes,4,opt,name=value,proto3,oneof"`
 }
 
+type Compare_CreateRevision struct {
+	// create_revision is the creation revision of the given key.
+	// A key that does not exist has the create_revision 0.
+	CreateRevision int64 `protobuf:"varint,5,opt,name=create_revision,json=createRevision,proto3,oneof"`
+}
+
+type Compare_ModRevision struct {
+	// mod_revision is the last modified revision of the given key.
+	ModRevision int64 `protobuf:"varint,6,opt,name=mod_revision,json=modRevision,proto3,oneof"`
+}
+
+type Compare_Version struct {
+	// version is the version of the given key.
+	Version int64 `protobuf:"varint,7,opt,name=version,proto3,oneof"`
+}
+
+type Compare_Lease struct {
+	// lease is the lease id of the given key.
+	// A key that is not attached to a lease has the lease 0.
+	Lease int64 `protobuf:"varint,8,opt,name=lease,proto3,oneof"` // leave room for more target_union field tags, jump to 64
+}
+
 func (*Compare_Value) isCompare_TargetUnion() {}
 
+func (*Compare_CreateRevision) isCompare_TargetUnion() {}
+
+func (*Compare_ModRevision) isCompare_TargetUnion() {}
+
+func (*Compare_Version) isCompare_TargetUnion() {}
+
+func (*Compare_Lease) isCompare_TargetUnion() {}
+
+// RangeFilter filters the key-value pairs of a range on the server side, the key-value pairs are returned only if they
+// match all the set expressions. Unset (empty or zero) expressions are not applied. The limit of the range applies
+// to the matching key-value pairs only.
+type RangeFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// key_regex is the RE2 regular expression (see https://github.com/google/re2/wiki/Syntax) the key must match.
+	// The expression is not anchored, use ^ and $ to match the whole key.
+	KeyRegex string `protobuf:"bytes,1,opt,name=key_regex,json=keyRegex,proto3" json:"key_regex,omitempty"`
+	// value_prefix is the prefix the value must start with.
+	ValuePrefix []byte `protobuf:"bytes,2,opt,name=value_prefix,json=valuePrefix,proto3" json:"value_prefix,omitempty"`
+	// value_suffix is the suffix the value must end with.
+	ValueSuffix []byte `protobuf:"bytes,3,opt,name=value_suffix,json=valueSuffix,proto3" json:"value_suffix,omitempty"`
+	// value_contains is the byte sequence the value must contain.
+	ValueContains []byte `protobuf:"bytes,4,opt,name=value_contains,json=valueContains,proto3" json:"value_contains,omitempty"`
+	// min_value_length is the lower bound on the value length in bytes, the bound is inclusive.
+	MinValueLength int64 `protobuf:"varint,5,opt,name=min_value_length,json=minValueLength,proto3" json:"min_value_length,omitempty"`
+	// max_value_length is the upper bound on the value length in bytes, the bound is inclusive.
+	MaxValueLength int64 `protobuf:"varint,6,opt,name=max_value_length,json=maxValueLength,proto3" json:"max_value_length,omitempty"`
+}
+
+func (x *RangeFilter) Reset() {
+	*x = RangeFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mvcc_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RangeFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RangeFilter) ProtoMessage() {}
+
+func (x *RangeFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_mvcc_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RangeFilter.ProtoReflect.Descriptor instead.
+func (*RangeFilter) Descriptor() ([]byte, []int) {
+	return file_mvcc_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RangeFilter) GetKeyRegex() string {
+	if x != nil {
+		return x.KeyRegex
+	}
+	return ""
+}
+
+func (x *RangeFilter) GetValuePrefix() []byte {
+	if x != nil {
+		return x.ValuePrefix
+	}
+	return nil
+}
+
+func (x *RangeFilter) GetValueSuffix() []byte {
+	if x != nil {
+		return x.ValueSuffix
+	}
+	return nil
+}
+
+func (x *RangeFilter) GetValueContains() []byte {
+	if x != nil {
+		return x.ValueContains
+	}
+	return nil
+}
+
+func (x *RangeFilter) GetMinValueLength() int64 {
+	if x != nil {
+		return x.MinValueLength
+	}
+	return 0
+}
+
+func (x *RangeFilter) GetMaxValueLength() int64 {
+	if x != nil {
+		return x.MaxValueLength
+	}
+	return 0
+}
+
 type KeyValue struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -767,12 +1495,20 @@ type KeyValue struct {
 	ModRevision int64 `protobuf:"varint,3,opt,name=mod_revision,json=modRevision,proto3" json:"mod_revision,omitempty"`
 	// value is the value held by the key, in bytes.
 	Value []byte `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	// version is the version of the key. A deletion resets
+	// the version to zero and any modification of the key
+	// increases its version.
+	Version int64 `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	// lease is the ID of the lease attached to the key.
+	// When the attached lease expires, the key will be deleted.
+	// If lease is 0, then no lease is attached to the key.
+	Lease int64 `protobuf:"varint,6,opt,name=lease,proto3" json:"lease,omitempty"`
 }
 
 func (x *KeyValue) Reset() {
 	*x = KeyValue{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mvcc_proto_msgTypes[6]
+		mi := &file_mvcc_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -785,7 +1521,7 @@ func (x *KeyValue) String() string {
 func (*KeyValue) ProtoMessage() {}
 
 func (x *KeyValue) ProtoReflect() protoreflect.Message {
-	mi := &file_mvcc_proto_msgTypes[6]
+	mi := &file_mvcc_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -798,7 +1534,7 @@ func (x *KeyValue) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KeyValue.ProtoReflect.Descriptor instead.
 func (*KeyValue) Descriptor() ([]byte, []int) {
-	return file_mvcc_proto_rawDescGZIP(), []int{6}
+	return file_mvcc_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *KeyValue) GetKey() []byte {
@@ -829,6 +1565,82 @@ func (x *KeyValue) GetValue() []byte {
 	return nil
 }
 
+func (x *KeyValue) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *KeyValue) GetLease() int64 {
+	if x != nil {
+		return x.Lease
+	}
+	return 0
+}
+
+type Event struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// type is the kind of event. If type is a PUT, it indicates
+	// new data has been stored to the key. If type is a DELETE,
+	// it indicates the key was deleted.
+	Type Event_EventType `protobuf:"varint,1,opt,name=type,proto3,enum=mvcc.v1.Event_EventType" json:"type,omitempty"`
+	// kv holds the KeyValue for the event.
+	// A PUT event contains current kv pair.
+	// A DELETE event contains the deleted key with
+	// its modification revision set to the revision of deletion.
+	Kv *KeyValue `protobuf:"bytes,2,opt,name=kv,proto3" json:"kv,omitempty"`
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mvcc_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_mvcc_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_mvcc_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Event) GetType() Event_EventType {
+	if x != nil {
+		return x.Type
+	}
+	return Event_PUT
+}
+
+func (x *Event) GetKv() *KeyValue {
+	if x != nil {
+		return x.Kv
+	}
+	return nil
+}
+
 type RequestOp_Range struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -849,12 +1661,33 @@ type RequestOp_Range struct {
 	KeysOnly bool `protobuf:"varint,4,opt,name=keys_only,json=keysOnly,proto3" json:"keys_only,omitempty"`
 	// count_only when set returns only the count of the keys in the range.
 	CountOnly bool `protobuf:"varint,5,opt,name=count_only,json=countOnly,proto3" json:"count_only,omitempty"`
+	// min_mod_revision is the lower bound for returned key mod revisions; all keys with
+	// lesser mod revisions will be filtered away.
+	MinModRevision int64 `protobuf:"varint,6,opt,name=min_mod_revision,json=minModRevision,proto3" json:"min_mod_revision,omitempty"`
+	// max_mod_revision is the upper bound for returned key mod revisions; all keys with
+	// greater mod revisions will be filtered away.
+	MaxModRevision int64 `protobuf:"varint,7,opt,name=max_mod_revision,json=maxModRevision,proto3" json:"max_mod_revision,omitempty"`
+	// min_create_revision is the lower bound for returned key create revisions; all keys with
+	// lesser create revisions will be filtered away.
+	MinCreateRevision int64 `protobuf:"varint,8,opt,name=min_create_revision,json=minCreateRevision,proto3" json:"min_create_revision,omitempty"`
+	// max_create_revision is the upper bound for returned key create revisions; all keys with
+	// greater create revisions will be filtered away.
+	MaxCreateRevision int64 `protobuf:"varint,9,opt,name=max_create_revision,json=maxCreateRevision,proto3" json:"max_create_revision,omitempty"`
+	// revision is the point-in-time of the key-value store to use for the range.
+	// If revision is less or equal to zero, the range is over the newest key-value store.
+	// Reading at a compacted or a future revision fails.
+	Revision int64 `protobuf:"varint,10,opt,name=revision,proto3" json:"revision,omitempty"`
+	// filter when set returns only the key-value pairs matching all the set filter expressions.
+	Filter *RangeFilter `protobuf:"bytes,11,opt,name=filter,proto3" json:"filter,omitempty"`
+	// sort_order is the order of the returned key-value pairs by the key, the limit applies from the beginning
+	// of the range in the given order. The default order is ascending.
+	SortOrder SortOrder `protobuf:"varint,12,opt,name=sort_order,json=sortOrder,proto3,enum=mvcc.v1.SortOrder" json:"sort_order,omitempty"`
 }
 
 func (x *RequestOp_Range) Reset() {
 	*x = RequestOp_Range{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mvcc_proto_msgTypes[7]
+		mi := &file_mvcc_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -867,7 +1700,7 @@ func (x *RequestOp_Range) String() string {
 func (*RequestOp_Range) ProtoMessage() {}
 
 func (x *RequestOp_Range) ProtoReflect() protoreflect.Message {
-	mi := &file_mvcc_proto_msgTypes[7]
+	mi := &file_mvcc_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -880,7 +1713,7 @@ func (x *RequestOp_Range) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestOp_Range.ProtoReflect.Descriptor instead.
 func (*RequestOp_Range) Descriptor() ([]byte, []int) {
-	return file_mvcc_proto_rawDescGZIP(), []int{3, 0}
+	return file_mvcc_proto_rawDescGZIP(), []int{7, 0}
 }
 
 func (x *RequestOp_Range) GetKey() []byte {
@@ -915,7 +1748,56 @@ func (x *RequestOp_Range) GetCountOnly() bool {
 	if x != nil {
 		return x.CountOnly
 	}
-	return false
+	return false
+}
+
+func (x *RequestOp_Range) GetMinModRevision() int64 {
+	if x != nil {
+		return x.MinModRevision
+	}
+	return 0
+}
+
+func (x *RequestOp_Range) GetMaxModRevision() int64 {
+	if x != nil {
+		return x.MaxModRevision
+	}
+	return 0
+}
+
+func (x *RequestOp_Range) GetMinCreateRevision() int64 {
+	if x != nil {
+		return x.MinCreateRevision
+	}
+	return 0
+}
+
+func (x *RequestOp_Range) GetMaxCreateRevision() int64 {
+	if x != nil {
+		return x.MaxCreateRevision
+	}
+	return 0
+}
+
+func (x *RequestOp_Range) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *RequestOp_Range) GetFilter() *RangeFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *RequestOp_Range) GetSortOrder() SortOrder {
+	if x != nil {
+		return x.SortOrder
+	}
+	return SortOrder_ASCEND
 }
 
 type RequestOp_Put struct {
@@ -929,12 +1811,15 @@ type RequestOp_Put struct {
 	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
 	// prev_kv if true the previous key-value pair will be returned in the put response.
 	PrevKv bool `protobuf:"varint,3,opt,name=prev_kv,json=prevKv,proto3" json:"prev_kv,omitempty"`
+	// lease is the lease ID to associate with the key in the key-value store. A lease
+	// value of 0 indicates no lease.
+	Lease int64 `protobuf:"varint,4,opt,name=lease,proto3" json:"lease,omitempty"`
 }
 
 func (x *RequestOp_Put) Reset() {
 	*x = RequestOp_Put{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mvcc_proto_msgTypes[8]
+		mi := &file_mvcc_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -947,7 +1832,7 @@ func (x *RequestOp_Put) String() string {
 func (*RequestOp_Put) ProtoMessage() {}
 
 func (x *RequestOp_Put) ProtoReflect() protoreflect.Message {
-	mi := &file_mvcc_proto_msgTypes[8]
+	mi := &file_mvcc_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -960,7 +1845,7 @@ func (x *RequestOp_Put) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestOp_Put.ProtoReflect.Descriptor instead.
 func (*RequestOp_Put) Descriptor() ([]byte, []int) {
-	return file_mvcc_proto_rawDescGZIP(), []int{3, 1}
+	return file_mvcc_proto_rawDescGZIP(), []int{7, 1}
 }
 
 func (x *RequestOp_Put) GetKey() []byte {
@@ -984,6 +1869,13 @@ func (x *RequestOp_Put) GetPrevKv() bool {
 	return false
 }
 
+func (x *RequestOp_Put) GetLease() int64 {
+	if x != nil {
+		return x.Lease
+	}
+	return 0
+}
+
 type RequestOp_DeleteRange struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1010,7 +1902,7 @@ type RequestOp_DeleteRange struct {
 func (x *RequestOp_DeleteRange) Reset() {
 	*x = RequestOp_DeleteRange{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mvcc_proto_msgTypes[9]
+		mi := &file_mvcc_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1023,7 +1915,7 @@ func (x *RequestOp_DeleteRange) String() string {
 func (*RequestOp_DeleteRange) ProtoMessage() {}
 
 func (x *RequestOp_DeleteRange) ProtoReflect() protoreflect.Message {
-	mi := &file_mvcc_proto_msgTypes[9]
+	mi := &file_mvcc_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1036,7 +1928,7 @@ func (x *RequestOp_DeleteRange) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestOp_DeleteRange.ProtoReflect.Descriptor instead.
 func (*RequestOp_DeleteRange) Descriptor() ([]byte, []int) {
-	return file_mvcc_proto_rawDescGZIP(), []int{3, 2}
+	return file_mvcc_proto_rawDescGZIP(), []int{7, 2}
 }
 
 func (x *RequestOp_DeleteRange) GetKey() []byte {
@@ -1067,6 +1959,92 @@ func (x *RequestOp_DeleteRange) GetCount() bool {
 	return false
 }
 
+// Increment treats the value of the key as a signed 64-bit integer encoded as a decimal string
+// and atomically adds the delta to it. The lease of an existing key is kept.
+type RequestOp_Increment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// key is the key, in bytes, of the value to increment.
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// delta is added to the value, use a negative delta to decrement the value.
+	Delta int64 `protobuf:"varint,2,opt,name=delta,proto3" json:"delta,omitempty"`
+	// initial is the value of the key before the delta is added if the key does not exist.
+	Initial int64 `protobuf:"varint,3,opt,name=initial,proto3" json:"initial,omitempty"`
+	// min is the lower bound of the resulting value, the operation fails if the resulting value is lower.
+	Min *int64 `protobuf:"varint,4,opt,name=min,proto3,oneof" json:"min,omitempty"`
+	// max is the upper bound of the resulting value, the operation fails if the resulting value is greater.
+	Max *int64 `protobuf:"varint,5,opt,name=max,proto3,oneof" json:"max,omitempty"`
+}
+
+func (x *RequestOp_Increment) Reset() {
+	*x = RequestOp_Increment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mvcc_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestOp_Increment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestOp_Increment) ProtoMessage() {}
+
+func (x *RequestOp_Increment) ProtoReflect() protoreflect.Message {
+	mi := &file_mvcc_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestOp_Increment.ProtoReflect.Descriptor instead.
+func (*RequestOp_Increment) Descriptor() ([]byte, []int) {
+	return file_mvcc_proto_rawDescGZIP(), []int{7, 3}
+}
+
+func (x *RequestOp_Increment) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *RequestOp_Increment) GetDelta() int64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+func (x *RequestOp_Increment) GetInitial() int64 {
+	if x != nil {
+		return x.Initial
+	}
+	return 0
+}
+
+func (x *RequestOp_Increment) GetMin() int64 {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return 0
+}
+
+func (x *RequestOp_Increment) GetMax() int64 {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return 0
+}
+
 type ResponseOp_Range struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1084,7 +2062,7 @@ type ResponseOp_Range struct {
 func (x *ResponseOp_Range) Reset() {
 	*x = ResponseOp_Range{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mvcc_proto_msgTypes[10]
+		mi := &file_mvcc_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1097,7 +2075,7 @@ func (x *ResponseOp_Range) String() string {
 func (*ResponseOp_Range) ProtoMessage() {}
 
 func (x *ResponseOp_Range) ProtoReflect() protoreflect.Message {
-	mi := &file_mvcc_proto_msgTypes[10]
+	mi := &file_mvcc_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1110,7 +2088,7 @@ func (x *ResponseOp_Range) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResponseOp_Range.ProtoReflect.Descriptor instead.
 func (*ResponseOp_Range) Descriptor() ([]byte, []int) {
-	return file_mvcc_proto_rawDescGZIP(), []int{4, 0}
+	return file_mvcc_proto_rawDescGZIP(), []int{8, 0}
 }
 
 func (x *ResponseOp_Range) GetKvs() []*KeyValue {
@@ -1146,7 +2124,7 @@ type ResponseOp_Put struct {
 func (x *ResponseOp_Put) Reset() {
 	*x = ResponseOp_Put{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mvcc_proto_msgTypes[11]
+		mi := &file_mvcc_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1159,7 +2137,7 @@ func (x *ResponseOp_Put) String() string {
 func (*ResponseOp_Put) ProtoMessage() {}
 
 func (x *ResponseOp_Put) ProtoReflect() protoreflect.Message {
-	mi := &file_mvcc_proto_msgTypes[11]
+	mi := &file_mvcc_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1172,7 +2150,7 @@ func (x *ResponseOp_Put) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResponseOp_Put.ProtoReflect.Descriptor instead.
 func (*ResponseOp_Put) Descriptor() ([]byte, []int) {
-	return file_mvcc_proto_rawDescGZIP(), []int{4, 1}
+	return file_mvcc_proto_rawDescGZIP(), []int{8, 1}
 }
 
 func (x *ResponseOp_Put) GetPrevKv() *KeyValue {
@@ -1196,7 +2174,7 @@ type ResponseOp_DeleteRange struct {
 func (x *ResponseOp_DeleteRange) Reset() {
 	*x = ResponseOp_DeleteRange{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_mvcc_proto_msgTypes[12]
+		mi := &file_mvcc_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1209,7 +2187,7 @@ func (x *ResponseOp_DeleteRange) String() string {
 func (*ResponseOp_DeleteRange) ProtoMessage() {}
 
 func (x *ResponseOp_DeleteRange) ProtoReflect() protoreflect.Message {
-	mi := &file_mvcc_proto_msgTypes[12]
+	mi := &file_mvcc_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1222,7 +2200,7 @@ func (x *ResponseOp_DeleteRange) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResponseOp_DeleteRange.ProtoReflect.Descriptor instead.
 func (*ResponseOp_DeleteRange) Descriptor() ([]byte, []int) {
-	return file_mvcc_proto_rawDescGZIP(), []int{4, 2}
+	return file_mvcc_proto_rawDescGZIP(), []int{8, 2}
 }
 
 func (x *ResponseOp_DeleteRange) GetDeleted() int64 {
@@ -1239,11 +2217,117 @@ func (x *ResponseOp_DeleteRange) GetPrevKvs() []*KeyValue {
 	return nil
 }
 
+type ResponseOp_Increment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// value is the value of the key after the increment.
+	Value int64 `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *ResponseOp_Increment) Reset() {
+	*x = ResponseOp_Increment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mvcc_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseOp_Increment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseOp_Increment) ProtoMessage() {}
+
+func (x *ResponseOp_Increment) ProtoReflect() protoreflect.Message {
+	mi := &file_mvcc_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseOp_Increment.ProtoReflect.Descriptor instead.
+func (*ResponseOp_Increment) Descriptor() ([]byte, []int) {
+	return file_mvcc_proto_rawDescGZIP(), []int{8, 3}
+}
+
+func (x *ResponseOp_Increment) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type ResponseOp_Txn struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// succeeded is set to true if the compare of the nested transaction evaluated to true or false otherwise.
+	Succeeded bool `protobuf:"varint,1,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	// responses is a list of responses corresponding to the results from applying
+	// success if succeeded is true or failure if succeeded is false.
+	Responses []*ResponseOp `protobuf:"bytes,2,rep,name=responses,proto3" json:"responses,omitempty"`
+}
+
+func (x *ResponseOp_Txn) Reset() {
+	*x = ResponseOp_Txn{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mvcc_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseOp_Txn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseOp_Txn) ProtoMessage() {}
+
+func (x *ResponseOp_Txn) ProtoReflect() protoreflect.Message {
+	mi := &file_mvcc_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseOp_Txn.ProtoReflect.Descriptor instead.
+func (*ResponseOp_Txn) Descriptor() ([]byte, []int) {
+	return file_mvcc_proto_rawDescGZIP(), []int{8, 4}
+}
+
+func (x *ResponseOp_Txn) GetSucceeded() bool {
+	if x != nil {
+		return x.Succeeded
+	}
+	return false
+}
+
+func (x *ResponseOp_Txn) GetResponses() []*ResponseOp {
+	if x != nil {
+		return x.Responses
+	}
+	return nil
+}
+
 var File_mvcc_proto protoreflect.FileDescriptor
 
 var file_mvcc_proto_rawDesc = []byte{
 	0x0a, 0x0a, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x6d, 0x76,
-	0x63, 0x63, 0x2e, 0x76, 0x31, 0x22, 0xff, 0x03, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
+	0x63, 0x63, 0x2e, 0x76, 0x31, 0x22, 0xa2, 0x08, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
 	0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
 	0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x30, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
 	0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e,
@@ -1266,123 +2350,279 @@ var file_mvcc_proto_rawDesc = []byte{
 	0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x6d,
 	0x61, 0x6e, 0x64, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a,
 	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x22, 0x65, 0x0a, 0x0b, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x54, 0x79,
+	0x75, 0x6e, 0x74, 0x12, 0x29, 0x0a, 0x05, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x65, 0x61,
+	0x73, 0x65, 0x48, 0x03, 0x52, 0x05, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1c,
+	0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x29, 0x0a, 0x10,
+	0x63, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x52,
+	0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x29, 0x0a, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b,
+	0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x48, 0x04, 0x52, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x88,
+	0x01, 0x01, 0x12, 0x39, 0x0a, 0x0b, 0x6c, 0x61, 0x72, 0x67, 0x65, 0x5f, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76,
+	0x31, 0x2e, 0x4c, 0x61, 0x72, 0x67, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x05, 0x52, 0x0a,
+	0x6c, 0x61, 0x72, 0x67, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1d, 0x0a,
+	0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x11, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x42, 0x0a, 0x0e,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x12,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x48, 0x06, 0x52, 0x0d,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x88, 0x01, 0x01,
+	0x12, 0x20, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x63, 0x65, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x18,
+	0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x63, 0x65, 0x70, 0x61, 0x72, 0x65,
+	0x6e, 0x74, 0x22, 0xf1, 0x01, 0x0a, 0x0b, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x54, 0x79,
 	0x70, 0x65, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x55, 0x54, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x44,
 	0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x44, 0x55, 0x4d, 0x4d, 0x59,
 	0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x50, 0x55, 0x54, 0x5f, 0x42, 0x41, 0x54, 0x43, 0x48, 0x10,
 	0x03, 0x12, 0x10, 0x0a, 0x0c, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x5f, 0x42, 0x41, 0x54, 0x43,
 	0x48, 0x10, 0x04, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x58, 0x4e, 0x10, 0x05, 0x12, 0x0c, 0x0a, 0x08,
-	0x53, 0x45, 0x51, 0x55, 0x45, 0x4e, 0x43, 0x45, 0x10, 0x06, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x6c,
-	0x65, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x42, 0x06, 0x0a, 0x04, 0x5f,
-	0x74, 0x78, 0x6e, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x65, 0x6e,
-	0x64, 0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x22, 0x5e, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x6d, 0x61,
-	0x6e, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x31, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6d, 0x76,
-	0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4f, 0x70,
-	0x52, 0x09, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72,
+	0x53, 0x45, 0x51, 0x55, 0x45, 0x4e, 0x43, 0x45, 0x10, 0x06, 0x12, 0x0f, 0x0a, 0x0b, 0x4c, 0x45,
+	0x41, 0x53, 0x45, 0x5f, 0x47, 0x52, 0x41, 0x4e, 0x54, 0x10, 0x07, 0x12, 0x10, 0x0a, 0x0c, 0x4c,
+	0x45, 0x41, 0x53, 0x45, 0x5f, 0x52, 0x45, 0x56, 0x4f, 0x4b, 0x45, 0x10, 0x08, 0x12, 0x14, 0x0a,
+	0x10, 0x4c, 0x45, 0x41, 0x53, 0x45, 0x5f, 0x4b, 0x45, 0x45, 0x50, 0x5f, 0x41, 0x4c, 0x49, 0x56,
+	0x45, 0x10, 0x09, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x4f, 0x4d, 0x50, 0x41, 0x43, 0x54, 0x10, 0x0a,
+	0x12, 0x0d, 0x0a, 0x09, 0x50, 0x55, 0x54, 0x5f, 0x43, 0x48, 0x55, 0x4e, 0x4b, 0x10, 0x0b, 0x12,
+	0x0d, 0x0a, 0x09, 0x50, 0x55, 0x54, 0x5f, 0x4c, 0x41, 0x52, 0x47, 0x45, 0x10, 0x0c, 0x12, 0x12,
+	0x0a, 0x0e, 0x44, 0x49, 0x53, 0x43, 0x41, 0x52, 0x44, 0x5f, 0x43, 0x48, 0x55, 0x4e, 0x4b, 0x53,
+	0x10, 0x0d, 0x12, 0x12, 0x0a, 0x0e, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x5f, 0x52, 0x45,
+	0x53, 0x55, 0x4c, 0x54, 0x10, 0x0e, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x6c, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x74, 0x78, 0x6e, 0x42,
+	0x0c, 0x0a, 0x0a, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x65, 0x6e, 0x64, 0x42, 0x08, 0x0a,
+	0x06, 0x5f, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x63, 0x68, 0x75, 0x6e,
+	0x6b, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x6c, 0x61, 0x72, 0x67, 0x65, 0x5f, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x42, 0x11, 0x0a, 0x0f, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x22, 0xc4, 0x01, 0x0a, 0x0d, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72,
 	0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x72,
-	0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x8d, 0x01, 0x0a, 0x03, 0x54, 0x78, 0x6e, 0x12,
-	0x2a, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x10, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61,
-	0x72, 0x65, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x12, 0x2c, 0x0a, 0x07, 0x73,
-	0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d,
-	0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4f, 0x70,
-	0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x2c, 0x0a, 0x07, 0x66, 0x61, 0x69,
-	0x6c, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x76, 0x63,
-	0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x52, 0x07,
-	0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x22, 0xa6, 0x04, 0x0a, 0x09, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x4f, 0x70, 0x12, 0x3f, 0x0a, 0x0d, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d,
-	0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4f, 0x70,
-	0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x39, 0x0a, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x5f, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x76,
-	0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x2e,
-	0x50, 0x75, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x50, 0x75,
-	0x74, 0x12, 0x52, 0x0a, 0x14, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x64, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1e, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x4f, 0x70, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x48,
-	0x00, 0x52, 0x12, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
-	0x52, 0x61, 0x6e, 0x67, 0x65, 0x1a, 0x88, 0x01, 0x0a, 0x05, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12,
-	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x45, 0x6e, 0x64, 0x12, 0x14,
-	0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c,
-	0x69, 0x6d, 0x69, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6b, 0x65, 0x79, 0x73, 0x5f, 0x6f, 0x6e, 0x6c,
-	0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6b, 0x65, 0x79, 0x73, 0x4f, 0x6e, 0x6c,
-	0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x4f, 0x6e, 0x6c, 0x79,
-	0x1a, 0x46, 0x0a, 0x03, 0x50, 0x75, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12,
-	0x17, 0x0a, 0x07, 0x70, 0x72, 0x65, 0x76, 0x5f, 0x6b, 0x76, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x06, 0x70, 0x72, 0x65, 0x76, 0x4b, 0x76, 0x1a, 0x6b, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x61, 0x6e,
-	0x67, 0x65, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x72, 0x61,
-	0x6e, 0x67, 0x65, 0x45, 0x6e, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x72, 0x65, 0x76, 0x5f, 0x6b,
-	0x76, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x72, 0x65, 0x76, 0x4b, 0x76, 0x12,
-	0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05,
-	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x09, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x22, 0xd3, 0x03, 0x0a, 0x0a, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4f, 0x70, 0x12,
-	0x42, 0x0a, 0x0e, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x72, 0x61, 0x6e, 0x67,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76,
-	0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4f, 0x70, 0x2e, 0x52, 0x61, 0x6e,
-	0x67, 0x65, 0x48, 0x00, 0x52, 0x0d, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x61,
-	0x6e, 0x67, 0x65, 0x12, 0x3c, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f,
-	0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6d, 0x76, 0x63, 0x63,
-	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4f, 0x70, 0x2e, 0x50,
-	0x75, 0x74, 0x48, 0x00, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x50, 0x75,
-	0x74, 0x12, 0x55, 0x0a, 0x15, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x64, 0x65,
-	0x6c, 0x65, 0x74, 0x65, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1f, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x4f, 0x70, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x61, 0x6e, 0x67,
-	0x65, 0x48, 0x00, 0x52, 0x13, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x1a, 0x56, 0x0a, 0x05, 0x52, 0x61, 0x6e, 0x67,
-	0x65, 0x12, 0x23, 0x0a, 0x03, 0x6b, 0x76, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11,
-	0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75,
-	0x65, 0x52, 0x03, 0x6b, 0x76, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x72, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6d, 0x6f, 0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
-	0x1a, 0x31, 0x0a, 0x03, 0x50, 0x75, 0x74, 0x12, 0x2a, 0x0a, 0x07, 0x70, 0x72, 0x65, 0x76, 0x5f,
-	0x6b, 0x76, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e,
-	0x76, 0x31, 0x2e, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x70, 0x72, 0x65,
-	0x76, 0x4b, 0x76, 0x1a, 0x55, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x61, 0x6e,
-	0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x2c, 0x0a, 0x08,
-	0x70, 0x72, 0x65, 0x76, 0x5f, 0x6b, 0x76, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11,
+	0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12,
+	0x3d, 0x0a, 0x0e, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52,
+	0x0d, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x21,
+	0x0a, 0x0c, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x48, 0x61, 0x73,
+	0x68, 0x22, 0x41, 0x0a, 0x05, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x22, 0x48, 0x0a, 0x0a, 0x4c, 0x61, 0x72, 0x67, 0x65, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x06, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x22, 0x84,
+	0x01, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x12, 0x31, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4f, 0x70, 0x52, 0x09, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x24, 0x0a, 0x05, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e,
+	0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x05,
+	0x6c, 0x65, 0x61, 0x73, 0x65, 0x22, 0x48, 0x0a, 0x05, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x12, 0x0e,
+	0x0a, 0x02, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x49, 0x44, 0x12, 0x10,
+	0x0a, 0x03, 0x54, 0x54, 0x4c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x54, 0x54, 0x4c,
+	0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x22,
+	0x8d, 0x01, 0x0a, 0x03, 0x54, 0x78, 0x6e, 0x12, 0x2a, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x70, 0x61,
+	0x72, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x70,
+	0x61, 0x72, 0x65, 0x12, 0x2c, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x12, 0x2c, 0x0a, 0x07, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x52, 0x07, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x22,
+	0xf9, 0x08, 0x0a, 0x09, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x12, 0x3f, 0x0a,
+	0x0d, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x00,
+	0x52, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x39,
+	0x0a, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x2e, 0x50, 0x75, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x72,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x50, 0x75, 0x74, 0x12, 0x52, 0x0a, 0x14, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x5f, 0x72, 0x61, 0x6e, 0x67,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x2e, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x00, 0x52, 0x12, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x4b, 0x0a,
+	0x11, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4f, 0x70, 0x2e, 0x49, 0x6e, 0x63,
+	0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x10, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x49, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x2f, 0x0a, 0x0b, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x74, 0x78, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0c, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x78, 0x6e, 0x48, 0x00, 0x52,
+	0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x54, 0x78, 0x6e, 0x1a, 0xb9, 0x03, 0x0a, 0x05,
+	0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x61, 0x6e, 0x67, 0x65,
+	0x5f, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x72, 0x61, 0x6e, 0x67,
+	0x65, 0x45, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6b, 0x65,
+	0x79, 0x73, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6b,
+	0x65, 0x79, 0x73, 0x4f, 0x6e, 0x6c, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x4f, 0x6e, 0x6c, 0x79, 0x12, 0x28, 0x0a, 0x10, 0x6d, 0x69, 0x6e, 0x5f, 0x6d, 0x6f,
+	0x64, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0e, 0x6d, 0x69, 0x6e, 0x4d, 0x6f, 0x64, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x28, 0x0a, 0x10, 0x6d, 0x61, 0x78, 0x5f, 0x6d, 0x6f, 0x64, 0x5f, 0x72, 0x65, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x4d,
+	0x6f, 0x64, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x13, 0x6d, 0x69,
+	0x6e, 0x5f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x6d, 0x69, 0x6e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x13, 0x6d, 0x61,
+	0x78, 0x5f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x6d, 0x61, 0x78, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x65,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2c, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x12, 0x31, 0x0a, 0x0a, 0x73, 0x6f, 0x72, 0x74, 0x5f, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x09, 0x73, 0x6f,
+	0x72, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x1a, 0x5c, 0x0a, 0x03, 0x50, 0x75, 0x74, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x72, 0x65, 0x76, 0x5f, 0x6b,
+	0x76, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x72, 0x65, 0x76, 0x4b, 0x76, 0x12,
+	0x14, 0x0a, 0x05, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x6c, 0x65, 0x61, 0x73, 0x65, 0x1a, 0x6b, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f,
+	0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x72, 0x61, 0x6e, 0x67, 0x65,
+	0x45, 0x6e, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x72, 0x65, 0x76, 0x5f, 0x6b, 0x76, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x72, 0x65, 0x76, 0x4b, 0x76, 0x12, 0x14, 0x0a, 0x05,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x1a, 0x8b, 0x01, 0x0a, 0x09, 0x49, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x6e, 0x69, 0x74,
+	0x69, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x69, 0x6e, 0x69, 0x74, 0x69,
+	0x61, 0x6c, 0x12, 0x15, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x48,
+	0x00, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x15, 0x0a, 0x03, 0x6d, 0x61, 0x78,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x48, 0x01, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x88, 0x01, 0x01,
+	0x42, 0x06, 0x0a, 0x04, 0x5f, 0x6d, 0x69, 0x6e, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x6d, 0x61, 0x78,
+	0x42, 0x09, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xdc, 0x05, 0x0a, 0x0a,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4f, 0x70, 0x12, 0x42, 0x0a, 0x0e, 0x72, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4f, 0x70, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x00, 0x52,
+	0x0d, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x3c,
+	0x0a, 0x0c, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x70, 0x75, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4f, 0x70, 0x2e, 0x50, 0x75, 0x74, 0x48, 0x00, 0x52,
+	0x0b, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x50, 0x75, 0x74, 0x12, 0x55, 0x0a, 0x15,
+	0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x5f,
+	0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6d, 0x76,
+	0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4f, 0x70,
+	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x00, 0x52, 0x13,
+	0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x61,
+	0x6e, 0x67, 0x65, 0x12, 0x4e, 0x0a, 0x12, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f,
+	0x69, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x4f, 0x70, 0x2e, 0x49, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x00,
+	0x52, 0x11, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x49, 0x6e, 0x63, 0x72, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x12, 0x3c, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f,
+	0x74, 0x78, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6d, 0x76, 0x63, 0x63,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4f, 0x70, 0x2e, 0x54,
+	0x78, 0x6e, 0x48, 0x00, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x78,
+	0x6e, 0x1a, 0x56, 0x0a, 0x05, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x23, 0x0a, 0x03, 0x6b, 0x76,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76,
+	0x31, 0x2e, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x03, 0x6b, 0x76, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6d,
+	0x6f, 0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x1a, 0x31, 0x0a, 0x03, 0x50, 0x75, 0x74,
+	0x12, 0x2a, 0x0a, 0x07, 0x70, 0x72, 0x65, 0x76, 0x5f, 0x6b, 0x76, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x65, 0x79, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x70, 0x72, 0x65, 0x76, 0x4b, 0x76, 0x1a, 0x55, 0x0a, 0x0b,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x64,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x64, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x2c, 0x0a, 0x08, 0x70, 0x72, 0x65, 0x76, 0x5f, 0x6b, 0x76,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76,
+	0x31, 0x2e, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x07, 0x70, 0x72, 0x65, 0x76,
+	0x4b, 0x76, 0x73, 0x1a, 0x21, 0x0a, 0x09, 0x49, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x56, 0x0a, 0x03, 0x54, 0x78, 0x6e, 0x12, 0x1c, 0x0a,
+	0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x12, 0x31, 0x0a, 0x09, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x4f, 0x70, 0x52, 0x09, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x42, 0x0a,
+	0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xdf, 0x03, 0x0a, 0x07, 0x43,
+	0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x12, 0x36, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x36,
+	0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e,
+	0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65,
+	0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x06,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x29, 0x0a, 0x0f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x0e, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0c, 0x6d,
+	0x6f, 0x64, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x03, 0x48, 0x00, 0x52, 0x0b, 0x6d, 0x6f, 0x64, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x1a, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x03, 0x48, 0x00, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x05,
+	0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x05, 0x6c,
+	0x65, 0x61, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x65, 0x6e,
+	0x64, 0x18, 0x40, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x45, 0x6e,
+	0x64, 0x22, 0x40, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x51, 0x55, 0x41, 0x4c, 0x10, 0x00, 0x12, 0x0b, 0x0a,
+	0x07, 0x47, 0x52, 0x45, 0x41, 0x54, 0x45, 0x52, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x4c, 0x45,
+	0x53, 0x53, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x4e, 0x4f, 0x54, 0x5f, 0x45, 0x51, 0x55, 0x41,
+	0x4c, 0x10, 0x03, 0x22, 0x47, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x54, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x12, 0x09, 0x0a, 0x05, 0x56, 0x41, 0x4c, 0x55, 0x45, 0x10, 0x00, 0x12,
+	0x0b, 0x0a, 0x07, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06,
+	0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x4f, 0x44, 0x10,
+	0x03, 0x12, 0x09, 0x0a, 0x05, 0x4c, 0x45, 0x41, 0x53, 0x45, 0x10, 0x04, 0x42, 0x0e, 0x0a, 0x0c,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x22, 0xeb, 0x01, 0x0a,
+	0x0b, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09,
+	0x6b, 0x65, 0x79, 0x5f, 0x72, 0x65, 0x67, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x6b, 0x65, 0x79, 0x52, 0x65, 0x67, 0x65, 0x78, 0x12, 0x21, 0x0a, 0x0c, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0b, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x21, 0x0a, 0x0c,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x73, 0x75, 0x66, 0x66, 0x69, 0x78, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0b, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x53, 0x75, 0x66, 0x66, 0x69, 0x78, 0x12,
+	0x25, 0x0a, 0x0e, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x43, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x6d, 0x69, 0x6e, 0x5f, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0e, 0x6d, 0x69, 0x6e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68,
+	0x12, 0x28, 0x0a, 0x10, 0x6d, 0x61, 0x78, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x6c, 0x65,
+	0x6e, 0x67, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x22, 0xae, 0x01, 0x0a, 0x08, 0x4b,
+	0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0e, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x6f, 0x64, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6d, 0x6f, 0x64, 0x52, 0x65, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x22, 0x7a, 0x0a, 0x05, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x12, 0x2c, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x18, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x21, 0x0a, 0x02, 0x6b, 0x76, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11,
 	0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75,
-	0x65, 0x52, 0x07, 0x70, 0x72, 0x65, 0x76, 0x4b, 0x76, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xae, 0x02, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x70, 0x61,
-	0x72, 0x65, 0x12, 0x36, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x6d, 0x76, 0x63, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d,
-	0x70, 0x61, 0x72, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x75,
-	0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x36, 0x0a, 0x06, 0x74, 0x61,
-	0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x6d, 0x76, 0x63,
-	0x63, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x2e, 0x43, 0x6f, 0x6d,
-	0x70, 0x61, 0x72, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67,
-	0x65, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1b, 0x0a, 0x09,
-	0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x40, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x08, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x45, 0x6e, 0x64, 0x22, 0x40, 0x0a, 0x0d, 0x43, 0x6f, 0x6d,
-	0x70, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x51,
-	0x55, 0x41, 0x4c, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x47, 0x52, 0x45, 0x41, 0x54, 0x45, 0x52,
-	0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x4c, 0x45, 0x53, 0x53, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09,
-	0x4e, 0x4f, 0x54, 0x5f, 0x45, 0x51, 0x55, 0x41, 0x4c, 0x10, 0x03, 0x22, 0x1a, 0x0a, 0x0d, 0x43,
-	0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x09, 0x0a, 0x05,
-	0x56, 0x41, 0x4c, 0x55, 0x45, 0x10, 0x00, 0x42, 0x0e, 0x0a, 0x0c, 0x74, 0x61, 0x72, 0x67, 0x65,
-	0x74, 0x5f, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x22, 0x7e, 0x0a, 0x08, 0x4b, 0x65, 0x79, 0x56, 0x61,
-	0x6c, 0x75, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f,
-	0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e,
-	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21,
-	0x0a, 0x0c, 0x6d, 0x6f, 0x64, 0x5f, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6d, 0x6f, 0x64, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f,
-	0x6e, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x0d, 0x5a, 0x0b, 0x2e, 0x2f, 0x72, 0x65, 0x67,
-	0x61, 0x74, 0x74, 0x61, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x52, 0x02, 0x6b, 0x76, 0x22, 0x20, 0x0a, 0x09, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x55, 0x54, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x44,
+	0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x01, 0x2a, 0x24, 0x0a, 0x09, 0x53, 0x6f, 0x72, 0x74, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x12, 0x0a, 0x0a, 0x06, 0x41, 0x53, 0x43, 0x45, 0x4e, 0x44, 0x10, 0x00,
+	0x12, 0x0b, 0x0a, 0x07, 0x44, 0x45, 0x53, 0x43, 0x45, 0x4e, 0x44, 0x10, 0x01, 0x42, 0x0d, 0x5a,
+	0x0b, 0x2e, 0x2f, 0x72, 0x65, 0x67, 0x61, 0x74, 0x74, 0x61, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1397,52 +2637,78 @@ func file_mvcc_proto_rawDescGZIP() []byte {
 	return file_mvcc_proto_rawDescData
 }
 
-var file_mvcc_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_mvcc_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_mvcc_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_mvcc_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
 var file_mvcc_proto_goTypes = []interface{}{
-	(Command_CommandType)(0),       // 0: mvcc.v1.Command.CommandType
-	(Compare_CompareResult)(0),     // 1: mvcc.v1.Compare.CompareResult
-	(Compare_CompareTarget)(0),     // 2: mvcc.v1.Compare.CompareTarget
-	(*Command)(nil),                // 3: mvcc.v1.Command
-	(*CommandResult)(nil),          // 4: mvcc.v1.CommandResult
-	(*Txn)(nil),                    // 5: mvcc.v1.Txn
-	(*RequestOp)(nil),              // 6: mvcc.v1.RequestOp
-	(*ResponseOp)(nil),             // 7: mvcc.v1.ResponseOp
-	(*Compare)(nil),                // 8: mvcc.v1.Compare
-	(*KeyValue)(nil),               // 9: mvcc.v1.KeyValue
-	(*RequestOp_Range)(nil),        // 10: mvcc.v1.RequestOp.Range
-	(*RequestOp_Put)(nil),          // 11: mvcc.v1.RequestOp.Put
-	(*RequestOp_DeleteRange)(nil),  // 12: mvcc.v1.RequestOp.DeleteRange
-	(*ResponseOp_Range)(nil),       // 13: mvcc.v1.ResponseOp.Range
-	(*ResponseOp_Put)(nil),         // 14: mvcc.v1.ResponseOp.Put
-	(*ResponseOp_DeleteRange)(nil), // 15: mvcc.v1.ResponseOp.DeleteRange
+	(SortOrder)(0),                 // 0: mvcc.v1.SortOrder
+	(Command_CommandType)(0),       // 1: mvcc.v1.Command.CommandType
+	(Compare_CompareResult)(0),     // 2: mvcc.v1.Compare.CompareResult
+	(Compare_CompareTarget)(0),     // 3: mvcc.v1.Compare.CompareTarget
+	(Event_EventType)(0),           // 4: mvcc.v1.Event.EventType
+	(*Command)(nil),                // 5: mvcc.v1.Command
+	(*RequestResult)(nil),          // 6: mvcc.v1.RequestResult
+	(*Chunk)(nil),                  // 7: mvcc.v1.Chunk
+	(*LargeValue)(nil),             // 8: mvcc.v1.LargeValue
+	(*CommandResult)(nil),          // 9: mvcc.v1.CommandResult
+	(*Lease)(nil),                  // 10: mvcc.v1.Lease
+	(*Txn)(nil),                    // 11: mvcc.v1.Txn
+	(*RequestOp)(nil),              // 12: mvcc.v1.RequestOp
+	(*ResponseOp)(nil),             // 13: mvcc.v1.ResponseOp
+	(*Compare)(nil),                // 14: mvcc.v1.Compare
+	(*RangeFilter)(nil),            // 15: mvcc.v1.RangeFilter
+	(*KeyValue)(nil),               // 16: mvcc.v1.KeyValue
+	(*Event)(nil),                  // 17: mvcc.v1.Event
+	(*RequestOp_Range)(nil),        // 18: mvcc.v1.RequestOp.Range
+	(*RequestOp_Put)(nil),          // 19: mvcc.v1.RequestOp.Put
+	(*RequestOp_DeleteRange)(nil),  // 20: mvcc.v1.RequestOp.DeleteRange
+	(*RequestOp_Increment)(nil),    // 21: mvcc.v1.RequestOp.Increment
+	(*ResponseOp_Range)(nil),       // 22: mvcc.v1.ResponseOp.Range
+	(*ResponseOp_Put)(nil),         // 23: mvcc.v1.ResponseOp.Put
+	(*ResponseOp_DeleteRange)(nil), // 24: mvcc.v1.ResponseOp.DeleteRange
+	(*ResponseOp_Increment)(nil),   // 25: mvcc.v1.ResponseOp.Increment
+	(*ResponseOp_Txn)(nil),         // 26: mvcc.v1.ResponseOp.Txn
 }
 var file_mvcc_proto_depIdxs = []int32{
-	0,  // 0: mvcc.v1.Command.type:type_name -> mvcc.v1.Command.CommandType
-	9,  // 1: mvcc.v1.Command.kv:type_name -> mvcc.v1.KeyValue
-	9,  // 2: mvcc.v1.Command.batch:type_name -> mvcc.v1.KeyValue
-	5,  // 3: mvcc.v1.Command.txn:type_name -> mvcc.v1.Txn
-	3,  // 4: mvcc.v1.Command.sequence:type_name -> mvcc.v1.Command
-	7,  // 5: mvcc.v1.CommandResult.responses:type_name -> mvcc.v1.ResponseOp
-	8,  // 6: mvcc.v1.Txn.compare:type_name -> mvcc.v1.Compare
-	6,  // 7: mvcc.v1.Txn.success:type_name -> mvcc.v1.RequestOp
-	6,  // 8: mvcc.v1.Txn.failure:type_name -> mvcc.v1.RequestOp
-	10, // 9: mvcc.v1.RequestOp.request_range:type_name -> mvcc.v1.RequestOp.Range
-	11, // 10: mvcc.v1.RequestOp.request_put:type_name -> mvcc.v1.RequestOp.Put
-	12, // 11: mvcc.v1.RequestOp.request_delete_range:type_name -> mvcc.v1.RequestOp.DeleteRange
-	13, // 12: mvcc.v1.ResponseOp.response_range:type_name -> mvcc.v1.ResponseOp.Range
-	14, // 13: mvcc.v1.ResponseOp.response_put:type_name -> mvcc.v1.ResponseOp.Put
-	15, // 14: mvcc.v1.ResponseOp.response_delete_range:type_name -> mvcc.v1.ResponseOp.DeleteRange
-	1,  // 15: mvcc.v1.Compare.result:type_name -> mvcc.v1.Compare.CompareResult
-	2,  // 16: mvcc.v1.Compare.target:type_name -> mvcc.v1.Compare.CompareTarget
-	9,  // 17: mvcc.v1.ResponseOp.Range.kvs:type_name -> mvcc.v1.KeyValue
-	9,  // 18: mvcc.v1.ResponseOp.Put.prev_kv:type_name -> mvcc.v1.KeyValue
-	9,  // 19: mvcc.v1.ResponseOp.DeleteRange.prev_kvs:type_name -> mvcc.v1.KeyValue
-	20, // [20:20] is the sub-list for method output_type
-	20, // [20:20] is the sub-list for method input_type
-	20, // [20:20] is the sub-list for extension type_name
-	20, // [20:20] is the sub-list for extension extendee
-	0,  // [0:20] is the sub-list for field type_name
+	1,  // 0: mvcc.v1.Command.type:type_name -> mvcc.v1.Command.CommandType
+	16, // 1: mvcc.v1.Command.kv:type_name -> mvcc.v1.KeyValue
+	16, // 2: mvcc.v1.Command.batch:type_name -> mvcc.v1.KeyValue
+	11, // 3: mvcc.v1.Command.txn:type_name -> mvcc.v1.Txn
+	5,  // 4: mvcc.v1.Command.sequence:type_name -> mvcc.v1.Command
+	10, // 5: mvcc.v1.Command.lease:type_name -> mvcc.v1.Lease
+	7,  // 6: mvcc.v1.Command.chunk:type_name -> mvcc.v1.Chunk
+	8,  // 7: mvcc.v1.Command.large_value:type_name -> mvcc.v1.LargeValue
+	6,  // 8: mvcc.v1.Command.request_result:type_name -> mvcc.v1.RequestResult
+	9,  // 9: mvcc.v1.RequestResult.command_result:type_name -> mvcc.v1.CommandResult
+	13, // 10: mvcc.v1.CommandResult.responses:type_name -> mvcc.v1.ResponseOp
+	10, // 11: mvcc.v1.CommandResult.lease:type_name -> mvcc.v1.Lease
+	14, // 12: mvcc.v1.Txn.compare:type_name -> mvcc.v1.Compare
+	12, // 13: mvcc.v1.Txn.success:type_name -> mvcc.v1.RequestOp
+	12, // 14: mvcc.v1.Txn.failure:type_name -> mvcc.v1.RequestOp
+	18, // 15: mvcc.v1.RequestOp.request_range:type_name -> mvcc.v1.RequestOp.Range
+	19, // 16: mvcc.v1.RequestOp.request_put:type_name -> mvcc.v1.RequestOp.Put
+	20, // 17: mvcc.v1.RequestOp.request_delete_range:type_name -> mvcc.v1.RequestOp.DeleteRange
+	21, // 18: mvcc.v1.RequestOp.request_increment:type_name -> mvcc.v1.RequestOp.Increment
+	11, // 19: mvcc.v1.RequestOp.request_txn:type_name -> mvcc.v1.Txn
+	22, // 20: mvcc.v1.ResponseOp.response_range:type_name -> mvcc.v1.ResponseOp.Range
+	23, // 21: mvcc.v1.ResponseOp.response_put:type_name -> mvcc.v1.ResponseOp.Put
+	24, // 22: mvcc.v1.ResponseOp.response_delete_range:type_name -> mvcc.v1.ResponseOp.DeleteRange
+	25, // 23: mvcc.v1.ResponseOp.response_increment:type_name -> mvcc.v1.ResponseOp.Increment
+	26, // 24: mvcc.v1.ResponseOp.response_txn:type_name -> mvcc.v1.ResponseOp.Txn
+	2,  // 25: mvcc.v1.Compare.result:type_name -> mvcc.v1.Compare.CompareResult
+	3,  // 26: mvcc.v1.Compare.target:type_name -> mvcc.v1.Compare.CompareTarget
+	4,  // 27: mvcc.v1.Event.type:type_name -> mvcc.v1.Event.EventType
+	16, // 28: mvcc.v1.Event.kv:type_name -> mvcc.v1.KeyValue
+	15, // 29: mvcc.v1.RequestOp.Range.filter:type_name -> mvcc.v1.RangeFilter
+	0,  // 30: mvcc.v1.RequestOp.Range.sort_order:type_name -> mvcc.v1.SortOrder
+	16, // 31: mvcc.v1.ResponseOp.Range.kvs:type_name -> mvcc.v1.KeyValue
+	16, // 32: mvcc.v1.ResponseOp.Put.prev_kv:type_name -> mvcc.v1.KeyValue
+	16, // 33: mvcc.v1.ResponseOp.DeleteRange.prev_kvs:type_name -> mvcc.v1.KeyValue
+	13, // 34: mvcc.v1.ResponseOp.Txn.responses:type_name -> mvcc.v1.ResponseOp
+	35, // [35:35] is the sub-list for method output_type
+	35, // [35:35] is the sub-list for method input_type
+	35, // [35:35] is the sub-list for extension type_name
+	35, // [35:35] is the sub-list for extension extendee
+	0,  // [0:35] is the sub-list for field type_name
 }
 
 func init() { file_mvcc_proto_init() }
@@ -1464,7 +2730,7 @@ func file_mvcc_proto_init() {
 			}
 		}
 		file_mvcc_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CommandResult); i {
+			switch v := v.(*RequestResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1476,7 +2742,7 @@ func file_mvcc_proto_init() {
 			}
 		}
 		file_mvcc_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Txn); i {
+			switch v := v.(*Chunk); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1488,7 +2754,7 @@ func file_mvcc_proto_init() {
 			}
 		}
 		file_mvcc_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestOp); i {
+			switch v := v.(*LargeValue); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1500,7 +2766,7 @@ func file_mvcc_proto_init() {
 			}
 		}
 		file_mvcc_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResponseOp); i {
+			switch v := v.(*CommandResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1512,7 +2778,7 @@ func file_mvcc_proto_init() {
 			}
 		}
 		file_mvcc_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Compare); i {
+			switch v := v.(*Lease); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1524,7 +2790,7 @@ func file_mvcc_proto_init() {
 			}
 		}
 		file_mvcc_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*KeyValue); i {
+			switch v := v.(*Txn); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1536,7 +2802,7 @@ func file_mvcc_proto_init() {
 			}
 		}
 		file_mvcc_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestOp_Range); i {
+			switch v := v.(*RequestOp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1548,7 +2814,7 @@ func file_mvcc_proto_init() {
 			}
 		}
 		file_mvcc_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestOp_Put); i {
+			switch v := v.(*ResponseOp); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1560,7 +2826,7 @@ func file_mvcc_proto_init() {
 			}
 		}
 		file_mvcc_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestOp_DeleteRange); i {
+			switch v := v.(*Compare); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1572,7 +2838,7 @@ func file_mvcc_proto_init() {
 			}
 		}
 		file_mvcc_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResponseOp_Range); i {
+			switch v := v.(*RangeFilter); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1584,7 +2850,7 @@ func file_mvcc_proto_init() {
 			}
 		}
 		file_mvcc_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResponseOp_Put); i {
+			switch v := v.(*KeyValue); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1596,6 +2862,90 @@ func file_mvcc_proto_init() {
 			}
 		}
 		file_mvcc_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Event); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mvcc_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestOp_Range); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mvcc_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestOp_Put); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mvcc_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestOp_DeleteRange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mvcc_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestOp_Increment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mvcc_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseOp_Range); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mvcc_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseOp_Put); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mvcc_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ResponseOp_DeleteRange); i {
 			case 0:
 				return &v.state
@@ -1607,28 +2957,61 @@ func file_mvcc_proto_init() {
 				return nil
 			}
 		}
+		file_mvcc_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseOp_Increment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mvcc_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseOp_Txn); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_mvcc_proto_msgTypes[0].OneofWrappers = []interface{}{}
-	file_mvcc_proto_msgTypes[3].OneofWrappers = []interface{}{
+	file_mvcc_proto_msgTypes[7].OneofWrappers = []interface{}{
 		(*RequestOp_RequestRange)(nil),
 		(*RequestOp_RequestPut)(nil),
 		(*RequestOp_RequestDeleteRange)(nil),
+		(*RequestOp_RequestIncrement)(nil),
+		(*RequestOp_RequestTxn)(nil),
 	}
-	file_mvcc_proto_msgTypes[4].OneofWrappers = []interface{}{
+	file_mvcc_proto_msgTypes[8].OneofWrappers = []interface{}{
 		(*ResponseOp_ResponseRange)(nil),
 		(*ResponseOp_ResponsePut)(nil),
 		(*ResponseOp_ResponseDeleteRange)(nil),
+		(*ResponseOp_ResponseIncrement)(nil),
+		(*ResponseOp_ResponseTxn)(nil),
 	}
-	file_mvcc_proto_msgTypes[5].OneofWrappers = []interface{}{
+	file_mvcc_proto_msgTypes[9].OneofWrappers = []interface{}{
 		(*Compare_Value)(nil),
+		(*Compare_CreateRevision)(nil),
+		(*Compare_ModRevision)(nil),
+		(*Compare_Version)(nil),
+		(*Compare_Lease)(nil),
 	}
+	file_mvcc_proto_msgTypes[16].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_mvcc_proto_rawDesc,
-			NumEnums:      3,
-			NumMessages:   13,
+			NumEnums:      5,
+			NumMessages:   22,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,256 @@
+// Copyright JAMF Software, LLC
+
+package regattaserver
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/jamf/regatta/regattapb"
+	"github.com/jamf/regatta/storage/table"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maintenanceServicePrefix prefix of the full method names of the maintenance service.
+	maintenanceServicePrefix = "/maintenance.v1.Maintenance/"
+	// leaseServicePrefix prefix of the full method names of the Lease service.
+	leaseServicePrefix = "/regatta.v1.Lease/"
+	// lockServicePrefix prefix of the full method names of the Lock service.
+	lockServicePrefix = "/regatta.v1.Lock/"
+	// electionServicePrefix prefix of the full method names of the Election service.
+	electionServicePrefix = "/regatta.v1.Election/"
+)
+
+// auditedMethods are the methods changing the tables, the users and roles or the keys other than the KV service writes.
+var auditedMethods = map[string]struct{}{
+	maintenanceServicePrefix + "Reset":       {},
+	maintenanceServicePrefix + "Restore":     {},
+	maintenanceServicePrefix + "Compact":     {},
+	maintenanceServicePrefix + "CreateTable": {},
+	maintenanceServicePrefix + "UpdateTable": {},
+	maintenanceServicePrefix + "DeleteTable": {},
+	maintenanceServicePrefix + "PutUser":     {},
+	maintenanceServicePrefix + "DeleteUser":  {},
+	maintenanceServicePrefix + "PutRole":     {},
+	maintenanceServicePrefix + "DeleteRole":  {},
+	leaseServicePrefix + "LeaseRevoke":       {},
+	lockServicePrefix + "Lock":               {},
+	lockServicePrefix + "Unlock":             {},
+	electionServicePrefix + "Campaign":       {},
+	electionServicePrefix + "Proclaim":       {},
+	electionServicePrefix + "Resign":         {},
+}
+
+// AuditRecord is the record of a single mutating request written by the Auditor as a JSON object on its own line.
+type AuditRecord struct {
+	// Time when the request finished.
+	Time time.Time `json:"time"`
+	// Method is the full gRPC method name, e.g. /regatta.v1.KV/Put.
+	Method string `json:"method"`
+	// Table is the name of the table, table.AnyTable if the request changes all the tables.
+	Table string `json:"table,omitempty"`
+	// Keys are the keys and the key ranges written by the request.
+	Keys []AuditKey `json:"keys,omitempty"`
+	// Lease is the ID of the lease revoked by the request along with its keys.
+	Lease int64 `json:"lease,omitempty"`
+	// Name is the name of the user or the role changed by the request.
+	Name string `json:"name,omitempty"`
+	// Revision is the revision of the table the write was applied at.
+	Revision uint64 `json:"revision,omitempty"`
+	// User is the name of the user authenticated by the Authorizer or the common name of the verified client certificate.
+	User string `json:"user,omitempty"`
+	// Peer is the address of the client.
+	Peer string `json:"peer,omitempty"`
+	// Code is the gRPC status code of the request, OK if the request succeeded.
+	Code string `json:"code"`
+	// Error is the error message of the failed request.
+	Error string `json:"error,omitempty"`
+}
+
+// AuditKey is the key or the key range [Key, RangeEnd) written by the request.
+type AuditKey struct {
+	Key      []byte `json:"key"`
+	RangeEnd []byte `json:"range_end,omitempty"`
+}
+
+// Auditor records the mutating requests of the API and maintenance services, both succeeded and failed. Records are
+// written as the newline delimited JSON, a record that cannot be written is logged and does not fail the request.
+type Auditor struct {
+	mu  sync.Mutex
+	w   io.Writer
+	now func() time.Time
+	log *zap.SugaredLogger
+}
+
+// NewAuditor returns the Auditor writing the records into the writer.
+func NewAuditor(w io.Writer) *Auditor {
+	return &Auditor{w: w, now: time.Now, log: zap.S().Named("audit")}
+}
+
+// UnaryServerInterceptor returns the interceptor recording the unary calls.
+func (a *Auditor) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !audited(info.FullMethod, req) {
+			return handler(ctx, req)
+		}
+		res, err := handler(ctx, req)
+		a.record(ctx, info.FullMethod, req, res, err)
+		return res, err
+	}
+}
+
+// StreamServerInterceptor returns the interceptor recording the client streaming calls by their first received message.
+func (a *Auditor) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !info.IsClientStream || !audited(info.FullMethod, nil) {
+			return handler(srv, ss)
+		}
+		as := &auditedStream{ServerStream: ss}
+		err := handler(srv, as)
+		a.record(ss.Context(), info.FullMethod, as.req, as.res, err)
+		return err
+	}
+}
+
+type auditedStream struct {
+	grpc.ServerStream
+	req interface{}
+	res interface{}
+}
+
+func (s *auditedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.req == nil {
+		s.req = m
+	}
+	return nil
+}
+
+func (s *auditedStream) SendMsg(m interface{}) error {
+	s.res = m
+	return s.ServerStream.SendMsg(m)
+}
+
+// audited returns true for the requests to be recorded, the request is nil for the streaming calls.
+func audited(method string, req interface{}) bool {
+	if _, ok := auditedMethods[method]; ok {
+		return true
+	}
+	return isWrite(method, req)
+}
+
+func (a *Auditor) record(ctx context.Context, method string, req, res interface{}, err error) {
+	rec := AuditRecord{
+		Time:   a.now(),
+		Method: method,
+		Table:  auditTable(req),
+		Keys:   auditKeys(req, res),
+		Name:   auditName(req),
+		Code:   status.Code(err).String(),
+	}
+	if r, ok := req.(*regattapb.LeaseRevokeRequest); ok {
+		rec.Lease = r.ID
+	}
+	if err != nil {
+		rec.Error = status.Convert(err).Message()
+	}
+	if r, ok := res.(interface {
+		GetHeader() *regattapb.ResponseHeader
+	}); ok {
+		rec.Revision = r.GetHeader().GetRevision()
+	}
+	if user, ok := UserFromContext(ctx); ok {
+		rec.User = user
+	} else if subject, ok := VerifiedSubject(ctx); ok {
+		rec.User = subject.CommonName
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		rec.Peer = p.Addr.String()
+	}
+	bts, merr := json.Marshal(rec)
+	if merr != nil {
+		a.log.Errorf("cannot encode audit record of %s: %v", method, merr)
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, werr := a.w.Write(append(bts, '\n')); werr != nil {
+		a.log.Errorf("cannot write audit record of %s: %v", method, werr)
+	}
+}
+
+// auditTable returns the name of the table the request changes.
+func auditTable(req interface{}) string {
+	switch r := req.(type) {
+	case *regattapb.ResetRequest:
+		if r.ResetAll {
+			return table.AnyTable
+		}
+		return string(r.Table)
+	case *regattapb.RestoreMessage:
+		return string(r.GetInfo().GetTable())
+	case interface{ GetTable() []byte }:
+		return string(r.GetTable())
+	case interface{ GetName() []byte }:
+		return string(r.GetName())
+	default:
+		return ""
+	}
+}
+
+// auditKeys returns the keys written by the request, the keys created by the Lock and Campaign requests are taken from the response.
+func auditKeys(req, res interface{}) []AuditKey {
+	switch r := req.(type) {
+	case *regattapb.PutRequest, *regattapb.DeleteRangeRequest, *regattapb.IncrementRequest, *regattapb.PutLargeRequest, *regattapb.TxnRequest:
+	case *regattapb.LockRequest:
+		if l, _ := res.(*regattapb.LockResponse); len(l.GetKey()) > 0 {
+			return []AuditKey{{Key: l.GetKey()}}
+		}
+		return nil
+	case *regattapb.CampaignRequest:
+		if c, _ := res.(*regattapb.CampaignResponse); len(c.GetLeader().GetKey()) > 0 {
+			return []AuditKey{{Key: c.GetLeader().GetKey()}}
+		}
+		return nil
+	case *regattapb.UnlockRequest:
+		return []AuditKey{{Key: r.Key}}
+	case *regattapb.ProclaimRequest:
+		return []AuditKey{{Key: r.GetLeader().GetKey()}}
+	case *regattapb.ResignRequest:
+		return []AuditKey{{Key: r.GetLeader().GetKey()}}
+	default:
+		return nil
+	}
+	var keys []AuditKey
+	for _, acc := range kvAccesses(req) {
+		if acc.write {
+			keys = append(keys, AuditKey{Key: acc.key, RangeEnd: acc.rangeEnd})
+		}
+	}
+	return keys
+}
+
+// auditName returns the name of the user or the role the request changes.
+func auditName(req interface{}) string {
+	switch r := req.(type) {
+	case *regattapb.PutUserRequest:
+		return r.Name
+	case *regattapb.DeleteUserRequest:
+		return r.Name
+	case *regattapb.PutRoleRequest:
+		return r.GetRole().GetName()
+	case *regattapb.DeleteRoleRequest:
+		return r.Name
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,293 @@
+// Copyright JAMF Software, LLC
+
+package regattaserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jamf/regatta/regattapb"
+	serrors "github.com/jamf/regatta/storage/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func readAuditRecords(t *testing.T, buf *bytes.Buffer) []AuditRecord {
+	t.Helper()
+	var res []AuditRecord
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var rec AuditRecord
+		require.NoError(t, json.Unmarshal([]byte(line), &rec))
+		res = append(res, rec)
+	}
+	buf.Reset()
+	return res
+}
+
+func TestAuditor_UnaryServerInterceptor(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		method string
+		req    interface{}
+		res    interface{}
+		err    error
+		want   []AuditRecord
+	}{
+		{
+			name:   "put",
+			ctx:    context.WithValue(peerContext("10.0.0.1:1000"), userKey{}, "user"),
+			method: "/regatta.v1.KV/Put",
+			req:    &regattapb.PutRequest{Table: table1Name, Key: key1Name, Value: table1Value1},
+			res:    &regattapb.PutResponse{Header: &regattapb.ResponseHeader{Revision: 5}},
+			want: []AuditRecord{{
+				Time: now, Method: "/regatta.v1.KV/Put", Table: string(table1Name), Keys: []AuditKey{{Key: key1Name}},
+				Revision: 5, User: "user", Peer: "10.0.0.1:1000", Code: "OK",
+			}},
+		},
+		{
+			name:   "failed delete range",
+			ctx:    certContext("client"),
+			method: "/regatta.v1.KV/DeleteRange",
+			req:    &regattapb.DeleteRangeRequest{Table: table1Name, Key: key1Name, RangeEnd: key2Name},
+			err:    serrors.ErrTableNotFound,
+			want: []AuditRecord{{
+				Time: now, Method: "/regatta.v1.KV/DeleteRange", Table: string(table1Name), Keys: []AuditKey{{Key: key1Name, RangeEnd: key2Name}},
+				User: "client", Code: "Unknown", Error: serrors.ErrTableNotFound.Error(),
+			}},
+		},
+		{
+			name:   "writable txn",
+			ctx:    context.Background(),
+			method: "/regatta.v1.KV/Txn",
+			req: &regattapb.TxnRequest{
+				Table:   table1Name,
+				Compare: []*regattapb.Compare{{Key: key3Name}},
+				Success: []*regattapb.RequestOp{
+					{Request: &regattapb.RequestOp_RequestPut{RequestPut: &regattapb.RequestOp_Put{Key: key1Name}}},
+					{Request: &regattapb.RequestOp_RequestRange{RequestRange: &regattapb.RequestOp_Range{Key: key3Name}}},
+				},
+				Failure: []*regattapb.RequestOp{
+					{Request: &regattapb.RequestOp_RequestDeleteRange{RequestDeleteRange: &regattapb.RequestOp_DeleteRange{Key: key2Name}}},
+				},
+			},
+			res: &regattapb.TxnResponse{Header: &regattapb.ResponseHeader{Revision: 7}, Succeeded: true},
+			want: []AuditRecord{{
+				Time: now, Method: "/regatta.v1.KV/Txn", Table: string(table1Name), Keys: []AuditKey{{Key: key1Name}, {Key: key2Name}},
+				Revision: 7, Code: "OK",
+			}},
+		},
+		{
+			name:   "read-only txn",
+			ctx:    context.Background(),
+			method: "/regatta.v1.KV/Txn",
+			req: &regattapb.TxnRequest{Table: table1Name, Success: []*regattapb.RequestOp{
+				{Request: &regattapb.RequestOp_RequestRange{RequestRange: &regattapb.RequestOp_Range{Key: key1Name}}},
+			}},
+			res: &regattapb.TxnResponse{},
+		},
+		{
+			name:   "range",
+			ctx:    context.Background(),
+			method: "/regatta.v1.KV/Range",
+			req:    &regattapb.RangeRequest{Table: table1Name, Key: key1Name},
+			res:    &regattapb.RangeResponse{},
+		},
+		{
+			name:   "reset all",
+			ctx:    context.Background(),
+			method: "/maintenance.v1.Maintenance/Reset",
+			req:    &regattapb.ResetRequest{ResetAll: true},
+			res:    &regattapb.ResetResponse{},
+			want:   []AuditRecord{{Time: now, Method: "/maintenance.v1.Maintenance/Reset", Table: "*", Code: "OK"}},
+		},
+		{
+			name:   "delete table",
+			ctx:    context.Background(),
+			method: "/maintenance.v1.Maintenance/DeleteTable",
+			req:    &regattapb.DeleteTableRequest{Name: table1Name},
+			err:    status.Error(codes.NotFound, "table not found"),
+			want: []AuditRecord{{
+				Time: now, Method: "/maintenance.v1.Maintenance/DeleteTable", Table: string(table1Name), Code: "NotFound", Error: "table not found",
+			}},
+		},
+		{
+			name:   "put user",
+			ctx:    context.Background(),
+			method: "/maintenance.v1.Maintenance/PutUser",
+			req:    &regattapb.PutUserRequest{Name: "user", Token: "secret", Roles: []string{"admin"}},
+			res:    &regattapb.PutUserResponse{},
+			want:   []AuditRecord{{Time: now, Method: "/maintenance.v1.Maintenance/PutUser", Name: "user", Code: "OK"}},
+		},
+		{
+			name:   "lock",
+			ctx:    context.Background(),
+			method: "/regatta.v1.Lock/Lock",
+			req:    &regattapb.LockRequest{Table: table1Name, Name: []byte("lock"), Lease: 1},
+			res:    &regattapb.LockResponse{Header: &regattapb.ResponseHeader{Revision: 4}, Key: []byte("lock/1")},
+			want: []AuditRecord{{
+				Time: now, Method: "/regatta.v1.Lock/Lock", Table: string(table1Name), Keys: []AuditKey{{Key: []byte("lock/1")}}, Revision: 4, Code: "OK",
+			}},
+		},
+		{
+			name:   "failed campaign",
+			ctx:    context.Background(),
+			method: "/regatta.v1.Election/Campaign",
+			req:    &regattapb.CampaignRequest{Table: table1Name, Name: []byte("election"), Lease: 1},
+			err:    status.Error(codes.DeadlineExceeded, "context deadline exceeded"),
+			want: []AuditRecord{{
+				Time: now, Method: "/regatta.v1.Election/Campaign", Table: string(table1Name), Code: "DeadlineExceeded", Error: "context deadline exceeded",
+			}},
+		},
+		{
+			name:   "lease revoke",
+			ctx:    context.Background(),
+			method: "/regatta.v1.Lease/LeaseRevoke",
+			req:    &regattapb.LeaseRevokeRequest{Table: table1Name, ID: 10},
+			res:    &regattapb.LeaseRevokeResponse{},
+			want:   []AuditRecord{{Time: now, Method: "/regatta.v1.Lease/LeaseRevoke", Table: string(table1Name), Lease: 10, Code: "OK"}},
+		},
+		{
+			name:   "list tables",
+			ctx:    context.Background(),
+			method: "/maintenance.v1.Maintenance/ListTables",
+			req:    &regattapb.ListTablesRequest{},
+			res:    &regattapb.ListTablesResponse{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			buf := &bytes.Buffer{}
+			a := NewAuditor(buf)
+			a.now = func() time.Time { return now }
+			res, err := a.UnaryServerInterceptor()(tt.ctx, tt.req, &grpc.UnaryServerInfo{FullMethod: tt.method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return tt.res, tt.err
+			})
+			r.Equal(tt.res, res)
+			r.Equal(tt.err, err)
+			r.Equal(tt.want, readAuditRecords(t, buf))
+		})
+	}
+}
+
+type sendingServerStream struct {
+	testServerStream
+	sent []interface{}
+}
+
+func (s *sendingServerStream) SendMsg(m interface{}) error {
+	s.sent = append(s.sent, m)
+	return nil
+}
+
+func TestAuditor_StreamServerInterceptor(t *testing.T) {
+	r := require.New(t)
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	buf := &bytes.Buffer{}
+	a := NewAuditor(buf)
+	a.now = func() time.Time { return now }
+	putLarge := func(_ interface{}, ss grpc.ServerStream) error {
+		for {
+			if err := ss.RecvMsg(&regattapb.PutLargeRequest{}); err != nil {
+				if errors.Is(err, io.EOF) {
+					return ss.SendMsg(&regattapb.PutLargeResponse{Header: &regattapb.ResponseHeader{Revision: 3}})
+				}
+				return err
+			}
+		}
+	}
+
+	t.Log("put large is recorded by the first message")
+	ss := &sendingServerStream{testServerStream: testServerStream{
+		ctx:  peerContext("10.0.0.1:1000"),
+		msgs: []proto.Message{&regattapb.PutLargeRequest{Table: table1Name, Key: key1Name}, &regattapb.PutLargeRequest{}, &regattapb.PutLargeRequest{}},
+	}}
+	err := a.StreamServerInterceptor()(nil, ss, &grpc.StreamServerInfo{FullMethod: "/regatta.v1.KV/PutLarge", IsClientStream: true}, putLarge)
+	r.NoError(err)
+	r.Len(ss.sent, 1)
+	r.Equal([]AuditRecord{{
+		Time: now, Method: "/regatta.v1.KV/PutLarge", Table: string(table1Name), Keys: []AuditKey{{Key: key1Name}},
+		Revision: 3, Peer: "10.0.0.1:1000", Code: "OK",
+	}}, readAuditRecords(t, buf))
+
+	t.Log("server streams are not recorded")
+	err = a.StreamServerInterceptor()(nil, ss, &grpc.StreamServerInfo{FullMethod: "/regatta.v1.KV/Cursor", IsServerStream: true}, func(interface{}, grpc.ServerStream) error {
+		return nil
+	})
+	r.NoError(err)
+	r.Empty(readAuditRecords(t, buf))
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestAuditor_WriteError(t *testing.T) {
+	r := require.New(t)
+	a := NewAuditor(failingWriter{})
+	res, err := a.UnaryServerInterceptor()(context.Background(), &regattapb.PutRequest{Table: table1Name, Key: key1Name}, &grpc.UnaryServerInfo{FullMethod: "/regatta.v1.KV/Put"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &regattapb.PutResponse{}, nil
+	})
+	r.NoError(err)
+	r.NotNil(res)
+}
+
+func Test_audited(t *testing.T) {
+	mutating := map[string]bool{
+		"/maintenance.v1.Maintenance/Restore":     true,
+		"/maintenance.v1.Maintenance/Reset":       true,
+		"/maintenance.v1.Maintenance/Compact":     true,
+		"/maintenance.v1.Maintenance/CreateTable": true,
+		"/maintenance.v1.Maintenance/DeleteTable": true,
+		"/maintenance.v1.Maintenance/UpdateTable": true,
+		"/maintenance.v1.Maintenance/PutUser":     true,
+		"/maintenance.v1.Maintenance/DeleteUser":  true,
+		"/maintenance.v1.Maintenance/PutRole":     true,
+		"/maintenance.v1.Maintenance/DeleteRole":  true,
+		"/regatta.v1.KV/Put":                      true,
+		"/regatta.v1.KV/DeleteRange":              true,
+		"/regatta.v1.KV/Increment":                true,
+		"/regatta.v1.KV/PutLarge":                 true,
+		"/regatta.v1.KV/Txn":                      true,
+		"/regatta.v1.Lease/LeaseRevoke":           true,
+		"/regatta.v1.Lock/Lock":                   true,
+		"/regatta.v1.Lock/Unlock":                 true,
+		"/regatta.v1.Election/Campaign":           true,
+		"/regatta.v1.Election/Proclaim":           true,
+		"/regatta.v1.Election/Resign":             true,
+	}
+	writableTxn := &regattapb.TxnRequest{Table: table1Name, Success: []*regattapb.RequestOp{
+		{Request: &regattapb.RequestOp_RequestPut{RequestPut: &regattapb.RequestOp_Put{Key: key1Name}}},
+	}}
+	for _, desc := range []grpc.ServiceDesc{regattapb.Maintenance_ServiceDesc, regattapb.KV_ServiceDesc, regattapb.Lease_ServiceDesc, regattapb.Lock_ServiceDesc, regattapb.Election_ServiceDesc} {
+		var methods []string
+		for _, m := range desc.Methods {
+			methods = append(methods, m.MethodName)
+		}
+		for _, s := range desc.Streams {
+			methods = append(methods, s.StreamName)
+		}
+		for _, m := range methods {
+			method := "/" + desc.ServiceName + "/" + m
+			t.Run(method, func(t *testing.T) {
+				require.Equal(t, mutating[method], audited(method, writableTxn))
+			})
+		}
+	}
+}
@@ -0,0 +1,353 @@
+// Copyright JAMF Software, LLC
+
+package regattaserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509/pkix"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	grpc_auth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
+	"github.com/jamf/regatta/regattapb"
+	"github.com/jamf/regatta/storage/table"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// authCacheTTL how long are the users and roles cached by the Authorizer.
+	authCacheTTL = 5 * time.Second
+	// kvServicePrefix prefix of the full method names of the KV service, the KV requests are authorized per key range.
+	kvServicePrefix = "/regatta.v1.KV/"
+	// apiServicesPrefix prefix of the full method names of the API services requiring the authentication.
+	apiServicesPrefix = "/regatta.v1."
+	// ForwardedUserHeader name of the metadata carrying the name of the user of the request forwarded by the follower cluster,
+	// the user is authenticated by the follower cluster by the client certificate.
+	ForwardedUserHeader = "regatta-forwarded-user"
+)
+
+// wildcard the range end of the ranges of all the keys greater than or equal to the key.
+var wildcard = []byte{0}
+
+type userKey struct{}
+
+// UserFromContext returns the name of the user authenticated by the Authorizer.
+func UserFromContext(ctx context.Context) (string, bool) {
+	user, ok := ctx.Value(userKey{}).(string)
+	return user, ok
+}
+
+// Authorizer authenticates the API clients by the bearer token or by the verified client certificate and authorizes
+// their requests by the permissions of the roles granted to them. The KV requests are checked against the permissions
+// covering the requested table and keys, the other API requests require the write permission on the whole table.
+type Authorizer struct {
+	Auth AuthService
+	// Forwarders are the common names of the client certificates of the follower clusters trusted to forward
+	// the requests of the users they authenticated by the client certificates, see ForwardedUserHeader.
+	Forwarders []string
+	mu         sync.Mutex
+	now        func() time.Time
+	// cache of the users and roles, reloaded once older than authCacheTTL.
+	cache *authCache
+}
+
+type authCache struct {
+	byToken map[string]table.User
+	byName  map[string]table.User
+	roles   map[string]table.Role
+	loaded  time.Time
+}
+
+// NewAuthorizer returns the Authorizer reading the users and roles from the service.
+func NewAuthorizer(auth AuthService) *Authorizer {
+	return &Authorizer{Auth: auth, now: time.Now}
+}
+
+// UnaryServerInterceptor returns the interceptor authorizing the unary calls.
+func (a *Authorizer) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !strings.HasPrefix(info.FullMethod, apiServicesPrefix) {
+			return handler(ctx, req)
+		}
+		user, roles, err := a.authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := authorize(user, roles, info.FullMethod, req); err != nil {
+			return nil, err
+		}
+		return handler(context.WithValue(ctx, userKey{}, user.Name), req)
+	}
+}
+
+// StreamServerInterceptor returns the interceptor authorizing the streaming calls by every received message.
+func (a *Authorizer) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !strings.HasPrefix(info.FullMethod, apiServicesPrefix) {
+			return handler(srv, ss)
+		}
+		user, roles, err := a.authenticate(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authorizedStream{
+			ServerStream: ss,
+			ctx:          context.WithValue(ss.Context(), userKey{}, user.Name),
+			authorize: func(m interface{}) error {
+				return authorize(user, roles, info.FullMethod, m)
+			},
+		})
+	}
+}
+
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx        context.Context
+	authorize  func(m interface{}) error
+	authorized bool
+}
+
+func (s *authorizedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *authorizedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.authorized && continuation(m) {
+		return nil
+	}
+	if err := s.authorize(m); err != nil {
+		return err
+	}
+	s.authorized = true
+	return nil
+}
+
+// continuation returns true for the messages continuing the request authorized by the first message of the stream,
+// i.e. the chunks of the PutLarge value not naming the table and key.
+func continuation(m interface{}) bool {
+	r, ok := m.(*regattapb.PutLargeRequest)
+	return ok && len(r.GetTable()) == 0 && len(r.GetKey()) == 0
+}
+
+// authenticate returns the user identified by the bearer token or by the common name of the verified client certificate
+// along with the roles to authorize its requests by.
+func (a *Authorizer) authenticate(ctx context.Context) (table.User, map[string]table.Role, error) {
+	cache, err := a.load()
+	if err != nil {
+		return table.User{}, nil, err
+	}
+	if token, err := grpc_auth.AuthFromMD(ctx, "bearer"); err == nil {
+		if user, ok := cache.byToken[table.HashToken(token)]; ok {
+			return user, cache.roles, nil
+		}
+		return table.User{}, nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	if subject, ok := VerifiedSubject(ctx); ok {
+		if forwarded := metadata.ValueFromIncomingContext(ctx, ForwardedUserHeader); len(forwarded) > 0 {
+			if !slices.Contains(a.Forwarders, subject.CommonName) {
+				return table.User{}, nil, status.Errorf(codes.Unauthenticated, "%q is not permitted to forward requests", subject.CommonName)
+			}
+			if user, ok := cache.byName[forwarded[0]]; ok {
+				return user, cache.roles, nil
+			}
+			return table.User{}, nil, status.Errorf(codes.Unauthenticated, "unknown user %q", forwarded[0])
+		}
+		if user, ok := cache.byName[subject.CommonName]; ok {
+			return user, cache.roles, nil
+		}
+		return table.User{}, nil, status.Errorf(codes.Unauthenticated, "unknown user %q", subject.CommonName)
+	}
+	return table.User{}, nil, status.Error(codes.Unauthenticated, "token or client certificate required")
+}
+
+// VerifiedSubject returns the subject of the client certificate of the peer verified by the server.
+func VerifiedSubject(ctx context.Context) (pkix.Name, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return pkix.Name{}, false
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return pkix.Name{}, false
+	}
+	return info.State.VerifiedChains[0][0].Subject, true
+}
+
+func (a *Authorizer) load() (*authCache, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.cache != nil && a.now().Sub(a.cache.loaded) < authCacheTTL {
+		return a.cache, nil
+	}
+	users, err := a.Auth.GetUsers()
+	if err != nil {
+		if a.cache != nil {
+			// Keep serving the last known users and roles while the meta store is unavailable.
+			return a.cache, nil
+		}
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	roles, err := a.Auth.GetRoles()
+	if err != nil {
+		if a.cache != nil {
+			return a.cache, nil
+		}
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	cache := &authCache{
+		byToken: make(map[string]table.User, len(users)),
+		byName:  make(map[string]table.User, len(users)),
+		roles:   make(map[string]table.Role, len(roles)),
+		loaded:  a.now(),
+	}
+	for _, u := range users {
+		cache.byName[u.Name] = u
+		if u.TokenHash != "" {
+			cache.byToken[u.TokenHash] = u
+		}
+	}
+	for _, r := range roles {
+		cache.roles[r.Name] = r
+	}
+	a.cache = cache
+	return cache, nil
+}
+
+// access is a read or write access to the key range of the table.
+type access struct {
+	table    []byte
+	key      []byte
+	rangeEnd []byte
+	write    bool
+}
+
+// authorize checks that the user is permitted all the accesses of the request.
+func authorize(user table.User, roles map[string]table.Role, method string, req interface{}) error {
+	var accesses []access
+	if strings.HasPrefix(method, kvServicePrefix) {
+		accesses = kvAccesses(req)
+	} else if r, ok := req.(interface{ GetTable() []byte }); ok {
+		accesses = []access{{table: r.GetTable(), key: wildcard, rangeEnd: wildcard, write: true}}
+	}
+	for _, acc := range accesses {
+		if !permitted(user, roles, acc) {
+			op := "read"
+			if acc.write {
+				op = "write"
+			}
+			return status.Errorf(codes.PermissionDenied, "user %q is not permitted to %s table %q", user.Name, op, acc.table)
+		}
+	}
+	return nil
+}
+
+func permitted(user table.User, roles map[string]table.Role, acc access) bool {
+	for _, name := range user.Roles {
+		for _, p := range roles[name].Permissions {
+			if p.Table != table.AnyTable && p.Table != string(acc.table) {
+				continue
+			}
+			if (acc.write && !p.Write) || (!acc.write && !p.Read) {
+				continue
+			}
+			if prefixCovers(p.KeyPrefix, acc.key, acc.rangeEnd) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// prefixCovers returns true if all the keys of the range [key, rangeEnd) have the prefix.
+func prefixCovers(prefix, key, rangeEnd []byte) bool {
+	if len(prefix) == 0 {
+		return true
+	}
+	if !bytes.HasPrefix(key, prefix) {
+		return false
+	}
+	if len(rangeEnd) == 0 {
+		return true
+	}
+	end := incrementRightmostByte(prefix)
+	if end == nil {
+		// All the keys starting by the prefix of 0xff bytes have the prefix.
+		return true
+	}
+	if bytes.Equal(rangeEnd, wildcard) {
+		return false
+	}
+	return bytes.Compare(rangeEnd, end) <= 0
+}
+
+// incrementRightmostByte returns the smallest key greater than all the keys with the prefix, nil if there is none.
+func incrementRightmostByte(prefix []byte) []byte {
+	end := bytes.Clone(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
+// kvAccesses returns the accesses of the KV service request.
+func kvAccesses(req interface{}) []access {
+	switch r := req.(type) {
+	case *regattapb.RangeRequest:
+		return []access{{table: r.Table, key: r.Key, rangeEnd: r.RangeEnd}}
+	case *regattapb.WatchRequest:
+		return []access{{table: r.Table, key: r.Key, rangeEnd: r.RangeEnd}}
+	case *regattapb.GetLargeRequest:
+		return []access{{table: r.Table, key: r.Key}}
+	case *regattapb.PutRequest:
+		return []access{{table: r.Table, key: r.Key, write: true}}
+	case *regattapb.IncrementRequest:
+		return []access{{table: r.Table, key: r.Key, write: true}}
+	case *regattapb.PutLargeRequest:
+		return []access{{table: r.Table, key: r.Key, write: true}}
+	case *regattapb.DeleteRangeRequest:
+		return []access{{table: r.Table, key: r.Key, rangeEnd: r.RangeEnd, write: true}}
+	case *regattapb.TxnRequest:
+		return txnAccesses(r.Table, r.Compare, r.Success, r.Failure)
+	default:
+		// Unknown requests of the KV service are denied as the whole table write.
+		return []access{{key: wildcard, rangeEnd: wildcard, write: true}}
+	}
+}
+
+func txnAccesses(tbl []byte, compare []*regattapb.Compare, success, failure []*regattapb.RequestOp) []access {
+	var res []access
+	for _, c := range compare {
+		res = append(res, access{table: tbl, key: c.Key, rangeEnd: c.RangeEnd})
+	}
+	for _, ops := range [][]*regattapb.RequestOp{success, failure} {
+		for _, op := range ops {
+			switch o := op.Request.(type) {
+			case *regattapb.RequestOp_RequestRange:
+				res = append(res, access{table: tbl, key: o.RequestRange.Key, rangeEnd: o.RequestRange.RangeEnd})
+			case *regattapb.RequestOp_RequestPut:
+				res = append(res, access{table: tbl, key: o.RequestPut.Key, write: true})
+			case *regattapb.RequestOp_RequestDeleteRange:
+				res = append(res, access{table: tbl, key: o.RequestDeleteRange.Key, rangeEnd: o.RequestDeleteRange.RangeEnd, write: true})
+			case *regattapb.RequestOp_RequestIncrement:
+				res = append(res, access{table: tbl, key: o.RequestIncrement.Key, write: true})
+			case *regattapb.RequestOp_RequestTxn:
+				res = append(res, txnAccesses(tbl, o.RequestTxn.Compare, o.RequestTxn.Success, o.RequestTxn.Failure)...)
+			}
+		}
+	}
+	return res
+}
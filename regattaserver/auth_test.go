@@ -0,0 +1,327 @@
+// Copyright JAMF Software, LLC
+
+package regattaserver
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/jamf/regatta/regattapb"
+	"github.com/jamf/regatta/storage/table"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func testAuthService() *MockAuthService {
+	return &MockAuthService{
+		users: []table.User{
+			{Name: "reader", TokenHash: table.HashToken("reader-token"), Roles: []string{"tenant-read"}},
+			{Name: "writer", TokenHash: table.HashToken("writer-token"), Roles: []string{"tenant-read", "tenant-write"}},
+			{Name: "admin", Roles: []string{"admin"}},
+			{Name: "table-writer", Roles: []string{"table-write"}},
+		},
+		roles: []table.Role{
+			{Name: "tenant-read", Permissions: []table.Permission{{Table: string(table1Name), KeyPrefix: []byte("tenant/"), Read: true}}},
+			{Name: "tenant-write", Permissions: []table.Permission{{Table: string(table1Name), KeyPrefix: []byte("tenant/"), Write: true}}},
+			{Name: "admin", Permissions: []table.Permission{{Table: table.AnyTable, Read: true, Write: true}}},
+			{Name: "table-write", Permissions: []table.Permission{{Table: string(table1Name), Write: true}}},
+		},
+	}
+}
+
+func tokenContext(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func certContext(cn string) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: cn}}}},
+	}}})
+}
+
+func TestAuthorizer_UnaryServerInterceptor(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		method   string
+		req      interface{}
+		wantUser string
+		wantCode codes.Code
+	}{
+		{
+			name:     "no credentials",
+			ctx:      context.Background(),
+			method:   "/regatta.v1.KV/Range",
+			req:      &regattapb.RangeRequest{Table: table1Name, Key: []byte("tenant/key")},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "invalid token",
+			ctx:      tokenContext("unknown"),
+			method:   "/regatta.v1.KV/Range",
+			req:      &regattapb.RangeRequest{Table: table1Name, Key: []byte("tenant/key")},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "unknown certificate",
+			ctx:      certContext("unknown"),
+			method:   "/regatta.v1.KV/Range",
+			req:      &regattapb.RangeRequest{Table: table1Name, Key: []byte("tenant/key")},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "read key",
+			ctx:      tokenContext("reader-token"),
+			method:   "/regatta.v1.KV/Range",
+			req:      &regattapb.RangeRequest{Table: table1Name, Key: []byte("tenant/key")},
+			wantUser: "reader",
+		},
+		{
+			name:     "read prefix range",
+			ctx:      tokenContext("reader-token"),
+			method:   "/regatta.v1.KV/Range",
+			req:      &regattapb.RangeRequest{Table: table1Name, Key: []byte("tenant/"), RangeEnd: []byte("tenant0")},
+			wantUser: "reader",
+		},
+		{
+			name:     "read range out of prefix",
+			ctx:      tokenContext("reader-token"),
+			method:   "/regatta.v1.KV/Range",
+			req:      &regattapb.RangeRequest{Table: table1Name, Key: []byte("tenant/"), RangeEnd: wildcard},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "read other table",
+			ctx:      tokenContext("reader-token"),
+			method:   "/regatta.v1.KV/Range",
+			req:      &regattapb.RangeRequest{Table: table2Name, Key: []byte("tenant/key")},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "write without permission",
+			ctx:      tokenContext("reader-token"),
+			method:   "/regatta.v1.KV/Put",
+			req:      &regattapb.PutRequest{Table: table1Name, Key: []byte("tenant/key")},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "write",
+			ctx:      tokenContext("writer-token"),
+			method:   "/regatta.v1.KV/Put",
+			req:      &regattapb.PutRequest{Table: table1Name, Key: []byte("tenant/key")},
+			wantUser: "writer",
+		},
+		{
+			name:   "nested txn write out of prefix",
+			ctx:    tokenContext("writer-token"),
+			method: "/regatta.v1.KV/Txn",
+			req: &regattapb.TxnRequest{Table: table1Name, Success: []*regattapb.RequestOp{
+				{Request: &regattapb.RequestOp_RequestPut{RequestPut: &regattapb.RequestOp_Put{Key: []byte("tenant/key")}}},
+				{Request: &regattapb.RequestOp_RequestTxn{RequestTxn: &regattapb.Txn{Failure: []*regattapb.RequestOp{
+					{Request: &regattapb.RequestOp_RequestDeleteRange{RequestDeleteRange: &regattapb.RequestOp_DeleteRange{Key: []byte("other")}}},
+				}}}},
+			}},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "client certificate",
+			ctx:      certContext("admin"),
+			method:   "/regatta.v1.KV/DeleteRange",
+			req:      &regattapb.DeleteRangeRequest{Table: table2Name, Key: wildcard, RangeEnd: wildcard},
+			wantUser: "admin",
+		},
+		{
+			name:     "lease requires the whole table",
+			ctx:      tokenContext("writer-token"),
+			method:   "/regatta.v1.Lease/LeaseGrant",
+			req:      &regattapb.LeaseGrantRequest{Table: table1Name},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "forwarded user",
+			ctx:      metadata.NewIncomingContext(certContext("follower"), metadata.Pairs(ForwardedUserHeader, "writer")),
+			method:   "/regatta.v1.KV/Put",
+			req:      &regattapb.PutRequest{Table: table1Name, Key: []byte("tenant/key")},
+			wantUser: "writer",
+		},
+		{
+			name:     "forwarded unknown user",
+			ctx:      metadata.NewIncomingContext(certContext("follower"), metadata.Pairs(ForwardedUserHeader, "unknown")),
+			method:   "/regatta.v1.KV/Put",
+			req:      &regattapb.PutRequest{Table: table1Name, Key: []byte("tenant/key")},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "forwarded by untrusted client",
+			ctx:      metadata.NewIncomingContext(certContext("reader"), metadata.Pairs(ForwardedUserHeader, "writer")),
+			method:   "/regatta.v1.KV/Put",
+			req:      &regattapb.PutRequest{Table: table1Name, Key: []byte("tenant/key")},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:   "other services are not authorized",
+			ctx:    context.Background(),
+			method: "/grpc.health.v1.Health/Check",
+			req:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			a := NewAuthorizer(testAuthService())
+			a.Forwarders = []string{"follower"}
+			_, err := a.UnaryServerInterceptor()(tt.ctx, tt.req, &grpc.UnaryServerInfo{FullMethod: tt.method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				user, _ := UserFromContext(ctx)
+				r.Equal(tt.wantUser, user)
+				return nil, nil
+			})
+			r.Equal(tt.wantCode, status.Code(err))
+		})
+	}
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	msgs []proto.Message
+}
+
+func (s *testServerStream) Context() context.Context { return s.ctx }
+
+func (s *testServerStream) RecvMsg(m interface{}) error {
+	if len(s.msgs) == 0 {
+		return io.EOF
+	}
+	proto.Merge(m.(proto.Message), s.msgs[0])
+	s.msgs = s.msgs[1:]
+	return nil
+}
+
+func TestAuthorizer_StreamServerInterceptor(t *testing.T) {
+	r := require.New(t)
+	a := NewAuthorizer(testAuthService())
+	info := &grpc.StreamServerInfo{FullMethod: "/regatta.v1.KV/PutLarge", IsClientStream: true}
+	recvAll := func(_ interface{}, ss grpc.ServerStream) error {
+		for {
+			if err := ss.RecvMsg(&regattapb.PutLargeRequest{}); err != nil {
+				if errors.Is(err, io.EOF) {
+					return nil
+				}
+				return err
+			}
+		}
+	}
+
+	t.Log("the first message is authorized")
+	err := a.StreamServerInterceptor()(nil, &testServerStream{ctx: tokenContext("writer-token"), msgs: []proto.Message{
+		&regattapb.PutLargeRequest{Table: table1Name, Key: []byte("tenant/key")}, &regattapb.PutLargeRequest{},
+	}}, info, recvAll)
+	r.NoError(err)
+
+	err = a.StreamServerInterceptor()(nil, &testServerStream{ctx: tokenContext("reader-token"), msgs: []proto.Message{
+		&regattapb.PutLargeRequest{Table: table1Name, Key: []byte("tenant/key")},
+	}}, info, recvAll)
+	r.Equal(codes.PermissionDenied, status.Code(err))
+
+	t.Log("every message is authorized")
+	info = &grpc.StreamServerInfo{FullMethod: "/regatta.v1.Lease/LeaseKeepAlive", IsClientStream: true, IsServerStream: true}
+	recvAll = func(_ interface{}, ss grpc.ServerStream) error {
+		for {
+			if err := ss.RecvMsg(&regattapb.LeaseKeepAliveRequest{}); err != nil {
+				if errors.Is(err, io.EOF) {
+					return nil
+				}
+				return err
+			}
+		}
+	}
+	err = a.StreamServerInterceptor()(nil, &testServerStream{ctx: certContext("table-writer"), msgs: []proto.Message{
+		&regattapb.LeaseKeepAliveRequest{Table: table1Name, ID: 1}, &regattapb.LeaseKeepAliveRequest{Table: table1Name, ID: 1},
+	}}, info, recvAll)
+	r.NoError(err)
+
+	err = a.StreamServerInterceptor()(nil, &testServerStream{ctx: certContext("table-writer"), msgs: []proto.Message{
+		&regattapb.LeaseKeepAliveRequest{Table: table1Name, ID: 1}, &regattapb.LeaseKeepAliveRequest{Table: table2Name, ID: 1},
+	}}, info, recvAll)
+	r.Equal(codes.PermissionDenied, status.Code(err))
+}
+
+func TestAuthorizer_cache(t *testing.T) {
+	r := require.New(t)
+	auth := testAuthService()
+	now := time.Now()
+	a := NewAuthorizer(auth)
+	a.now = func() time.Time { return now }
+	_, _, err := a.authenticate(tokenContext("reader-token"))
+	r.NoError(err)
+
+	t.Log("removed user is cached")
+	auth.users = nil
+	_, _, err = a.authenticate(tokenContext("reader-token"))
+	r.NoError(err)
+
+	t.Log("cache is reloaded")
+	now = now.Add(authCacheTTL)
+	_, _, err = a.authenticate(tokenContext("reader-token"))
+	r.Equal(codes.Unauthenticated, status.Code(err))
+
+	t.Log("last known users are kept if the service fails")
+	auth.users = testAuthService().users
+	now = now.Add(authCacheTTL)
+	_, _, err = a.authenticate(tokenContext("reader-token"))
+	r.NoError(err)
+	auth.error = errors.New("unavailable")
+	now = now.Add(authCacheTTL)
+	_, _, err = a.authenticate(tokenContext("reader-token"))
+	r.NoError(err)
+	_, _, err = NewAuthorizer(auth).authenticate(tokenContext("reader-token"))
+	r.Equal(codes.Unavailable, status.Code(err))
+}
+
+func TestVerifiedSubject(t *testing.T) {
+	r := require.New(t)
+	subject, ok := VerifiedSubject(certContext("client"))
+	r.True(ok)
+	r.Equal("client", subject.CommonName)
+
+	t.Log("unverified certificate")
+	_, ok = VerifiedSubject(peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: "client"}}},
+	}}}))
+	r.False(ok)
+	_, ok = VerifiedSubject(context.Background())
+	r.False(ok)
+}
+
+func Test_prefixCovers(t *testing.T) {
+	tests := []struct {
+		prefix   string
+		key      string
+		rangeEnd string
+		want     bool
+	}{
+		{prefix: "", key: "a", rangeEnd: "\x00", want: true},
+		{prefix: "a/", key: "a/b", want: true},
+		{prefix: "a/", key: "b", want: false},
+		{prefix: "a/", key: "a/", rangeEnd: "a0", want: true},
+		{prefix: "a/", key: "a/", rangeEnd: "a1", want: false},
+		{prefix: "a/", key: "a/", rangeEnd: "\x00", want: false},
+		{prefix: "a\xff", key: "a\xff", rangeEnd: "b", want: true},
+		{prefix: "\xff", key: "\xff", rangeEnd: "\x00", want: true},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, prefixCovers([]byte(tt.prefix), []byte(tt.key), []byte(tt.rangeEnd)), "prefix %q range [%q, %q)", tt.prefix, tt.key, tt.rangeEnd)
+	}
+}
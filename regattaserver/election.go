@@ -0,0 +1,215 @@
+// Copyright JAMF Software, LLC
+
+package regattaserver
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/jamf/regatta/regattapb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ElectionServer implements Election service from proto/regatta.proto.
+type ElectionServer struct {
+	regattapb.UnimplementedElectionServer
+	Storage KVService
+	// Tables and LogReader are used to watch the keys of the preceding candidates.
+	Tables    TableService
+	LogReader LogReaderService
+}
+
+// Campaign implements proto/regatta.proto Election.Campaign method.
+func (s *ElectionServer) Campaign(ctx context.Context, req *regattapb.CampaignRequest) (*regattapb.CampaignResponse, error) {
+	if len(req.GetTable()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "table must be set")
+	}
+
+	if len(req.GetName()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "name must be set")
+	}
+
+	if req.GetLease() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "lease must be set")
+	}
+
+	key := candidateKey(req.GetName(), req.GetLease())
+	kv, header, created, err := createCandidate(ctx, s.Storage, req.GetTable(), key, req.GetValue(), req.GetLease())
+	if err != nil {
+		return nil, err
+	}
+	leader := &regattapb.LeaderKey{Name: req.GetName(), Key: key, Rev: kv.CreateRevision, Lease: req.GetLease()}
+	// The candidate key already existed, the campaign continues with the new value.
+	if !bytes.Equal(kv.Value, req.GetValue()) {
+		if header, err = s.proclaim(ctx, req.GetTable(), leader, req.GetValue()); err != nil {
+			return nil, err
+		}
+	}
+	if err := waitPredecessors(ctx, s.Storage, s.Tables, s.LogReader, req.GetTable(), req.GetName(), kv.CreateRevision); err != nil {
+		if created {
+			deleteCandidate(ctx, s.Storage, req.GetTable(), kv)
+		}
+		return nil, err
+	}
+	return &regattapb.CampaignResponse{Header: header, Leader: leader}, nil
+}
+
+// Proclaim implements proto/regatta.proto Election.Proclaim method.
+func (s *ElectionServer) Proclaim(ctx context.Context, req *regattapb.ProclaimRequest) (*regattapb.ProclaimResponse, error) {
+	if len(req.GetTable()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "table must be set")
+	}
+
+	if len(req.GetLeader().GetKey()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "leader must be set")
+	}
+
+	header, err := s.proclaim(ctx, req.GetTable(), req.GetLeader(), req.GetValue())
+	if err != nil {
+		return nil, err
+	}
+	return &regattapb.ProclaimResponse{Header: header}, nil
+}
+
+func (s *ElectionServer) proclaim(ctx context.Context, table []byte, leader *regattapb.LeaderKey, value []byte) (*regattapb.ResponseHeader, error) {
+	r, err := s.Storage.Txn(ctx, &regattapb.TxnRequest{
+		Table:   table,
+		Compare: []*regattapb.Compare{leaderCompare(leader)},
+		Success: []*regattapb.RequestOp{
+			{Request: &regattapb.RequestOp_RequestPut{RequestPut: &regattapb.RequestOp_Put{Key: leader.GetKey(), Value: value, Lease: leader.GetLease()}}},
+		},
+	})
+	if err != nil {
+		return nil, storageError(err)
+	}
+	if !r.Succeeded {
+		return nil, status.Error(codes.FailedPrecondition, "not a leader")
+	}
+	return r.Header, nil
+}
+
+// Leader implements proto/regatta.proto Election.Leader method.
+func (s *ElectionServer) Leader(ctx context.Context, req *regattapb.LeaderRequest) (*regattapb.LeaderResponse, error) {
+	if len(req.GetTable()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "table must be set")
+	}
+
+	if len(req.GetName()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "name must be set")
+	}
+
+	r, err := s.leader(ctx, req, 0)
+	if err != nil {
+		return nil, err
+	}
+	if r.Kv == nil {
+		return nil, status.Error(codes.NotFound, "no leader")
+	}
+	return r, nil
+}
+
+// Observe implements proto/regatta.proto Election.Observe method.
+func (s *ElectionServer) Observe(req *regattapb.LeaderRequest, srv regattapb.Election_ObserveServer) error {
+	if len(req.GetTable()) == 0 {
+		return status.Error(codes.InvalidArgument, "table must be set")
+	}
+
+	if len(req.GetName()) == 0 {
+		return status.Error(codes.InvalidArgument, "name must be set")
+	}
+
+	t, err := s.Tables.GetTable(string(req.GetTable()))
+	if err != nil {
+		return storageError(err)
+	}
+	r, err := s.leader(srv.Context(), req, 0)
+	if err != nil {
+		return err
+	}
+	var last *regattapb.KeyValue
+	observe := func(r *regattapb.LeaderResponse) error {
+		if r.Kv == nil || (last != nil && bytes.Equal(r.Kv.Key, last.Key) && r.Kv.ModRevision == last.ModRevision) {
+			return nil
+		}
+		if err := srv.Send(r); err != nil {
+			return err
+		}
+		last = r.Kv
+		return nil
+	}
+	if err := observe(r); err != nil {
+		return err
+	}
+	// The leader is read again only once any of the candidate keys changes.
+	prefix := candidatePrefix(req.GetName())
+	w := &watcher{
+		table:     t,
+		logReader: s.LogReader,
+		rng:       watchRange{key: prefix, rangeEnd: prefixEnd(prefix)},
+		send: func(res *regattapb.WatchResponse) error {
+			r, err := s.leader(srv.Context(), req, int64(res.Header.GetRevision()))
+			if err != nil {
+				return err
+			}
+			return observe(r)
+		},
+	}
+	return w.run(srv.Context(), r.Header.GetRevision()+1)
+}
+
+// leader reads the candidate key with the lowest create revision at the revision (the latest one if 0),
+// the key is nil if there is no leader.
+func (s *ElectionServer) leader(ctx context.Context, req *regattapb.LeaderRequest, revision int64) (*regattapb.LeaderResponse, error) {
+	prefix := candidatePrefix(req.GetName())
+	r, err := s.Storage.Range(ctx, &regattapb.RangeRequest{
+		Table:        req.GetTable(),
+		Key:          prefix,
+		RangeEnd:     prefixEnd(prefix),
+		Revision:     revision,
+		Linearizable: revision == 0,
+	})
+	if err != nil {
+		return nil, storageError(err)
+	}
+	var leader *regattapb.KeyValue
+	for _, kv := range r.Kvs {
+		if leader == nil || kv.CreateRevision < leader.CreateRevision {
+			leader = kv
+		}
+	}
+	return &regattapb.LeaderResponse{Header: r.Header, Kv: leader}, nil
+}
+
+// Resign implements proto/regatta.proto Election.Resign method.
+func (s *ElectionServer) Resign(ctx context.Context, req *regattapb.ResignRequest) (*regattapb.ResignResponse, error) {
+	if len(req.GetTable()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "table must be set")
+	}
+
+	if len(req.GetLeader().GetKey()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "leader must be set")
+	}
+
+	r, err := s.Storage.Txn(ctx, &regattapb.TxnRequest{
+		Table:   req.GetTable(),
+		Compare: []*regattapb.Compare{leaderCompare(req.GetLeader())},
+		Success: []*regattapb.RequestOp{
+			{Request: &regattapb.RequestOp_RequestDeleteRange{RequestDeleteRange: &regattapb.RequestOp_DeleteRange{Key: req.GetLeader().GetKey()}}},
+		},
+	})
+	if err != nil {
+		return nil, storageError(err)
+	}
+	return &regattapb.ResignResponse{Header: r.Header}, nil
+}
+
+// leaderCompare checks that the leader key was not recreated since the leader was elected.
+func leaderCompare(leader *regattapb.LeaderKey) *regattapb.Compare {
+	return &regattapb.Compare{
+		Key:         leader.GetKey(),
+		Target:      regattapb.Compare_CREATE,
+		Result:      regattapb.Compare_EQUAL,
+		TargetUnion: &regattapb.Compare_CreateRevision{CreateRevision: leader.GetRev()},
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright JAMF Software, LLC
+
+package regattaserver
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jamf/regatta/regattapb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockObserveServer struct {
+	grpc.ServerStream
+	ctx       context.Context
+	mu        sync.Mutex
+	responses []*regattapb.LeaderResponse
+}
+
+func (m *mockObserveServer) Context() context.Context {
+	return m.ctx
+}
+
+func (m *mockObserveServer) Send(r *regattapb.LeaderResponse) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.responses = append(m.responses, r)
+	return nil
+}
+
+func TestElectionServer_Campaign(t *testing.T) {
+	r := require.New(t)
+	storage := newMemoryStorage()
+	es := &ElectionServer{Storage: storage, Tables: memoryTables{storage: storage}, LogReader: storage}
+
+	_, err := es.Campaign(context.Background(), &regattapb.CampaignRequest{Table: table1Name, Lease: 1})
+	r.EqualError(err, status.Error(codes.InvalidArgument, "name must be set").Error())
+	_, err = es.Leader(context.Background(), &regattapb.LeaderRequest{Table: table1Name, Name: []byte("election")})
+	r.EqualError(err, status.Error(codes.NotFound, "no leader").Error())
+
+	first, err := es.Campaign(context.Background(), &regattapb.CampaignRequest{Table: table1Name, Name: []byte("election"), Lease: 1, Value: []byte("first")})
+	r.NoError(err)
+	r.Equal(&regattapb.LeaderKey{Name: []byte("election"), Key: []byte("election/1"), Rev: 1, Lease: 1}, first.Leader)
+
+	leader, err := es.Leader(context.Background(), &regattapb.LeaderRequest{Table: table1Name, Name: []byte("election")})
+	r.NoError(err)
+	r.Equal([]byte("first"), leader.Kv.Value)
+
+	_, err = es.Proclaim(context.Background(), &regattapb.ProclaimRequest{Table: table1Name, Leader: first.Leader, Value: []byte("proclaimed")})
+	r.NoError(err)
+	leader, err = es.Leader(context.Background(), &regattapb.LeaderRequest{Table: table1Name, Name: []byte("election")})
+	r.NoError(err)
+	r.Equal([]byte("proclaimed"), leader.Kv.Value)
+
+	elected := make(chan *regattapb.CampaignResponse)
+	go func() {
+		res, err := es.Campaign(context.Background(), &regattapb.CampaignRequest{Table: table1Name, Name: []byte("election"), Lease: 2, Value: []byte("second")})
+		assert.NoError(t, err)
+		elected <- res
+	}()
+	select {
+	case <-elected:
+		r.Fail("elected while the leader did not resign")
+	case <-time.After(3 * watchPollInterval):
+	}
+
+	_, err = es.Resign(context.Background(), &regattapb.ResignRequest{Table: table1Name, Leader: first.Leader})
+	r.NoError(err)
+	select {
+	case res := <-elected:
+		r.Equal([]byte("election/2"), res.Leader.Key)
+	case <-time.After(5 * time.Second):
+		r.Fail("not elected")
+	}
+
+	_, err = es.Proclaim(context.Background(), &regattapb.ProclaimRequest{Table: table1Name, Leader: first.Leader, Value: []byte("resigned")})
+	r.EqualError(err, status.Error(codes.FailedPrecondition, "not a leader").Error())
+}
+
+func TestElectionServer_Observe(t *testing.T) {
+	r := require.New(t)
+	storage := newMemoryStorage()
+	es := &ElectionServer{Storage: storage, Tables: memoryTables{storage: storage}, LogReader: storage}
+
+	err := es.Observe(&regattapb.LeaderRequest{Name: []byte("election")}, &mockObserveServer{ctx: context.Background()})
+	r.EqualError(err, status.Error(codes.InvalidArgument, "table must be set").Error())
+
+	campaign, err := es.Campaign(context.Background(), &regattapb.CampaignRequest{Table: table1Name, Name: []byte("election"), Lease: 1, Value: []byte("first")})
+	r.NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	srv := &mockObserveServer{ctx: ctx}
+	done := make(chan error)
+	go func() {
+		done <- es.Observe(&regattapb.LeaderRequest{Table: table1Name, Name: []byte("election")}, srv)
+	}()
+	time.Sleep(2 * watchPollInterval)
+	_, err = es.Proclaim(context.Background(), &regattapb.ProclaimRequest{Table: table1Name, Leader: campaign.Leader, Value: []byte("second")})
+	r.NoError(err)
+	time.Sleep(3 * watchPollInterval)
+	cancel()
+	r.NoError(<-done)
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	r.Len(srv.responses, 2)
+	r.Equal([]byte("first"), srv.responses[0].Kv.Value)
+	r.Equal([]byte("second"), srv.responses[1].Kv.Value)
+}
@@ -0,0 +1,315 @@
+// Copyright JAMF Software, LLC
+
+package regattaserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// ndjsonContentType content type of the responses of the streaming methods, every message is a JSON object on its own line.
+const ndjsonContentType = "application/x-ndjson"
+
+var (
+	gatewayMarshal   = protojson.MarshalOptions{}
+	gatewayUnmarshal = protojson.UnmarshalOptions{}
+)
+
+// Gateway serves the registered gRPC services over HTTP with the messages encoded as JSON (the bytes fields are base64 encoded).
+// Every method is served by POST requests at the /<service>/<method> path, e.g. /regatta.v1.KV/Range. The responses of the streaming
+// methods are streamed as newline delimited JSON, the requests of the client streaming methods are read as a sequence of JSON objects.
+// The HTTP headers of the request are passed to the services as the gRPC metadata.
+type Gateway struct {
+	mux    *http.ServeMux
+	unary  grpc.UnaryServerInterceptor
+	stream grpc.StreamServerInterceptor
+}
+
+// NewGateway returns an empty gateway, the services are registered by the generated Register functions.
+func NewGateway() *Gateway {
+	return &Gateway{mux: http.NewServeMux()}
+}
+
+// WithInterceptors returns the view of the gateway applying the interceptors to the calls of the services registered through it.
+// The interceptors (either could be nil) should match the interceptors of the gRPC server of the services.
+func (g *Gateway) WithInterceptors(unary grpc.UnaryServerInterceptor, stream grpc.StreamServerInterceptor) *Gateway {
+	return &Gateway{mux: g.mux, unary: unary, stream: stream}
+}
+
+// ServeHTTP implements http.Handler interface.
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mux.ServeHTTP(w, r)
+}
+
+// RegisterService implements grpc.ServiceRegistrar interface.
+func (g *Gateway) RegisterService(desc *grpc.ServiceDesc, impl interface{}) {
+	for _, m := range desc.Methods {
+		g.mux.Handle("/"+desc.ServiceName+"/"+m.MethodName, &unaryGatewayHandler{
+			impl:    impl,
+			method:  m,
+			unary:   g.unary,
+			maxSize: DefaultMaxGRPCSize,
+		})
+	}
+	for _, s := range desc.Streams {
+		g.mux.Handle("/"+desc.ServiceName+"/"+s.StreamName, &streamGatewayHandler{
+			impl:   impl,
+			desc:   s,
+			method: "/" + desc.ServiceName + "/" + s.StreamName,
+			stream: g.stream,
+		})
+	}
+}
+
+type unaryGatewayHandler struct {
+	impl    interface{}
+	method  grpc.MethodDesc
+	unary   grpc.UnaryServerInterceptor
+	maxSize int64
+}
+
+func (h *unaryGatewayHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeGatewayError(w, status.Error(codes.Unimplemented, "method must be POST"))
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.maxSize))
+	if err != nil {
+		writeGatewayError(w, status.Errorf(codes.InvalidArgument, "cannot read request body: %v", err))
+		return
+	}
+	dec := func(m interface{}) error {
+		return decodeGatewayMessage(body, m)
+	}
+	res, err := h.method.Handler(h.impl, gatewayContext(r), dec, h.unary)
+	if err != nil {
+		writeGatewayError(w, err)
+		return
+	}
+	bts, err := gatewayMarshal.Marshal(res.(proto.Message))
+	if err != nil {
+		writeGatewayError(w, status.Error(codes.Internal, err.Error()))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(bts)
+}
+
+type streamGatewayHandler struct {
+	impl   interface{}
+	desc   grpc.StreamDesc
+	method string
+	stream grpc.StreamServerInterceptor
+}
+
+func (h *streamGatewayHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeGatewayError(w, status.Error(codes.Unimplemented, "method must be POST"))
+		return
+	}
+	ss := &gatewayStream{ctx: gatewayContext(r), w: w, dec: json.NewDecoder(r.Body)}
+	var err error
+	if h.stream != nil {
+		err = h.stream(h.impl, ss, &grpc.StreamServerInfo{
+			FullMethod:     h.method,
+			IsClientStream: h.desc.ClientStreams,
+			IsServerStream: h.desc.ServerStreams,
+		}, h.desc.Handler)
+	} else {
+		err = h.desc.Handler(h.impl, ss)
+	}
+	if err == nil {
+		return
+	}
+	if !ss.sent {
+		writeGatewayError(w, err)
+		return
+	}
+	// The status of the stream is already sent, the error is sent as the last line of the stream.
+	bts, merr := gatewayMarshal.Marshal(status.Convert(err).Proto())
+	if merr != nil {
+		return
+	}
+	_ = ss.writeLine(append(append([]byte(`{"error":`), bts...), '}'))
+}
+
+// gatewayStream implements grpc.ServerStream interface on top of the HTTP request and response.
+type gatewayStream struct {
+	ctx      context.Context
+	w        http.ResponseWriter
+	dec      *json.Decoder
+	received int
+	sent     bool
+}
+
+func (s *gatewayStream) SetHeader(metadata.MD) error  { return nil }
+func (s *gatewayStream) SendHeader(metadata.MD) error { return nil }
+func (s *gatewayStream) SetTrailer(metadata.MD)       {}
+func (s *gatewayStream) Context() context.Context     { return s.ctx }
+
+func (s *gatewayStream) SendMsg(m interface{}) error {
+	bts, err := gatewayMarshal.Marshal(m.(proto.Message))
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if !s.sent {
+		s.w.Header().Set("Content-Type", ndjsonContentType)
+		s.w.WriteHeader(http.StatusOK)
+		s.sent = true
+	}
+	return s.writeLine(bts)
+}
+
+func (s *gatewayStream) writeLine(bts []byte) error {
+	if _, err := s.w.Write(append(bts, '\n')); err != nil {
+		return err
+	}
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+func (s *gatewayStream) RecvMsg(m interface{}) error {
+	var raw json.RawMessage
+	if err := s.dec.Decode(&raw); err != nil {
+		if errors.Is(err, io.EOF) {
+			// The request body of the server streaming methods is optional, the empty message is received instead.
+			if s.received == 0 {
+				s.received++
+				return decodeGatewayMessage(nil, m)
+			}
+			return io.EOF
+		}
+		return status.Errorf(codes.InvalidArgument, "cannot read request body: %v", err)
+	}
+	s.received++
+	return decodeGatewayMessage(raw, m)
+}
+
+// decodeGatewayMessage decodes the JSON encoded message, the empty data decode into the empty message.
+func decodeGatewayMessage(data []byte, m interface{}) error {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	if err := gatewayUnmarshal.Unmarshal(data, m.(proto.Message)); err != nil {
+		return status.Errorf(codes.InvalidArgument, "cannot decode request: %v", err)
+	}
+	return nil
+}
+
+// gatewayContext returns the context of the request carrying its headers as the incoming gRPC metadata and its peer.
+func gatewayContext(r *http.Request) context.Context {
+	md := metadata.MD{}
+	for k, v := range r.Header {
+		md.Append(strings.ToLower(k), v...)
+	}
+	p := &peer.Peer{Addr: gatewayAddr(r.RemoteAddr)}
+	if r.TLS != nil {
+		p.AuthInfo = credentials.TLSInfo{State: *r.TLS}
+	}
+	return peer.NewContext(metadata.NewIncomingContext(r.Context(), md), p)
+}
+
+// gatewayAddr the address of the HTTP client.
+type gatewayAddr string
+
+func (a gatewayAddr) Network() string { return "tcp" }
+func (a gatewayAddr) String() string  { return string(a) }
+
+// writeGatewayError writes the gRPC status of the error as the JSON encoded google.rpc.Status with the matching HTTP status code.
+func writeGatewayError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	bts, merr := gatewayMarshal.Marshal(st.Proto())
+	if merr != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatusFromCode(st.Code()))
+	_, _ = w.Write(bts)
+}
+
+// httpStatusFromCode maps the gRPC status code to the HTTP status code.
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		// Client Closed Request as there is no standard HTTP status code.
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// GatewayServer serves the Gateway on its own address over TLS.
+type GatewayServer struct {
+	addr       string
+	httpServer *http.Server
+	log        *zap.SugaredLogger
+}
+
+// NewGatewayServer returns initialized gateway server, the requests are read without a deadline as the client streams could be long.
+func NewGatewayServer(addr string, gateway *Gateway, tlsConfig *tls.Config) *GatewayServer {
+	l := zap.S().Named("gateway")
+	return &GatewayServer{
+		addr: addr,
+		httpServer: &http.Server{
+			Addr:              addr,
+			Handler:           gateway,
+			TLSConfig:         tlsConfig,
+			ErrorLog:          zap.NewStdLog(l.Desugar()),
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+		log: l,
+	}
+}
+
+// ListenAndServe starts underlying HTTP server.
+func (s *GatewayServer) ListenAndServe() error {
+	s.log.Infof("listen gateway on: %s", s.addr)
+	return s.httpServer.ListenAndServeTLS("", "")
+}
+
+// Shutdown stops underlying HTTP server.
+func (s *GatewayServer) Shutdown() {
+	s.log.Infof("stopping gateway on: %s", s.addr)
+	_ = s.httpServer.Shutdown(context.TODO())
+	s.log.Infof("stopped gateway on: %s", s.addr)
+}
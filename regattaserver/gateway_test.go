@@ -0,0 +1,187 @@
+// Copyright JAMF Software, LLC
+
+package regattaserver
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	grpc_auth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
+	"github.com/jamf/regatta/regattapb"
+	serrors "github.com/jamf/regatta/storage/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func newTestGateway(storage *MockStorage) *httptest.Server {
+	gw := NewGateway()
+	regattapb.RegisterKVServer(gw, &KVServer{Storage: storage})
+	return httptest.NewServer(gw)
+}
+
+func gatewayPost(t *testing.T, url, body string, headers map[string]string) *http.Response {
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	require.NoError(t, err)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = res.Body.Close()
+	})
+	return res
+}
+
+func TestGateway_Unary(t *testing.T) {
+	storage := &MockStorage{rangeResponse: regattapb.RangeResponse{
+		Header: &regattapb.ResponseHeader{Revision: 10},
+		Kvs:    []*regattapb.KeyValue{{Key: key1Name, Value: table1Value1}},
+		Count:  1,
+	}}
+	srv := newTestGateway(storage)
+	defer srv.Close()
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		wantCode int
+		want     string
+	}{
+		{
+			name:     "range",
+			method:   http.MethodPost,
+			path:     "/regatta.v1.KV/Range",
+			body:     `{"table": "dGFibGVfMQ==", "key": "a2V5XzE="}`,
+			wantCode: http.StatusOK,
+			want:     `{"header":{"revision":"10"},"kvs":[{"key":"a2V5XzE=","value":"dGFibGVfMS92YWx1ZV8x"}],"count":"1"}`,
+		},
+		{
+			name:     "invalid argument",
+			method:   http.MethodPost,
+			path:     "/regatta.v1.KV/Range",
+			body:     `{"key": "a2V5XzE="}`,
+			wantCode: http.StatusBadRequest,
+			want:     `{"code":3,"message":"table must be set"}`,
+		},
+		{
+			name:     "malformed request",
+			method:   http.MethodPost,
+			path:     "/regatta.v1.KV/Range",
+			body:     `{"table": 1}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "unknown method",
+			method:   http.MethodPost,
+			path:     "/regatta.v1.KV/Unknown",
+			body:     `{}`,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "method not allowed",
+			method:   http.MethodGet,
+			path:     "/regatta.v1.KV/Range",
+			wantCode: http.StatusNotImplemented,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			req, err := http.NewRequest(tt.method, srv.URL+tt.path, strings.NewReader(tt.body))
+			r.NoError(err)
+			res, err := http.DefaultClient.Do(req)
+			r.NoError(err)
+			defer res.Body.Close()
+			r.Equal(tt.wantCode, res.StatusCode)
+			if tt.want != "" {
+				body, err := io.ReadAll(res.Body)
+				r.NoError(err)
+				r.JSONEq(tt.want, string(body))
+			}
+		})
+	}
+}
+
+func TestGateway_Stream(t *testing.T) {
+	r := require.New(t)
+	storage := &MockStorage{cursorResponses: []*regattapb.RangeResponse{
+		{Kvs: []*regattapb.KeyValue{{Key: key1Name}}, More: true},
+		{Kvs: []*regattapb.KeyValue{{Key: key2Name}}},
+	}}
+	srv := newTestGateway(storage)
+	defer srv.Close()
+
+	res := gatewayPost(t, srv.URL+"/regatta.v1.KV/Cursor", `{"table": "dGFibGVfMQ==", "key": "a2V5XzE="}`, nil)
+	r.Equal(http.StatusOK, res.StatusCode)
+	r.Equal(ndjsonContentType, res.Header.Get("Content-Type"))
+	var keys []string
+	scanner := bufio.NewScanner(res.Body)
+	for scanner.Scan() {
+		msg := &regattapb.RangeResponse{}
+		r.NoError(protojson.Unmarshal(scanner.Bytes(), msg))
+		keys = append(keys, string(msg.Kvs[0].Key))
+	}
+	r.Equal([]string{string(key1Name), string(key2Name)}, keys)
+
+	t.Log("error after the stream started is sent as the last line")
+	storage.rangeError = serrors.ErrCompacted
+	res = gatewayPost(t, srv.URL+"/regatta.v1.KV/Cursor", `{"table": "dGFibGVfMQ==", "key": "a2V5XzE="}`, nil)
+	r.Equal(http.StatusOK, res.StatusCode)
+	body, err := io.ReadAll(res.Body)
+	r.NoError(err)
+	lines := strings.Split(strings.TrimSpace(string(body)), "\n")
+	r.Len(lines, 3)
+	r.JSONEq(`{"error":{"code":11,"message":"required revision has been compacted"}}`, lines[2])
+
+	t.Log("error before the stream started is sent with the status code")
+	storage.cursorResponses = nil
+	res = gatewayPost(t, srv.URL+"/regatta.v1.KV/Cursor", `{"table": "dGFibGVfMQ==", "key": "a2V5XzE="}`, nil)
+	r.Equal(http.StatusBadRequest, res.StatusCode)
+}
+
+func TestGateway_ClientStream(t *testing.T) {
+	r := require.New(t)
+	storage := &MockStorage{putLargeResponse: regattapb.PutLargeResponse{Size: 6}}
+	srv := newTestGateway(storage)
+	defer srv.Close()
+
+	res := gatewayPost(t, srv.URL+"/regatta.v1.KV/PutLarge", `{"table": "dGFibGVfMQ==", "key": "a2V5XzE=", "value": "Zm9v"}
+{"value": "YmFy"}`, nil)
+	r.Equal(http.StatusOK, res.StatusCode)
+	r.Equal([]byte("foobar"), storage.putLargeValue)
+}
+
+func TestGateway_WithInterceptors(t *testing.T) {
+	r := require.New(t)
+	auth := func(ctx context.Context) (context.Context, error) {
+		token, err := grpc_auth.AuthFromMD(ctx, "bearer")
+		if err != nil {
+			return nil, err
+		}
+		if token != "secret" {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+		return ctx, nil
+	}
+	gw := NewGateway()
+	regattapb.RegisterKVServer(gw.WithInterceptors(grpc_auth.UnaryServerInterceptor(auth), grpc_auth.StreamServerInterceptor(auth)), &KVServer{Storage: &MockStorage{}})
+	srv := httptest.NewServer(gw)
+	defer srv.Close()
+
+	res := gatewayPost(t, srv.URL+"/regatta.v1.KV/Put", `{"table": "dGFibGVfMQ==", "key": "a2V5XzE="}`, nil)
+	r.Equal(http.StatusUnauthorized, res.StatusCode)
+	res = gatewayPost(t, srv.URL+"/regatta.v1.KV/Put", `{"table": "dGFibGVfMQ==", "key": "a2V5XzE="}`, map[string]string{"Authorization": "Bearer secret"})
+	r.Equal(http.StatusOK, res.StatusCode)
+	res = gatewayPost(t, srv.URL+"/regatta.v1.KV/Cursor", `{"table": "dGFibGVfMQ==", "key": "a2V5XzE="}`, map[string]string{"Authorization": "Bearer wrong"})
+	r.Equal(http.StatusUnauthorized, res.StatusCode)
+}
@@ -4,58 +4,314 @@ package regattaserver
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
+	"io"
+	"regexp"
+	"time"
 
 	"github.com/jamf/regatta/regattapb"
 	serrors "github.com/jamf/regatta/storage/errors"
+	"github.com/jamf/regatta/storage/table/fsm"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+const (
+	// replicationPollInterval how often is the local replica checked while waiting for the forwarded write to be replicated.
+	replicationPollInterval = 10 * time.Millisecond
+	// maxTxnDepth the maximum nesting depth of the transactions.
+	maxTxnDepth = 8
+	// maxRequestIDLen the maximum length of the request ID used to deduplicate the retried writes.
+	maxRequestIDLen = 128
+)
+
 // KVServer implements KV service from proto/regatta.proto.
 type KVServer struct {
 	regattapb.UnimplementedKVServer
 	Storage KVService
+	// Tables and LogReader are used to serve the Watch method, Watch is not available if any of them is not set.
+	Tables    TableService
+	LogReader LogReaderService
 }
 
 // Range implements proto/regatta.proto KV.Range method.
-// Currently, only subset of functionality is implemented.
-// The versioning functionality is not available.
 func (s *KVServer) Range(ctx context.Context, req *regattapb.RangeRequest) (*regattapb.RangeResponse, error) {
+	if err := validateRangeRequest(req); err != nil {
+		return nil, err
+	}
+
+	val, err := s.Storage.Range(ctx, req)
+	if err != nil {
+		if errors.Is(err, serrors.ErrTableNotFound) {
+			return nil, status.Error(codes.NotFound, "table not found")
+		}
+		if errors.Is(err, serrors.ErrCompacted) || errors.Is(err, serrors.ErrFutureRevision) {
+			return nil, status.Error(codes.OutOfRange, err.Error())
+		}
+		if errors.Is(err, serrors.ErrReplicaStale) {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+		if errors.Is(err, serrors.ErrRevisionNotApplied) {
+			return nil, status.Error(codes.DeadlineExceeded, err.Error())
+		}
+		if errors.Is(err, serrors.ErrValueTooLarge) {
+			return nil, errValueTooLarge
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return val, nil
+}
+
+// errValueTooLarge returned when the large value could not be returned by the Range (or Cursor) method.
+var errValueTooLarge = status.Error(codes.FailedPrecondition, "value too large to be returned in a single response, use GetLarge method")
+
+// Cursor implements proto/regatta.proto KV.Cursor method.
+func (s *KVServer) Cursor(req *regattapb.RangeRequest, srv regattapb.KV_CursorServer) error {
+	if err := validateRangeRequest(req); err != nil {
+		return err
+	}
+
+	if req.GetBatchSize() < 0 {
+		return status.Error(codes.InvalidArgument, "batch_size must be a positive number")
+	}
+
+	if err := s.Storage.Cursor(srv.Context(), req, srv.Send); err != nil {
+		if errors.Is(err, serrors.ErrTableNotFound) {
+			return status.Error(codes.NotFound, "table not found")
+		}
+		if errors.Is(err, serrors.ErrCompacted) || errors.Is(err, serrors.ErrFutureRevision) {
+			return status.Error(codes.OutOfRange, err.Error())
+		}
+		if errors.Is(err, serrors.ErrReplicaStale) {
+			return status.Error(codes.Unavailable, err.Error())
+		}
+		if errors.Is(err, serrors.ErrRevisionNotApplied) {
+			return status.Error(codes.DeadlineExceeded, err.Error())
+		}
+		if errors.Is(err, serrors.ErrValueTooLarge) {
+			return errValueTooLarge
+		}
+		if errors.Is(err, fsm.ErrCursorStopped) {
+			return status.FromContextError(srv.Context().Err()).Err()
+		}
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return status.Error(codes.Internal, err.Error())
+	}
+	return nil
+}
+
+func validateRangeRequest(req *regattapb.RangeRequest) error {
 	if req.GetLimit() < 0 {
-		return nil, status.Errorf(codes.InvalidArgument, "limit must be a positive number")
+		return status.Errorf(codes.InvalidArgument, "limit must be a positive number")
 	} else if req.GetKeysOnly() && req.GetCountOnly() {
-		return nil, status.Error(codes.InvalidArgument, "keys_only and count_only must not be set at the same time")
-	} else if req.GetMinModRevision() > 0 {
-		return nil, status.Error(codes.Unimplemented, "min_mod_revision not implemented")
-	} else if req.GetMaxModRevision() > 0 {
-		return nil, status.Error(codes.Unimplemented, "max_mod_revision not implemented")
-	} else if req.GetMinCreateRevision() > 0 {
-		return nil, status.Error(codes.Unimplemented, "min_create_revision not implemented")
-	} else if req.GetMaxCreateRevision() > 0 {
-		return nil, status.Error(codes.Unimplemented, "max_create_revision not implemented")
+		return status.Error(codes.InvalidArgument, "keys_only and count_only must not be set at the same time")
+	} else if req.GetMinModRevision() < 0 || req.GetMaxModRevision() < 0 || req.GetMinCreateRevision() < 0 || req.GetMaxCreateRevision() < 0 {
+		return status.Error(codes.InvalidArgument, "revision bounds must not be negative")
+	} else if _, ok := regattapb.SortOrder_name[int32(req.GetSortOrder())]; !ok {
+		return status.Error(codes.InvalidArgument, "sort_order is not valid")
+	} else if err := validateRangeFilter(req.GetFilter()); err != nil {
+		return err
 	}
 
 	if len(req.GetTable()) == 0 {
-		return nil, status.Error(codes.InvalidArgument, "table must be set")
+		return status.Error(codes.InvalidArgument, "table must be set")
 	}
 
 	if len(req.GetKey()) == 0 {
-		return nil, status.Error(codes.InvalidArgument, "key must be set")
+		return status.Error(codes.InvalidArgument, "key must be set")
 	}
+	return nil
+}
 
-	val, err := s.Storage.Range(ctx, req)
+func validateRangeFilter(filter *regattapb.RangeFilter) error {
+	if filter.GetMinValueLength() < 0 || filter.GetMaxValueLength() < 0 {
+		return status.Error(codes.InvalidArgument, "value length bounds must not be negative")
+	}
+	if filter.GetKeyRegex() != "" {
+		if _, err := regexp.Compile(filter.GetKeyRegex()); err != nil {
+			return status.Errorf(codes.InvalidArgument, "key_regex is not valid: %v", err)
+		}
+	}
+	return nil
+}
+
+func validateRequestID(id []byte) error {
+	if len(id) > maxRequestIDLen {
+		return status.Errorf(codes.InvalidArgument, "request_id must not be longer than %d bytes", maxRequestIDLen)
+	}
+	return nil
+}
+
+// userRequestID scopes the request ID to the authenticated user, the users could not read the results of the requests
+// of each other by reusing their request IDs.
+func userRequestID(ctx context.Context, id []byte) []byte {
+	if len(id) == 0 {
+		return nil
+	}
+	user, _ := UserFromContext(ctx)
+	scoped := make([]byte, 0, binary.MaxVarintLen64+len(user)+len(id))
+	scoped = binary.AppendUvarint(scoped, uint64(len(user)))
+	scoped = append(scoped, user...)
+	return append(scoped, id...)
+}
+
+// validateTxnOps validates the operations of the transaction at the nesting depth, the nested transactions are validated recursively.
+func validateTxnOps(ops []*regattapb.RequestOp, depth int) error {
+	for _, op := range ops {
+		switch o := op.Request.(type) {
+		case *regattapb.RequestOp_RequestRange:
+			if err := validateRangeFilter(o.RequestRange.GetFilter()); err != nil {
+				return err
+			}
+		case *regattapb.RequestOp_RequestTxn:
+			if depth == maxTxnDepth {
+				return status.Errorf(codes.InvalidArgument, "transactions must not be nested deeper than %d levels", maxTxnDepth)
+			}
+			if err := validateTxnOps(o.RequestTxn.GetSuccess(), depth+1); err != nil {
+				return err
+			}
+			if err := validateTxnOps(o.RequestTxn.GetFailure(), depth+1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Put implements proto/regatta.proto KV.Put method.
+func (s *KVServer) Put(ctx context.Context, req *regattapb.PutRequest) (*regattapb.PutResponse, error) {
+	if len(req.GetTable()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "table must be set")
+	}
+
+	if len(req.GetKey()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "key must be set")
+	}
+
+	if req.GetLease() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "lease must not be negative")
+	}
+
+	if err := validateRequestID(req.GetRequestId()); err != nil {
+		return nil, err
+	}
+	req.RequestId = userRequestID(ctx, req.GetRequestId())
+
+	r, err := s.Storage.Put(ctx, req)
 	if err != nil {
 		if errors.Is(err, serrors.ErrTableNotFound) {
 			return nil, status.Error(codes.NotFound, "table not found")
 		}
+		if errors.Is(err, serrors.ErrRequestIDReused) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, serrors.ErrLeaseNotFound) {
+			return nil, status.Error(codes.NotFound, "lease not found")
+		}
+		if errors.Is(err, serrors.ErrValueLengthExceeded) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	return val, nil
+	return r, nil
 }
 
-// Put implements proto/regatta.proto KV.Put method.
-func (s *KVServer) Put(ctx context.Context, req *regattapb.PutRequest) (*regattapb.PutResponse, error) {
+// PutLarge implements proto/regatta.proto KV.PutLarge method.
+func (s *KVServer) PutLarge(srv regattapb.KV_PutLargeServer) error {
+	req, err := srv.Recv()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return status.Errorf(codes.InvalidArgument, "table must be set")
+		}
+		return err
+	}
+
+	if len(req.GetTable()) == 0 {
+		return status.Errorf(codes.InvalidArgument, "table must be set")
+	}
+
+	if len(req.GetKey()) == 0 {
+		return status.Errorf(codes.InvalidArgument, "key must be set")
+	}
+
+	if req.GetLease() < 0 {
+		return status.Errorf(codes.InvalidArgument, "lease must not be negative")
+	}
+
+	r, err := s.Storage.PutLarge(srv.Context(), req, &putLargeReader{srv: srv, buf: req.GetValue()})
+	if err != nil {
+		if errors.Is(err, serrors.ErrTableNotFound) {
+			return status.Error(codes.NotFound, "table not found")
+		}
+		if errors.Is(err, serrors.ErrLeaseNotFound) {
+			return status.Error(codes.NotFound, "lease not found")
+		}
+		if errors.Is(err, serrors.ErrValueLengthExceeded) {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return status.Error(codes.Internal, err.Error())
+	}
+	return srv.SendAndClose(r)
+}
+
+// putLargeReader reads the value of the large value from the PutLarge stream.
+type putLargeReader struct {
+	srv regattapb.KV_PutLargeServer
+	buf []byte
+}
+
+func (r *putLargeReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		req, err := r.srv.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = req.GetValue()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// GetLarge implements proto/regatta.proto KV.GetLarge method.
+func (s *KVServer) GetLarge(req *regattapb.GetLargeRequest, srv regattapb.KV_GetLargeServer) error {
+	if len(req.GetTable()) == 0 {
+		return status.Errorf(codes.InvalidArgument, "table must be set")
+	}
+
+	if len(req.GetKey()) == 0 {
+		return status.Errorf(codes.InvalidArgument, "key must be set")
+	}
+
+	if err := s.Storage.GetLarge(srv.Context(), req, srv.Send); err != nil {
+		if errors.Is(err, serrors.ErrTableNotFound) {
+			return status.Error(codes.NotFound, "table not found")
+		}
+		if errors.Is(err, serrors.ErrKeyNotFound) {
+			return status.Error(codes.NotFound, "key not found")
+		}
+		if errors.Is(err, serrors.ErrCompacted) || errors.Is(err, serrors.ErrFutureRevision) {
+			return status.Error(codes.OutOfRange, err.Error())
+		}
+		if errors.Is(err, fsm.ErrCursorStopped) {
+			return status.FromContextError(srv.Context().Err()).Err()
+		}
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return status.Error(codes.Internal, err.Error())
+	}
+	return nil
+}
+
+// Increment implements proto/regatta.proto KV.Increment method.
+func (s *KVServer) Increment(ctx context.Context, req *regattapb.IncrementRequest) (*regattapb.IncrementResponse, error) {
 	if len(req.GetTable()) == 0 {
 		return nil, status.Errorf(codes.InvalidArgument, "table must be set")
 	}
@@ -64,16 +320,38 @@ func (s *KVServer) Put(ctx context.Context, req *regattapb.PutRequest) (*regatta
 		return nil, status.Errorf(codes.InvalidArgument, "key must be set")
 	}
 
-	r, err := s.Storage.Put(ctx, req)
+	if err := validateRequestID(req.GetRequestId()); err != nil {
+		return nil, err
+	}
+	req.RequestId = userRequestID(ctx, req.GetRequestId())
+
+	r, err := s.Storage.Increment(ctx, req)
 	if err != nil {
 		if errors.Is(err, serrors.ErrTableNotFound) {
 			return nil, status.Error(codes.NotFound, "table not found")
 		}
+		if errors.Is(err, serrors.ErrRequestIDReused) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if code, ok := incrementErrorCode(err); ok {
+			return nil, status.Error(code, err.Error())
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	return r, nil
 }
 
+// incrementErrorCode returns the status code of an error of a failed increment.
+func incrementErrorCode(err error) (codes.Code, bool) {
+	switch {
+	case errors.Is(err, serrors.ErrValueNotInteger):
+		return codes.FailedPrecondition, true
+	case errors.Is(err, serrors.ErrValueOutOfBounds):
+		return codes.OutOfRange, true
+	}
+	return codes.OK, false
+}
+
 // DeleteRange implements proto/regatta.proto KV.DeleteRange method.
 func (s *KVServer) DeleteRange(ctx context.Context, req *regattapb.DeleteRangeRequest) (*regattapb.DeleteRangeResponse, error) {
 	if len(req.GetTable()) == 0 {
@@ -84,11 +362,19 @@ func (s *KVServer) DeleteRange(ctx context.Context, req *regattapb.DeleteRangeRe
 		return nil, status.Errorf(codes.InvalidArgument, "key must be set")
 	}
 
+	if err := validateRequestID(req.GetRequestId()); err != nil {
+		return nil, err
+	}
+	req.RequestId = userRequestID(ctx, req.GetRequestId())
+
 	r, err := s.Storage.Delete(ctx, req)
 	if err != nil {
 		if errors.Is(err, serrors.ErrTableNotFound) {
 			return nil, status.Error(codes.NotFound, "table not found")
 		}
+		if errors.Is(err, serrors.ErrRequestIDReused) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	return r, nil
@@ -103,29 +389,166 @@ func (s *KVServer) Txn(ctx context.Context, req *regattapb.TxnRequest) (*regatta
 		return nil, status.Errorf(codes.InvalidArgument, "table must be set")
 	}
 
+	if err := validateTxnOps(req.GetSuccess(), 0); err != nil {
+		return nil, err
+	}
+	if err := validateTxnOps(req.GetFailure(), 0); err != nil {
+		return nil, err
+	}
+
+	if err := validateRequestID(req.GetRequestId()); err != nil {
+		return nil, err
+	}
+	req.RequestId = userRequestID(ctx, req.GetRequestId())
+
 	r, err := s.Storage.Txn(ctx, req)
 	if err != nil {
 		if errors.Is(err, serrors.ErrTableNotFound) {
 			return nil, status.Error(codes.NotFound, "table not found")
 		}
+		if errors.Is(err, serrors.ErrRequestIDReused) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, serrors.ErrLeaseNotFound) {
+			return nil, status.Error(codes.NotFound, "lease not found")
+		}
+		if code, ok := incrementErrorCode(err); ok {
+			return nil, status.Error(code, err.Error())
+		}
+		if errors.Is(err, serrors.ErrValueTooLarge) {
+			return nil, errValueTooLarge
+		}
+		if errors.Is(err, serrors.ErrValueLengthExceeded) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, serrors.ErrRevisionNotApplied) {
+			return nil, status.Error(codes.DeadlineExceeded, err.Error())
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	return r, nil
 }
 
+// Watch implements proto/regatta.proto KV.Watch method.
+func (s *KVServer) Watch(req *regattapb.WatchRequest, srv regattapb.KV_WatchServer) error {
+	if s.Tables == nil || s.LogReader == nil {
+		return status.Error(codes.Unimplemented, "method Watch not implemented")
+	}
+
+	if len(req.GetTable()) == 0 {
+		return status.Errorf(codes.InvalidArgument, "table must be set")
+	}
+
+	if len(req.GetKey()) == 0 {
+		return status.Errorf(codes.InvalidArgument, "key must be set")
+	}
+
+	t, err := s.Tables.GetTable(string(req.GetTable()))
+	if err != nil {
+		if errors.Is(err, serrors.ErrTableNotFound) {
+			return status.Error(codes.NotFound, "table not found")
+		}
+		return status.Error(codes.Unavailable, err.Error())
+	}
+
+	w := &watcher{
+		table:     t,
+		logReader: s.LogReader,
+		rng:       watchRange{key: req.GetKey(), rangeEnd: req.GetRangeEnd()},
+		send:      srv.Send,
+	}
+	return w.run(srv.Context(), req.GetStartRevision())
+}
+
 // ReadonlyKVServer implements read part of KV service from proto/regatta.proto.
+// The writes are rejected unless the Leader client is set, in which case they are forwarded to the leader cluster.
 type ReadonlyKVServer struct {
 	KVServer
+	// Leader is the KV API client of the leader cluster the writes are forwarded to.
+	Leader regattapb.KVClient
+	// Replication if set, the forwarded writes wait until the local replica of the table applies the revision returned by the leader cluster.
+	Replication LeaderIndexService
 }
 
 // Put implements proto/regatta.proto KV.Put method.
-func (r *ReadonlyKVServer) Put(_ context.Context, _ *regattapb.PutRequest) (*regattapb.PutResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method Put not implemented for follower")
+func (r *ReadonlyKVServer) Put(ctx context.Context, req *regattapb.PutRequest) (*regattapb.PutResponse, error) {
+	if r.Leader == nil {
+		return nil, status.Error(codes.Unimplemented, "method Put not implemented for follower")
+	}
+	res, err := r.Leader.Put(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.waitReplicated(ctx, req.GetTable(), res.GetHeader()); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// PutLarge implements proto/regatta.proto KV.PutLarge method.
+func (r *ReadonlyKVServer) PutLarge(srv regattapb.KV_PutLargeServer) error {
+	if r.Leader == nil {
+		return status.Error(codes.Unimplemented, "method PutLarge not implemented for follower")
+	}
+	stream, err := r.Leader.PutLarge(srv.Context())
+	if err != nil {
+		return err
+	}
+	var table []byte
+	for {
+		req, err := srv.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if table == nil {
+			table = req.GetTable()
+		}
+		if err := stream.Send(req); err != nil {
+			// The actual error is returned by the CloseAndRecv.
+			break
+		}
+	}
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+	if err := r.waitReplicated(srv.Context(), table, res.GetHeader()); err != nil {
+		return err
+	}
+	return srv.SendAndClose(res)
+}
+
+// Increment implements proto/regatta.proto KV.Increment method.
+func (r *ReadonlyKVServer) Increment(ctx context.Context, req *regattapb.IncrementRequest) (*regattapb.IncrementResponse, error) {
+	if r.Leader == nil {
+		return nil, status.Error(codes.Unimplemented, "method Increment not implemented for follower")
+	}
+	res, err := r.Leader.Increment(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.waitReplicated(ctx, req.GetTable(), res.GetHeader()); err != nil {
+		return nil, err
+	}
+	return res, nil
 }
 
 // DeleteRange implements proto/regatta.proto KV.DeleteRange method.
-func (r *ReadonlyKVServer) DeleteRange(_ context.Context, _ *regattapb.DeleteRangeRequest) (*regattapb.DeleteRangeResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method DeleteRange not implemented for follower")
+func (r *ReadonlyKVServer) DeleteRange(ctx context.Context, req *regattapb.DeleteRangeRequest) (*regattapb.DeleteRangeResponse, error) {
+	if r.Leader == nil {
+		return nil, status.Error(codes.Unimplemented, "method DeleteRange not implemented for follower")
+	}
+	res, err := r.Leader.DeleteRange(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.waitReplicated(ctx, req.GetTable(), res.GetHeader()); err != nil {
+		return nil, err
+	}
+	return res, nil
 }
 
 // Txn processes multiple requests in a single transaction.
@@ -137,18 +560,66 @@ func (r *ReadonlyKVServer) Txn(ctx context.Context, req *regattapb.TxnRequest) (
 	if isReadonlyTransaction(req) {
 		return r.KVServer.Txn(ctx, req)
 	}
-	return nil, status.Error(codes.Unimplemented, "writable Txn not implemented for follower")
+	if r.Leader == nil {
+		return nil, status.Error(codes.Unimplemented, "writable Txn not implemented for follower")
+	}
+	res, err := r.Leader.Txn(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.waitReplicated(ctx, req.GetTable(), res.GetHeader()); err != nil {
+		return nil, err
+	}
+	return res, nil
 }
 
-func isReadonlyTransaction(req *regattapb.TxnRequest) bool {
-	for _, op := range req.Success {
-		if _, ok := op.Request.(*regattapb.RequestOp_RequestRange); !ok {
-			return false
+// waitReplicated waits until the local replica of the table applies the revision of the forwarded write
+// if the Replication is set. The write is already applied in the leader cluster when the waiting fails.
+func (r *ReadonlyKVServer) waitReplicated(ctx context.Context, table []byte, header *regattapb.ResponseHeader) error {
+	if r.Replication == nil {
+		return nil
+	}
+	ticker := time.NewTicker(replicationPollInterval)
+	defer ticker.Stop()
+	for {
+		idx, err := r.Replication.LeaderIndex(ctx, table)
+		if err != nil {
+			if errors.Is(err, serrors.ErrTableNotFound) {
+				return status.Error(codes.NotFound, "table not found")
+			}
+			return status.Error(codes.Internal, err.Error())
+		}
+		if idx >= header.GetRevision() {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.Errorf(status.FromContextError(ctx.Err()).Code(), "write applied in the leader cluster at revision %d but not replicated yet", header.GetRevision())
+		case <-ticker.C:
 		}
 	}
+}
+
+// Watch implements proto/regatta.proto KV.Watch method.
+func (r *ReadonlyKVServer) Watch(_ *regattapb.WatchRequest, _ regattapb.KV_WatchServer) error {
+	return status.Error(codes.Unimplemented, "method Watch not implemented for follower")
+}
+
+func isReadonlyTransaction(req *regattapb.TxnRequest) bool {
+	return isReadonlyOps(req.Success) && isReadonlyOps(req.Failure)
+}
 
-	for _, op := range req.Failure {
-		if _, ok := op.Request.(*regattapb.RequestOp_RequestRange); !ok {
+// isReadonlyOps reports whether all the operations are ranges, the nested transactions are read-only if their operations are.
+func isReadonlyOps(ops []*regattapb.RequestOp) bool {
+	for _, op := range ops {
+		switch o := op.Request.(type) {
+		case *regattapb.RequestOp_RequestRange:
+		case *regattapb.RequestOp_RequestTxn:
+			if !isReadonlyOps(o.RequestTxn.Success) || !isReadonlyOps(o.RequestTxn.Failure) {
+				return false
+			}
+		default:
 			return false
 		}
 	}
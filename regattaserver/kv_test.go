@@ -4,12 +4,16 @@ package regattaserver
 
 import (
 	"context"
+	"io"
 	"testing"
+	"time"
 
 	"github.com/jamf/regatta/regattapb"
 	"github.com/jamf/regatta/storage/errors"
+	"github.com/jamf/regatta/storage/logreader"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -319,6 +323,41 @@ func TestKVServer_RangeNotFound(t *testing.T) {
 	r.EqualError(err, status.Errorf(codes.NotFound, "table not found").Error())
 }
 
+func TestKVServer_RangeRevision(t *testing.T) {
+	r := require.New(t)
+	kv := KVServer{Storage: &MockStorage{rangeError: errors.ErrCompacted}}
+	_, err := kv.Range(context.Background(), &regattapb.RangeRequest{Table: table1Name, Key: key1Name, Revision: 1})
+	r.EqualError(err, status.Error(codes.OutOfRange, "required revision has been compacted").Error())
+
+	kv.Storage = &MockStorage{rangeError: errors.ErrFutureRevision}
+	_, err = kv.Range(context.Background(), &regattapb.RangeRequest{Table: table1Name, Key: key1Name, Revision: 100})
+	r.EqualError(err, status.Error(codes.OutOfRange, "required revision is a future revision").Error())
+
+	kv.Storage = &MockStorage{rangeError: errors.ErrCompacted}
+	err = kv.Cursor(&regattapb.RangeRequest{Table: table1Name, Key: key1Name, Revision: 1}, &mockCursorServer{ctx: context.Background()})
+	r.EqualError(err, status.Error(codes.OutOfRange, "required revision has been compacted").Error())
+}
+
+func TestKVServer_RangeMaxStaleness(t *testing.T) {
+	r := require.New(t)
+	kv := KVServer{Storage: &MockStorage{rangeError: errors.ErrReplicaStale}}
+	_, err := kv.Range(context.Background(), &regattapb.RangeRequest{Table: table1Name, Key: key1Name, MaxStalenessMs: 1000})
+	r.EqualError(err, status.Error(codes.Unavailable, "replica is staler than the requested max staleness").Error())
+
+	err = kv.Cursor(&regattapb.RangeRequest{Table: table1Name, Key: key1Name, MaxStalenessMs: 1000}, &mockCursorServer{ctx: context.Background()})
+	r.EqualError(err, status.Error(codes.Unavailable, "replica is staler than the requested max staleness").Error())
+}
+
+func TestKVServer_RangeMinRevision(t *testing.T) {
+	r := require.New(t)
+	kv := KVServer{Storage: &MockStorage{rangeError: errors.ErrRevisionNotApplied}}
+	_, err := kv.Range(context.Background(), &regattapb.RangeRequest{Table: table1Name, Key: key1Name, MinRevision: 1000})
+	r.EqualError(err, status.Error(codes.DeadlineExceeded, "replica has not applied the requested min revision").Error())
+
+	err = kv.Cursor(&regattapb.RangeRequest{Table: table1Name, Key: key1Name, MinRevision: 1000}, &mockCursorServer{ctx: context.Background()})
+	r.EqualError(err, status.Error(codes.DeadlineExceeded, "replica has not applied the requested min revision").Error())
+}
+
 func TestKVServer_RangeInvalidArgument(t *testing.T) {
 	r := require.New(t)
 	kv := KVServer{
@@ -355,45 +394,217 @@ func TestKVServer_RangeInvalidArgument(t *testing.T) {
 		CountOnly: true,
 	})
 	r.EqualError(err, status.Errorf(codes.InvalidArgument, "keys_only and count_only must not be set at the same time").Error())
+
+	t.Log("Get with unknown sort order")
+	_, err = kv.Range(context.Background(), &regattapb.RangeRequest{
+		Table:     table1Name,
+		Key:       key1Name,
+		SortOrder: regattapb.SortOrder(42),
+	})
+	r.EqualError(err, status.Errorf(codes.InvalidArgument, "sort_order is not valid").Error())
+
+	t.Log("Get with invalid key regex")
+	_, err = kv.Range(context.Background(), &regattapb.RangeRequest{
+		Table:  table1Name,
+		Key:    key1Name,
+		Filter: &regattapb.RangeFilter{KeyRegex: "key("},
+	})
+	r.EqualError(err, status.Errorf(codes.InvalidArgument, "key_regex is not valid: error parsing regexp: missing closing ): `key(`").Error())
+
+	t.Log("Get with negative value length bound")
+	_, err = kv.Range(context.Background(), &regattapb.RangeRequest{
+		Table:  table1Name,
+		Key:    key1Name,
+		Filter: &regattapb.RangeFilter{MaxValueLength: -1},
+	})
+	r.EqualError(err, status.Errorf(codes.InvalidArgument, "value length bounds must not be negative").Error())
+
+	t.Log("Txn with invalid key regex")
+	_, err = kv.Txn(context.Background(), &regattapb.TxnRequest{
+		Table: table1Name,
+		Success: []*regattapb.RequestOp{{Request: &regattapb.RequestOp_RequestRange{
+			RequestRange: &regattapb.RequestOp_Range{Key: key1Name, Filter: &regattapb.RangeFilter{KeyRegex: "key("}},
+		}}},
+	})
+	r.EqualError(err, status.Errorf(codes.InvalidArgument, "key_regex is not valid: error parsing regexp: missing closing ): `key(`").Error())
 }
 
-func TestKVServer_RangeUnimplemented(t *testing.T) {
+func TestKVServer_RangeNegativeRevision(t *testing.T) {
 	a := assert.New(t)
 	kv := KVServer{
 		Storage: &MockStorage{},
 	}
 
-	t.Log("Get kv with unimplemented min_mod_revision")
+	t.Log("Get kv with negative min_mod_revision")
 	_, err := kv.Range(context.Background(), &regattapb.RangeRequest{
 		Table:          table1Name,
 		Key:            key1Name,
-		MinModRevision: 1,
+		MinModRevision: -1,
 	})
-	a.EqualError(err, status.Errorf(codes.Unimplemented, "min_mod_revision not implemented").Error())
+	a.EqualError(err, status.Errorf(codes.InvalidArgument, "revision bounds must not be negative").Error())
 
-	t.Log("Get kv with unimplemented max_mod_revision")
+	t.Log("Get kv with negative max_mod_revision")
 	_, err = kv.Range(context.Background(), &regattapb.RangeRequest{
 		Table:          table1Name,
 		Key:            key1Name,
-		MaxModRevision: 1,
+		MaxModRevision: -1,
 	})
-	a.EqualError(err, status.Errorf(codes.Unimplemented, "max_mod_revision not implemented").Error())
+	a.EqualError(err, status.Errorf(codes.InvalidArgument, "revision bounds must not be negative").Error())
 
-	t.Log("Get kv with unimplemented min_create_revision")
+	t.Log("Get kv with negative min_create_revision")
 	_, err = kv.Range(context.Background(), &regattapb.RangeRequest{
 		Table:             table1Name,
 		Key:               key1Name,
-		MinCreateRevision: 1,
+		MinCreateRevision: -1,
 	})
-	a.EqualError(err, status.Errorf(codes.Unimplemented, "min_create_revision not implemented").Error())
+	a.EqualError(err, status.Errorf(codes.InvalidArgument, "revision bounds must not be negative").Error())
 
-	t.Log("Get kv with unimplemented max_create_revision")
+	t.Log("Get kv with negative max_create_revision")
 	_, err = kv.Range(context.Background(), &regattapb.RangeRequest{
 		Table:             table1Name,
 		Key:               key1Name,
-		MaxCreateRevision: 1,
+		MaxCreateRevision: -1,
 	})
-	a.EqualError(err, status.Errorf(codes.Unimplemented, "max_create_revision not implemented").Error())
+	a.EqualError(err, status.Errorf(codes.InvalidArgument, "revision bounds must not be negative").Error())
+}
+
+type mockCursorServer struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses []*regattapb.RangeResponse
+}
+
+func (m *mockCursorServer) Context() context.Context {
+	return m.ctx
+}
+
+func (m *mockCursorServer) Send(r *regattapb.RangeResponse) error {
+	m.responses = append(m.responses, r)
+	return nil
+}
+
+func TestKVServer_Cursor(t *testing.T) {
+	r := require.New(t)
+	responses := []*regattapb.RangeResponse{
+		{Kvs: []*regattapb.KeyValue{{Key: key1Name, Value: table1Value1}}, Count: 1, More: true},
+		{Kvs: []*regattapb.KeyValue{{Key: key2Name, Value: table1Value2}}, Count: 1},
+	}
+	kv := KVServer{
+		Storage: &MockStorage{cursorResponses: responses},
+	}
+
+	t.Log("Stream batches")
+	srv := &mockCursorServer{ctx: context.Background()}
+	r.NoError(kv.Cursor(&regattapb.RangeRequest{Table: table1Name, Key: key1Name, RangeEnd: key3Name, BatchSize: 1}, srv))
+	r.Equal(responses, srv.responses)
+
+	t.Log("Stream with negative batch size")
+	err := kv.Cursor(&regattapb.RangeRequest{Table: table1Name, Key: key1Name, BatchSize: -1}, &mockCursorServer{ctx: context.Background()})
+	r.EqualError(err, status.Errorf(codes.InvalidArgument, "batch_size must be a positive number").Error())
+
+	t.Log("Stream with empty key name")
+	err = kv.Cursor(&regattapb.RangeRequest{Table: table1Name}, &mockCursorServer{ctx: context.Background()})
+	r.EqualError(err, status.Errorf(codes.InvalidArgument, "key must be set").Error())
+
+	t.Log("Stream from non-existing table")
+	kv.Storage = &MockStorage{rangeError: errors.ErrTableNotFound}
+	err = kv.Cursor(&regattapb.RangeRequest{Table: table1Name, Key: key1Name}, &mockCursorServer{ctx: context.Background()})
+	r.EqualError(err, status.Errorf(codes.NotFound, "table not found").Error())
+}
+
+type mockPutLargeServer struct {
+	grpc.ServerStream
+	ctx      context.Context
+	requests []*regattapb.PutLargeRequest
+	response *regattapb.PutLargeResponse
+}
+
+func (m *mockPutLargeServer) Context() context.Context {
+	return m.ctx
+}
+
+func (m *mockPutLargeServer) Recv() (*regattapb.PutLargeRequest, error) {
+	if len(m.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := m.requests[0]
+	m.requests = m.requests[1:]
+	return req, nil
+}
+
+func (m *mockPutLargeServer) SendAndClose(r *regattapb.PutLargeResponse) error {
+	m.response = r
+	return nil
+}
+
+func TestKVServer_PutLarge(t *testing.T) {
+	r := require.New(t)
+	storage := &MockStorage{putLargeResponse: regattapb.PutLargeResponse{Size: 30}}
+	kv := KVServer{Storage: storage}
+
+	t.Log("value is read from all the messages")
+	srv := &mockPutLargeServer{ctx: context.Background(), requests: []*regattapb.PutLargeRequest{
+		{Table: table1Name, Key: key1Name, Value: table1Value1},
+		{},
+		{Value: table1Value2},
+	}}
+	r.NoError(kv.PutLarge(srv))
+	r.Equal(append(append([]byte{}, table1Value1...), table1Value2...), storage.putLargeValue)
+	r.Equal(uint64(30), srv.response.Size)
+
+	t.Log("empty stream")
+	err := kv.PutLarge(&mockPutLargeServer{ctx: context.Background()})
+	r.EqualError(err, status.Errorf(codes.InvalidArgument, "table must be set").Error())
+
+	t.Log("key must be set in the first message")
+	err = kv.PutLarge(&mockPutLargeServer{ctx: context.Background(), requests: []*regattapb.PutLargeRequest{{Table: table1Name}, {Key: key1Name}}})
+	r.EqualError(err, status.Errorf(codes.InvalidArgument, "key must be set").Error())
+
+	t.Log("value too large")
+	kv.Storage = &MockStorage{putError: errors.ErrValueLengthExceeded}
+	err = kv.PutLarge(&mockPutLargeServer{ctx: context.Background(), requests: []*regattapb.PutLargeRequest{{Table: table1Name, Key: key1Name}}})
+	r.EqualError(err, status.Error(codes.InvalidArgument, errors.ErrValueLengthExceeded.Error()).Error())
+}
+
+type mockGetLargeServer struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses []*regattapb.GetLargeResponse
+}
+
+func (m *mockGetLargeServer) Context() context.Context {
+	return m.ctx
+}
+
+func (m *mockGetLargeServer) Send(r *regattapb.GetLargeResponse) error {
+	m.responses = append(m.responses, r)
+	return nil
+}
+
+func TestKVServer_GetLarge(t *testing.T) {
+	r := require.New(t)
+	responses := []*regattapb.GetLargeResponse{
+		{Header: &regattapb.ResponseHeader{Revision: 1}, Kv: &regattapb.KeyValue{Key: key1Name}, Value: table1Value1},
+		{Value: table1Value2},
+	}
+	kv := KVServer{Storage: &MockStorage{getLargeResponses: responses}}
+
+	srv := &mockGetLargeServer{ctx: context.Background()}
+	r.NoError(kv.GetLarge(&regattapb.GetLargeRequest{Table: table1Name, Key: key1Name}, srv))
+	r.Equal(responses, srv.responses)
+
+	err := kv.GetLarge(&regattapb.GetLargeRequest{Table: table1Name}, &mockGetLargeServer{ctx: context.Background()})
+	r.EqualError(err, status.Errorf(codes.InvalidArgument, "key must be set").Error())
+
+	kv.Storage = &MockStorage{rangeError: errors.ErrKeyNotFound}
+	err = kv.GetLarge(&regattapb.GetLargeRequest{Table: table1Name, Key: key1Name}, &mockGetLargeServer{ctx: context.Background()})
+	r.EqualError(err, status.Errorf(codes.NotFound, "key not found").Error())
+}
+
+func TestKVServer_RangeValueTooLarge(t *testing.T) {
+	kv := KVServer{Storage: &MockStorage{rangeError: errors.ErrValueTooLarge}}
+	_, err := kv.Range(context.Background(), &regattapb.RangeRequest{Table: table1Name, Key: key1Name})
+	require.EqualError(t, err, errValueTooLarge.Error())
 }
 
 func TestKVServer_PutInvalidArgument(t *testing.T) {
@@ -418,6 +629,34 @@ func TestKVServer_PutInvalidArgument(t *testing.T) {
 	})
 	r.EqualError(err, status.Errorf(codes.InvalidArgument, "key must be set").Error())
 
+	t.Log("Put with too long request ID")
+	_, err = kv.Put(context.Background(), &regattapb.PutRequest{
+		Table:     table1Name,
+		Key:       key1Name,
+		Value:     table1Value1,
+		RequestId: make([]byte, maxRequestIDLen+1),
+	})
+	r.EqualError(err, status.Errorf(codes.InvalidArgument, "request_id must not be longer than 128 bytes").Error())
+
+	t.Log("Put with reused request ID")
+	kv.Storage = &MockStorage{putError: errors.ErrRequestIDReused}
+	_, err = kv.Put(context.Background(), &regattapb.PutRequest{
+		Table:     table1Name,
+		Key:       key1Name,
+		Value:     table1Value1,
+		RequestId: []byte("request"),
+	})
+	r.EqualError(err, status.Errorf(codes.InvalidArgument, "request ID was already used by a different request").Error())
+
+	t.Log("Put with too large value")
+	kv.Storage = &MockStorage{putError: errors.ErrValueLengthExceeded}
+	_, err = kv.Put(context.Background(), &regattapb.PutRequest{
+		Table: table1Name,
+		Key:   key1Name,
+		Value: table1Value1,
+	})
+	r.EqualError(err, status.Errorf(codes.InvalidArgument, "value length exceeded max allowed value").Error())
+
 	t.Log("Put with non-existing table")
 	kv.Storage = &MockStorage{putError: errors.ErrTableNotFound}
 	_, err = kv.Put(context.Background(), &regattapb.PutRequest{
@@ -448,6 +687,14 @@ func TestKVServer_DeleteRangeInvalidArgument(t *testing.T) {
 	})
 	r.EqualError(err, status.Errorf(codes.InvalidArgument, "key must be set").Error())
 
+	t.Log("Delete with too long request ID")
+	_, err = kv.DeleteRange(context.Background(), &regattapb.DeleteRangeRequest{
+		Table:     table1Name,
+		Key:       key1Name,
+		RequestId: make([]byte, maxRequestIDLen+1),
+	})
+	r.EqualError(err, status.Errorf(codes.InvalidArgument, "request_id must not be longer than 128 bytes").Error())
+
 	t.Log("Delete with non-existing table")
 	kv.Storage = &MockStorage{deleteError: errors.ErrTableNotFound}
 	_, err = kv.DeleteRange(context.Background(), &regattapb.DeleteRangeRequest{
@@ -473,6 +720,71 @@ func TestKVServer_DeleteRange(t *testing.T) {
 	r.Equal(int64(1), drresp.GetDeleted())
 }
 
+func TestKVServer_Increment(t *testing.T) {
+	r := require.New(t)
+	kv := KVServer{
+		Storage: &MockStorage{incrementResponse: regattapb.IncrementResponse{Value: 5}},
+	}
+
+	t.Log("Increment with empty table name")
+	_, err := kv.Increment(context.Background(), &regattapb.IncrementRequest{Key: key1Name, Delta: 1})
+	r.EqualError(err, status.Errorf(codes.InvalidArgument, "table must be set").Error())
+
+	t.Log("Increment with empty key name")
+	_, err = kv.Increment(context.Background(), &regattapb.IncrementRequest{Table: table1Name, Delta: 1})
+	r.EqualError(err, status.Errorf(codes.InvalidArgument, "key must be set").Error())
+
+	t.Log("Increment kv")
+	resp, err := kv.Increment(context.Background(), &regattapb.IncrementRequest{Table: table1Name, Key: key1Name, Delta: 1})
+	r.NoError(err)
+	r.Equal(int64(5), resp.GetValue())
+
+	t.Log("Increment non-integer value")
+	kv.Storage = &MockStorage{incrementError: errors.ErrValueNotInteger}
+	_, err = kv.Increment(context.Background(), &regattapb.IncrementRequest{Table: table1Name, Key: key1Name, Delta: 1})
+	r.EqualError(err, status.Errorf(codes.FailedPrecondition, "value is not an integer").Error())
+
+	t.Log("Increment out of bounds")
+	kv.Storage = &MockStorage{incrementError: errors.ErrValueOutOfBounds}
+	_, err = kv.Increment(context.Background(), &regattapb.IncrementRequest{Table: table1Name, Key: key1Name, Delta: 1})
+	r.EqualError(err, status.Errorf(codes.OutOfRange, "value out of bounds").Error())
+
+	t.Log("Increment with non-existing table")
+	kv.Storage = &MockStorage{incrementError: errors.ErrTableNotFound}
+	_, err = kv.Increment(context.Background(), &regattapb.IncrementRequest{Table: []byte("non_existing_table"), Key: key1Name, Delta: 1})
+	r.EqualError(err, status.Errorf(codes.NotFound, "table not found").Error())
+
+	t.Log("Increment on follower")
+	_, err = (&ReadonlyKVServer{KVServer: kv}).Increment(context.Background(), &regattapb.IncrementRequest{Table: table1Name, Key: key1Name, Delta: 1})
+	r.EqualError(err, status.Errorf(codes.Unimplemented, "method Increment not implemented for follower").Error())
+}
+
+func TestKVServer_WatchInvalidArgument(t *testing.T) {
+	r := require.New(t)
+	kv := KVServer{
+		Storage: &MockStorage{},
+	}
+
+	t.Log("Watch without log reader")
+	err := kv.Watch(&regattapb.WatchRequest{Table: table1Name, Key: key1Name}, nil)
+	r.EqualError(err, status.Errorf(codes.Unimplemented, "method Watch not implemented").Error())
+
+	kv.Tables = MockTableService{error: errors.ErrTableNotFound}
+	kv.LogReader = &logreader.Simple{}
+
+	t.Log("Watch with empty table name")
+	err = kv.Watch(&regattapb.WatchRequest{Table: []byte{}, Key: key1Name}, nil)
+	r.EqualError(err, status.Errorf(codes.InvalidArgument, "table must be set").Error())
+
+	t.Log("Watch with empty key name")
+	err = kv.Watch(&regattapb.WatchRequest{Table: table1Name, Key: []byte{}}, nil)
+	r.EqualError(err, status.Errorf(codes.InvalidArgument, "key must be set").Error())
+
+	t.Log("Watch non-existing table")
+	err = kv.Watch(&regattapb.WatchRequest{Table: []byte("non_existing_table"), Key: key1Name}, nil)
+	r.EqualError(err, status.Errorf(codes.NotFound, "table not found").Error())
+}
+
 func TestReadonlyKVServer_Put(t *testing.T) {
 	r := require.New(t)
 	kv := ReadonlyKVServer{
@@ -505,6 +817,22 @@ func TestReadonlyKVServer_DeleteRange(t *testing.T) {
 	r.EqualError(err, status.Errorf(codes.Unimplemented, "method DeleteRange not implemented for follower").Error())
 }
 
+func TestReadonlyKVServer_Watch(t *testing.T) {
+	r := require.New(t)
+	kv := ReadonlyKVServer{
+		KVServer: KVServer{
+			Storage: &MockStorage{},
+		},
+	}
+
+	t.Log("Watch key")
+	err := kv.Watch(&regattapb.WatchRequest{
+		Table: table1Name,
+		Key:   key1Name,
+	}, nil)
+	r.EqualError(err, status.Errorf(codes.Unimplemented, "method Watch not implemented for follower").Error())
+}
+
 func TestReadonlyKVServer_Txn(t *testing.T) {
 	r := require.New(t)
 	kv := ReadonlyKVServer{
@@ -537,4 +865,161 @@ func TestReadonlyKVServer_Txn(t *testing.T) {
 		},
 	})
 	r.NoError(err)
+
+	t.Log("Writable nested Txn")
+	_, err = kv.Txn(context.Background(), &regattapb.TxnRequest{
+		Table: table1Name,
+		Success: []*regattapb.RequestOp{
+			{Request: &regattapb.RequestOp_RequestTxn{RequestTxn: &regattapb.Txn{
+				Success: []*regattapb.RequestOp{{Request: &regattapb.RequestOp_RequestRange{RequestRange: &regattapb.RequestOp_Range{Key: key1Name}}}},
+				Failure: []*regattapb.RequestOp{{Request: &regattapb.RequestOp_RequestPut{RequestPut: &regattapb.RequestOp_Put{Key: key1Name}}}},
+			}}},
+		},
+	})
+	r.EqualError(err, status.Errorf(codes.Unimplemented, "writable Txn not implemented for follower").Error())
+
+	t.Log("Readonly nested Txn")
+	_, err = kv.Txn(context.Background(), &regattapb.TxnRequest{
+		Table: table1Name,
+		Success: []*regattapb.RequestOp{
+			{Request: &regattapb.RequestOp_RequestTxn{RequestTxn: &regattapb.Txn{
+				Failure: []*regattapb.RequestOp{{Request: &regattapb.RequestOp_RequestRange{RequestRange: &regattapb.RequestOp_Range{Key: key1Name}}}},
+			}}},
+		},
+	})
+	r.NoError(err)
+}
+
+func TestKVServer_TxnNestedTooDeep(t *testing.T) {
+	kv := KVServer{Storage: &MockStorage{}}
+	op := &regattapb.RequestOp{Request: &regattapb.RequestOp_RequestRange{RequestRange: &regattapb.RequestOp_Range{Key: key1Name}}}
+	for i := 0; i <= maxTxnDepth; i++ {
+		op = &regattapb.RequestOp{Request: &regattapb.RequestOp_RequestTxn{RequestTxn: &regattapb.Txn{Success: []*regattapb.RequestOp{op}}}}
+	}
+	_, err := kv.Txn(context.Background(), &regattapb.TxnRequest{Table: table1Name, Failure: []*regattapb.RequestOp{op}})
+	require.EqualError(t, err, status.Errorf(codes.InvalidArgument, "transactions must not be nested deeper than %d levels", maxTxnDepth).Error())
+
+	_, err = kv.Txn(context.Background(), &regattapb.TxnRequest{Table: table1Name, Failure: op.GetRequestTxn().Success})
+	require.NoError(t, err)
+}
+
+// mockLeaderKVClient is the KV client of the leader cluster answering all the writes with the same header.
+type mockLeaderKVClient struct {
+	regattapb.KVClient
+	header *regattapb.ResponseHeader
+	err    error
+}
+
+func (c *mockLeaderKVClient) Put(_ context.Context, _ *regattapb.PutRequest, _ ...grpc.CallOption) (*regattapb.PutResponse, error) {
+	return &regattapb.PutResponse{Header: c.header}, c.err
+}
+
+func (c *mockLeaderKVClient) DeleteRange(_ context.Context, _ *regattapb.DeleteRangeRequest, _ ...grpc.CallOption) (*regattapb.DeleteRangeResponse, error) {
+	return &regattapb.DeleteRangeResponse{Header: c.header}, c.err
+}
+
+func (c *mockLeaderKVClient) Txn(_ context.Context, _ *regattapb.TxnRequest, _ ...grpc.CallOption) (*regattapb.TxnResponse, error) {
+	return &regattapb.TxnResponse{Header: c.header, Succeeded: true}, c.err
+}
+
+// mockLeaderIndex replicates a single leader index on every read.
+type mockLeaderIndex struct {
+	index uint64
+}
+
+func (m *mockLeaderIndex) LeaderIndex(_ context.Context, _ []byte) (uint64, error) {
+	m.index++
+	return m.index, nil
+}
+
+func TestReadonlyKVServer_Forward(t *testing.T) {
+	r := require.New(t)
+	header := &regattapb.ResponseHeader{ShardId: 1, ReplicaId: 2, Revision: 5}
+	replication := &mockLeaderIndex{}
+	kv := ReadonlyKVServer{
+		KVServer:    KVServer{Storage: &MockStorage{}},
+		Leader:      &mockLeaderKVClient{header: header},
+		Replication: replication,
+	}
+
+	put, err := kv.Put(context.Background(), &regattapb.PutRequest{Table: table1Name, Key: key1Name})
+	r.NoError(err)
+	r.Equal(header, put.Header)
+	r.Equal(uint64(5), replication.index, "put must wait until the revision is replicated")
+
+	replication.index = 0
+	del, err := kv.DeleteRange(context.Background(), &regattapb.DeleteRangeRequest{Table: table1Name, Key: key1Name})
+	r.NoError(err)
+	r.Equal(header, del.Header)
+	r.Equal(uint64(5), replication.index, "delete must wait until the revision is replicated")
+
+	replication.index = 0
+	txn, err := kv.Txn(context.Background(), &regattapb.TxnRequest{
+		Table: table1Name,
+		Success: []*regattapb.RequestOp{
+			{Request: &regattapb.RequestOp_RequestPut{RequestPut: &regattapb.RequestOp_Put{Key: key1Name}}},
+		},
+	})
+	r.NoError(err)
+	r.Equal(header, txn.Header)
+	r.True(txn.Succeeded)
+	r.Equal(uint64(5), replication.index, "txn must wait until the revision is replicated")
+
+	t.Log("waiting for the replication times out")
+	replication.index = 0
+	kv.Leader = &mockLeaderKVClient{header: &regattapb.ResponseHeader{Revision: 1_000_000}}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = kv.Put(ctx, &regattapb.PutRequest{Table: table1Name, Key: key1Name})
+	r.EqualError(err, status.Error(codes.DeadlineExceeded, "write applied in the leader cluster at revision 1000000 but not replicated yet").Error())
+
+	t.Log("leader error is returned as is")
+	kv.Leader = &mockLeaderKVClient{err: status.Error(codes.NotFound, "table not found")}
+	_, err = kv.Put(context.Background(), &regattapb.PutRequest{Table: table1Name, Key: key1Name})
+	r.EqualError(err, status.Error(codes.NotFound, "table not found").Error())
+
+	t.Log("forward without waiting for the replication")
+	kv.Leader = &mockLeaderKVClient{header: header}
+	kv.Replication = nil
+	put, err = kv.Put(context.Background(), &regattapb.PutRequest{Table: table1Name, Key: key1Name})
+	r.NoError(err)
+	r.Equal(header, put.Header)
+}
+
+func Test_userRequestID(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		id  []byte
+	}
+	tests := []struct {
+		name string
+		args args
+		want []byte
+	}{
+		{
+			name: "no request ID",
+			args: args{ctx: context.WithValue(context.Background(), userKey{}, "user"), id: nil},
+			want: nil,
+		},
+		{
+			name: "anonymous user",
+			args: args{ctx: context.Background(), id: []byte("request")},
+			want: []byte("\x00request"),
+		},
+		{
+			name: "authenticated user",
+			args: args{ctx: context.WithValue(context.Background(), userKey{}, "user"), id: []byte("request")},
+			want: []byte("\x04userrequest"),
+		},
+		{
+			name: "user name prefixed by the request ID of another user",
+			args: args{ctx: context.WithValue(context.Background(), userKey{}, "use"), id: []byte("rrequest")},
+			want: []byte("\x03userrequest"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, userRequestID(tt.args.ctx, tt.args.id))
+		})
+	}
 }
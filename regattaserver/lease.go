@@ -0,0 +1,139 @@
+// Copyright JAMF Software, LLC
+
+package regattaserver
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/jamf/regatta/regattapb"
+	serrors "github.com/jamf/regatta/storage/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// LeaseServer implements Lease service from proto/regatta.proto.
+type LeaseServer struct {
+	regattapb.UnimplementedLeaseServer
+	Storage LeaseService
+}
+
+// LeaseGrant implements proto/regatta.proto Lease.LeaseGrant method.
+func (s *LeaseServer) LeaseGrant(ctx context.Context, req *regattapb.LeaseGrantRequest) (*regattapb.LeaseGrantResponse, error) {
+	if len(req.GetTable()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "table must be set")
+	}
+
+	if req.GetTTL() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "TTL must be a positive number")
+	}
+
+	if req.GetID() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "ID must not be negative")
+	}
+
+	r, err := s.Storage.LeaseGrant(ctx, req)
+	if err != nil {
+		if errors.Is(err, serrors.ErrTableNotFound) {
+			return nil, status.Error(codes.NotFound, "table not found")
+		}
+		if errors.Is(err, serrors.ErrLeaseExists) {
+			return nil, status.Error(codes.AlreadyExists, "lease already exists")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return r, nil
+}
+
+// LeaseRevoke implements proto/regatta.proto Lease.LeaseRevoke method.
+func (s *LeaseServer) LeaseRevoke(ctx context.Context, req *regattapb.LeaseRevokeRequest) (*regattapb.LeaseRevokeResponse, error) {
+	if len(req.GetTable()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "table must be set")
+	}
+
+	r, err := s.Storage.LeaseRevoke(ctx, req)
+	if err != nil {
+		if errors.Is(err, serrors.ErrTableNotFound) {
+			return nil, status.Error(codes.NotFound, "table not found")
+		}
+		if errors.Is(err, serrors.ErrLeaseNotFound) {
+			return nil, status.Error(codes.NotFound, "lease not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return r, nil
+}
+
+// LeaseKeepAlive implements proto/regatta.proto Lease.LeaseKeepAlive method.
+// A keep alive of a lease that does not exist is responded with the TTL 0.
+func (s *LeaseServer) LeaseKeepAlive(srv regattapb.Lease_LeaseKeepAliveServer) error {
+	for {
+		req, err := srv.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if len(req.GetTable()) == 0 {
+			return status.Error(codes.InvalidArgument, "table must be set")
+		}
+
+		r, err := s.Storage.LeaseKeepAlive(srv.Context(), req)
+		if err != nil {
+			switch {
+			case errors.Is(err, serrors.ErrTableNotFound):
+				return status.Error(codes.NotFound, "table not found")
+			case errors.Is(err, serrors.ErrLeaseNotFound):
+				r = &regattapb.LeaseKeepAliveResponse{ID: req.GetID()}
+			default:
+				return status.Error(codes.Internal, err.Error())
+			}
+		}
+		if err := srv.Send(r); err != nil {
+			return err
+		}
+	}
+}
+
+// LeaseTimeToLive implements proto/regatta.proto Lease.LeaseTimeToLive method.
+// The TTL of a lease that does not exist is -1.
+func (s *LeaseServer) LeaseTimeToLive(ctx context.Context, req *regattapb.LeaseTimeToLiveRequest) (*regattapb.LeaseTimeToLiveResponse, error) {
+	if len(req.GetTable()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "table must be set")
+	}
+
+	r, err := s.Storage.LeaseTimeToLive(ctx, req)
+	if err != nil {
+		if errors.Is(err, serrors.ErrTableNotFound) {
+			return nil, status.Error(codes.NotFound, "table not found")
+		}
+		if errors.Is(err, serrors.ErrLeaseNotFound) {
+			return &regattapb.LeaseTimeToLiveResponse{ID: req.GetID(), TTL: -1}, nil
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return r, nil
+}
+
+// ReadonlyLeaseServer implements read part of Lease service from proto/regatta.proto.
+type ReadonlyLeaseServer struct {
+	LeaseServer
+}
+
+// LeaseGrant implements proto/regatta.proto Lease.LeaseGrant method.
+func (r *ReadonlyLeaseServer) LeaseGrant(_ context.Context, _ *regattapb.LeaseGrantRequest) (*regattapb.LeaseGrantResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method LeaseGrant not implemented for follower")
+}
+
+// LeaseRevoke implements proto/regatta.proto Lease.LeaseRevoke method.
+func (r *ReadonlyLeaseServer) LeaseRevoke(_ context.Context, _ *regattapb.LeaseRevokeRequest) (*regattapb.LeaseRevokeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method LeaseRevoke not implemented for follower")
+}
+
+// LeaseKeepAlive implements proto/regatta.proto Lease.LeaseKeepAlive method.
+func (r *ReadonlyLeaseServer) LeaseKeepAlive(_ regattapb.Lease_LeaseKeepAliveServer) error {
+	return status.Error(codes.Unimplemented, "method LeaseKeepAlive not implemented for follower")
+}
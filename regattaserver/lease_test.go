@@ -0,0 +1,128 @@
+// Copyright JAMF Software, LLC
+
+package regattaserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jamf/regatta/regattapb"
+	"github.com/jamf/regatta/storage/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type MockLeaseStorage struct {
+	grantResponse      regattapb.LeaseGrantResponse
+	timeToLiveResponse regattapb.LeaseTimeToLiveResponse
+	err                error
+}
+
+func (s *MockLeaseStorage) LeaseGrant(_ context.Context, _ *regattapb.LeaseGrantRequest) (*regattapb.LeaseGrantResponse, error) {
+	return &s.grantResponse, s.err
+}
+
+func (s *MockLeaseStorage) LeaseRevoke(_ context.Context, _ *regattapb.LeaseRevokeRequest) (*regattapb.LeaseRevokeResponse, error) {
+	return &regattapb.LeaseRevokeResponse{}, s.err
+}
+
+func (s *MockLeaseStorage) LeaseKeepAlive(_ context.Context, _ *regattapb.LeaseKeepAliveRequest) (*regattapb.LeaseKeepAliveResponse, error) {
+	return &regattapb.LeaseKeepAliveResponse{}, s.err
+}
+
+func (s *MockLeaseStorage) LeaseTimeToLive(_ context.Context, _ *regattapb.LeaseTimeToLiveRequest) (*regattapb.LeaseTimeToLiveResponse, error) {
+	return &s.timeToLiveResponse, s.err
+}
+
+func TestLeaseServer_LeaseGrant(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *regattapb.LeaseGrantRequest
+		storage *MockLeaseStorage
+		want    *regattapb.LeaseGrantResponse
+		wantErr error
+	}{
+		{
+			name:    "missing table",
+			req:     &regattapb.LeaseGrantRequest{TTL: 10},
+			storage: &MockLeaseStorage{},
+			wantErr: status.Error(codes.InvalidArgument, "table must be set"),
+		},
+		{
+			name:    "missing TTL",
+			req:     &regattapb.LeaseGrantRequest{Table: table1Name},
+			storage: &MockLeaseStorage{},
+			wantErr: status.Error(codes.InvalidArgument, "TTL must be a positive number"),
+		},
+		{
+			name:    "negative ID",
+			req:     &regattapb.LeaseGrantRequest{Table: table1Name, TTL: 10, ID: -1},
+			storage: &MockLeaseStorage{},
+			wantErr: status.Error(codes.InvalidArgument, "ID must not be negative"),
+		},
+		{
+			name:    "table not found",
+			req:     &regattapb.LeaseGrantRequest{Table: table1Name, TTL: 10},
+			storage: &MockLeaseStorage{err: errors.ErrTableNotFound},
+			wantErr: status.Error(codes.NotFound, "table not found"),
+		},
+		{
+			name:    "lease exists",
+			req:     &regattapb.LeaseGrantRequest{Table: table1Name, TTL: 10, ID: 1},
+			storage: &MockLeaseStorage{err: errors.ErrLeaseExists},
+			wantErr: status.Error(codes.AlreadyExists, "lease already exists"),
+		},
+		{
+			name:    "grant lease",
+			req:     &regattapb.LeaseGrantRequest{Table: table1Name, TTL: 10},
+			storage: &MockLeaseStorage{grantResponse: regattapb.LeaseGrantResponse{ID: 1, TTL: 10}},
+			want:    &regattapb.LeaseGrantResponse{ID: 1, TTL: 10},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			ls := &LeaseServer{Storage: tt.storage}
+			got, err := ls.LeaseGrant(context.Background(), tt.req)
+			if tt.wantErr != nil {
+				r.EqualError(err, tt.wantErr.Error())
+				return
+			}
+			r.NoError(err)
+			r.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestLeaseServer_LeaseRevoke(t *testing.T) {
+	r := require.New(t)
+	ls := &LeaseServer{Storage: &MockLeaseStorage{err: errors.ErrLeaseNotFound}}
+	_, err := ls.LeaseRevoke(context.Background(), &regattapb.LeaseRevokeRequest{ID: 1})
+	r.EqualError(err, status.Error(codes.InvalidArgument, "table must be set").Error())
+
+	_, err = ls.LeaseRevoke(context.Background(), &regattapb.LeaseRevokeRequest{Table: table1Name, ID: 1})
+	r.EqualError(err, status.Error(codes.NotFound, "lease not found").Error())
+}
+
+func TestLeaseServer_LeaseTimeToLive(t *testing.T) {
+	r := require.New(t)
+	ls := &LeaseServer{Storage: &MockLeaseStorage{err: errors.ErrLeaseNotFound}}
+	res, err := ls.LeaseTimeToLive(context.Background(), &regattapb.LeaseTimeToLiveRequest{Table: table1Name, ID: 1})
+	r.NoError(err)
+	r.Equal(&regattapb.LeaseTimeToLiveResponse{ID: 1, TTL: -1}, res)
+
+	ls = &LeaseServer{Storage: &MockLeaseStorage{timeToLiveResponse: regattapb.LeaseTimeToLiveResponse{ID: 1, TTL: 5, GrantedTTL: 10}}}
+	res, err = ls.LeaseTimeToLive(context.Background(), &regattapb.LeaseTimeToLiveRequest{Table: table1Name, ID: 1})
+	r.NoError(err)
+	r.Equal(&regattapb.LeaseTimeToLiveResponse{ID: 1, TTL: 5, GrantedTTL: 10}, res)
+}
+
+func TestReadonlyLeaseServer(t *testing.T) {
+	r := require.New(t)
+	ls := &ReadonlyLeaseServer{LeaseServer: LeaseServer{Storage: &MockLeaseStorage{}}}
+	_, err := ls.LeaseGrant(context.Background(), &regattapb.LeaseGrantRequest{Table: table1Name, TTL: 10})
+	r.EqualError(err, status.Error(codes.Unimplemented, "method LeaseGrant not implemented for follower").Error())
+	_, err = ls.LeaseRevoke(context.Background(), &regattapb.LeaseRevokeRequest{Table: table1Name, ID: 1})
+	r.EqualError(err, status.Error(codes.Unimplemented, "method LeaseRevoke not implemented for follower").Error())
+}
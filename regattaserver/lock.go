@@ -0,0 +1,216 @@
+// Copyright JAMF Software, LLC
+
+package regattaserver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jamf/regatta/regattapb"
+	serrors "github.com/jamf/regatta/storage/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errKeyDeleted stops watching the key once it is deleted.
+var errKeyDeleted = errors.New("key deleted")
+
+// LockServer implements Lock service from proto/regatta.proto.
+type LockServer struct {
+	regattapb.UnimplementedLockServer
+	Storage KVService
+	// Tables and LogReader are used to watch the keys of the lock holders.
+	Tables    TableService
+	LogReader LogReaderService
+}
+
+// Lock implements proto/regatta.proto Lock.Lock method.
+func (s *LockServer) Lock(ctx context.Context, req *regattapb.LockRequest) (*regattapb.LockResponse, error) {
+	if len(req.GetTable()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "table must be set")
+	}
+
+	if len(req.GetName()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "name must be set")
+	}
+
+	if req.GetLease() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "lease must be set")
+	}
+
+	key := candidateKey(req.GetName(), req.GetLease())
+	kv, header, created, err := createCandidate(ctx, s.Storage, req.GetTable(), key, nil, req.GetLease())
+	if err != nil {
+		return nil, err
+	}
+	if err := waitPredecessors(ctx, s.Storage, s.Tables, s.LogReader, req.GetTable(), req.GetName(), kv.CreateRevision); err != nil {
+		if created {
+			deleteCandidate(ctx, s.Storage, req.GetTable(), kv)
+		}
+		return nil, err
+	}
+	return &regattapb.LockResponse{Header: header, Key: key, Revision: kv.CreateRevision}, nil
+}
+
+// Unlock implements proto/regatta.proto Lock.Unlock method.
+func (s *LockServer) Unlock(ctx context.Context, req *regattapb.UnlockRequest) (*regattapb.UnlockResponse, error) {
+	if len(req.GetTable()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "table must be set")
+	}
+
+	if len(req.GetKey()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "key must be set")
+	}
+
+	r, err := s.Storage.Delete(ctx, &regattapb.DeleteRangeRequest{Table: req.GetTable(), Key: req.GetKey()})
+	if err != nil {
+		return nil, storageError(err)
+	}
+	return &regattapb.UnlockResponse{Header: r.Header}, nil
+}
+
+// candidateKey returns the key representing the lease in the lock or the election of the name.
+func candidateKey(name []byte, lease int64) []byte {
+	return fmt.Appendf(candidatePrefix(name), "%x", lease)
+}
+
+// candidatePrefix returns the prefix of the keys of the lock or the election of the name.
+func candidatePrefix(name []byte) []byte {
+	prefix := make([]byte, 0, len(name)+17)
+	prefix = append(prefix, name...)
+	return append(prefix, '/')
+}
+
+// prefixEnd returns the range end of the keys prefixed by the prefix, see RangeRequest.
+func prefixEnd(prefix []byte) []byte {
+	end := bytes.Clone(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return []byte{0}
+}
+
+// createCandidate creates the candidate key attached to the lease unless it already exists and returns the key,
+// created is set if the key was created by the call.
+func createCandidate(ctx context.Context, storage KVService, table, key, value []byte, lease int64) (kv *regattapb.KeyValue, header *regattapb.ResponseHeader, created bool, err error) {
+	get := &regattapb.RequestOp{Request: &regattapb.RequestOp_RequestRange{RequestRange: &regattapb.RequestOp_Range{Key: key}}}
+	r, err := storage.Txn(ctx, &regattapb.TxnRequest{
+		Table: table,
+		Compare: []*regattapb.Compare{{
+			Key:         key,
+			Target:      regattapb.Compare_CREATE,
+			Result:      regattapb.Compare_EQUAL,
+			TargetUnion: &regattapb.Compare_CreateRevision{CreateRevision: 0},
+		}},
+		Success: []*regattapb.RequestOp{
+			{Request: &regattapb.RequestOp_RequestPut{RequestPut: &regattapb.RequestOp_Put{Key: key, Value: value, Lease: lease}}},
+			get,
+		},
+		Failure: []*regattapb.RequestOp{get},
+	})
+	if err != nil {
+		return nil, nil, false, storageError(err)
+	}
+	kvs := r.Responses[len(r.Responses)-1].GetResponseRange().GetKvs()
+	if len(kvs) == 0 {
+		return nil, nil, false, status.Error(codes.Aborted, "key deleted before it could be read")
+	}
+	return kvs[0], r.Header, r.Succeeded, nil
+}
+
+// deleteCandidate deletes the candidate key unless it was recreated since, the key is deleted even if the context is done.
+func deleteCandidate(ctx context.Context, storage KVService, table []byte, kv *regattapb.KeyValue) {
+	_, _ = storage.Txn(context.WithoutCancel(ctx), &regattapb.TxnRequest{
+		Table: table,
+		Compare: []*regattapb.Compare{{
+			Key:         kv.Key,
+			Target:      regattapb.Compare_CREATE,
+			Result:      regattapb.Compare_EQUAL,
+			TargetUnion: &regattapb.Compare_CreateRevision{CreateRevision: kv.CreateRevision},
+		}},
+		Success: []*regattapb.RequestOp{
+			{Request: &regattapb.RequestOp_RequestDeleteRange{RequestDeleteRange: &regattapb.RequestOp_DeleteRange{Key: kv.Key}}},
+		},
+	})
+}
+
+// waitPredecessors waits until all the keys of the lock or the election of the name created before the revision are deleted.
+// The deletion of the most recent predecessor is awaited by watching its key in the log of the table, the predecessors
+// are checked again afterwards since the older ones could still exist.
+func waitPredecessors(ctx context.Context, storage KVService, tables TableService, logReader LogReaderService, table, name []byte, revision int64) error {
+	if revision <= 1 {
+		return nil
+	}
+	prefix := candidatePrefix(name)
+	for {
+		r, err := storage.Range(ctx, &regattapb.RangeRequest{
+			Table:             table,
+			Key:               prefix,
+			RangeEnd:          prefixEnd(prefix),
+			MaxCreateRevision: revision - 1,
+			KeysOnly:          true,
+			Linearizable:      true,
+		})
+		if err != nil {
+			return storageError(err)
+		}
+		if len(r.Kvs) == 0 {
+			return nil
+		}
+		last := r.Kvs[0]
+		for _, kv := range r.Kvs[1:] {
+			if kv.CreateRevision > last.CreateRevision {
+				last = kv
+			}
+		}
+		if err := waitDeleted(ctx, tables, logReader, table, last.Key, r.Header.GetRevision()+1); err != nil {
+			return err
+		}
+	}
+}
+
+// waitDeleted waits until the key is deleted at or after the revision.
+func waitDeleted(ctx context.Context, tables TableService, logReader LogReaderService, table, key []byte, revision uint64) error {
+	t, err := tables.GetTable(string(table))
+	if err != nil {
+		return storageError(err)
+	}
+	w := &watcher{
+		table:     t,
+		logReader: logReader,
+		rng:       watchRange{key: key},
+		send: func(res *regattapb.WatchResponse) error {
+			for _, ev := range res.Events {
+				if ev.Type == regattapb.Event_DELETE {
+					return errKeyDeleted
+				}
+			}
+			return nil
+		},
+	}
+	switch err := w.run(ctx, revision); {
+	case errors.Is(err, errKeyDeleted):
+		return nil
+	case err != nil:
+		return err
+	}
+	return status.FromContextError(ctx.Err()).Err()
+}
+
+// storageError converts the error returned by the storage into the status error.
+func storageError(err error) error {
+	switch {
+	case errors.Is(err, serrors.ErrTableNotFound):
+		return status.Error(codes.NotFound, "table not found")
+	case errors.Is(err, serrors.ErrLeaseNotFound):
+		return status.Error(codes.NotFound, "lease not found")
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	}
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,376 @@
+// Copyright JAMF Software, LLC
+
+package regattaserver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jamf/regatta/regattapb"
+	"github.com/jamf/regatta/storage/table"
+	"github.com/jamf/regatta/storage/table/fsm"
+	"github.com/lni/dragonboat/v4"
+	"github.com/lni/dragonboat/v4/client"
+	"github.com/lni/dragonboat/v4/raftpb"
+	sm "github.com/lni/dragonboat/v4/statemachine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// memoryStorage is a KVService keeping the keys in memory, Txn supports only the CREATE compare target.
+// The writes are recorded in the log served by the LogReaderService and the table read by the watcher,
+// the keys of every revision are kept to serve the reads of the past revisions.
+type memoryStorage struct {
+	MockStorage
+	mu       sync.Mutex
+	revision int64
+	kvs      map[string]*regattapb.KeyValue
+	states   map[int64]map[string]*regattapb.KeyValue
+	log      []raftpb.Entry
+	results  map[uint64]fsm.UpdateResult
+	// readErr and logErr fail the reads of the table and of the log.
+	readErr error
+	logErr  error
+}
+
+func newMemoryStorage() *memoryStorage {
+	return &memoryStorage{
+		kvs:     make(map[string]*regattapb.KeyValue),
+		states:  make(map[int64]map[string]*regattapb.KeyValue),
+		results: make(map[uint64]fsm.UpdateResult),
+	}
+}
+
+// memoryTables is a TableService of the tables read from the memoryStorage.
+type memoryTables struct {
+	MockTableService
+	storage *memoryStorage
+}
+
+func (t memoryTables) GetTable(name string) (table.ActiveTable, error) {
+	return table.Table{Name: name, ClusterID: 1}.AsActive(t.storage), nil
+}
+
+func (s *memoryStorage) appendLocked(cmd *regattapb.Command) {
+	data, err := cmd.MarshalVT()
+	if err != nil {
+		panic(err)
+	}
+	s.log = append(s.log, raftpb.Entry{Index: uint64(s.revision), Type: raftpb.EncodedEntry, Cmd: append([]byte{0}, data...)})
+}
+
+// snapshotLocked keeps the keys of the current revision.
+func (s *memoryStorage) snapshotLocked() {
+	state := make(map[string]*regattapb.KeyValue, len(s.kvs))
+	for k, kv := range s.kvs {
+		state[k] = kv
+	}
+	s.states[s.revision] = state
+}
+
+// stateLocked returns the keys of the revision, 0 is the current revision.
+func (s *memoryStorage) stateLocked(revision int64) map[string]*regattapb.KeyValue {
+	if revision == 0 || revision == s.revision {
+		return s.kvs
+	}
+	return s.states[revision]
+}
+
+func (s *memoryStorage) QueryRaftLog(_ context.Context, _ uint64, logRange dragonboat.LogRange, _ uint64) ([]raftpb.Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.logErr != nil {
+		return nil, s.logErr
+	}
+	var entries []raftpb.Entry
+	for _, e := range s.log {
+		if e.Index >= logRange.FirstIndex && e.Index < logRange.LastIndex {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+func (s *memoryStorage) StaleRead(_ uint64, req interface{}) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.readErr != nil {
+		return nil, s.readErr
+	}
+	switch r := req.(type) {
+	case fsm.LocalIndexRequest:
+		return &fsm.IndexResponse{Index: uint64(s.revision)}, nil
+	case fsm.TxnResultRequest:
+		res, ok := s.results[r.Index]
+		return &fsm.TxnResultResponse{Found: ok, Result: res}, nil
+	case fsm.RangeRequest:
+		rng := s.rangeLocked(s.stateLocked(r.Range.Revision), r.Range.Key, r.Range.RangeEnd, r.Range.MaxCreateRevision, r.Range.Limit)
+		return &fsm.RangeResponse{Range: &regattapb.ResponseOp_Range{Kvs: rng.Kvs, Count: rng.Count}, Revision: uint64(s.revision)}, nil
+	}
+	return nil, fmt.Errorf("unsupported read %T", req)
+}
+
+func (s *memoryStorage) SyncRead(_ context.Context, id uint64, req interface{}) (interface{}, error) {
+	return s.StaleRead(id, req)
+}
+
+func (s *memoryStorage) SyncPropose(context.Context, *client.Session, []byte) (sm.Result, error) {
+	return sm.Result{}, errors.New("propose not supported")
+}
+
+func (s *memoryStorage) GetNoOPSession(uint64) *client.Session {
+	return nil
+}
+
+func (s *memoryStorage) GetLeaderID(uint64) (uint64, uint64, bool, error) {
+	return 0, 0, false, nil
+}
+
+func (s *memoryStorage) Range(_ context.Context, req *regattapb.RangeRequest) (*regattapb.RangeResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rangeLocked(s.stateLocked(req.Revision), req.Key, req.RangeEnd, req.MaxCreateRevision, req.Limit), nil
+}
+
+func (s *memoryStorage) rangeLocked(kvs map[string]*regattapb.KeyValue, key, rangeEnd []byte, maxCreateRevision, limit int64) *regattapb.RangeResponse {
+	res := &regattapb.RangeResponse{Header: &regattapb.ResponseHeader{Revision: uint64(s.revision)}}
+	for _, kv := range kvs {
+		switch {
+		case len(rangeEnd) == 0 && !bytes.Equal(kv.Key, key):
+			continue
+		case len(rangeEnd) > 0 && bytes.Compare(kv.Key, key) < 0:
+			continue
+		case len(rangeEnd) > 0 && !bytes.Equal(rangeEnd, []byte{0}) && bytes.Compare(kv.Key, rangeEnd) >= 0:
+			continue
+		}
+		if maxCreateRevision > 0 && kv.CreateRevision > maxCreateRevision {
+			continue
+		}
+		res.Kvs = append(res.Kvs, kv)
+	}
+	sort.Slice(res.Kvs, func(i, j int) bool { return bytes.Compare(res.Kvs[i].Key, res.Kvs[j].Key) < 0 })
+	if limit > 0 && int64(len(res.Kvs)) > limit {
+		res.Kvs = res.Kvs[:limit]
+	}
+	res.Count = int64(len(res.Kvs))
+	return res
+}
+
+func (s *memoryStorage) Delete(_ context.Context, req *regattapb.DeleteRangeRequest) (*regattapb.DeleteRangeResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.revision++
+	delete(s.kvs, string(req.Key))
+	s.snapshotLocked()
+	s.appendLocked(&regattapb.Command{Type: regattapb.Command_DELETE, Table: req.Table, Kv: &regattapb.KeyValue{Key: req.Key}})
+	return &regattapb.DeleteRangeResponse{Header: &regattapb.ResponseHeader{Revision: uint64(s.revision)}}, nil
+}
+
+func (s *memoryStorage) Txn(_ context.Context, req *regattapb.TxnRequest) (*regattapb.TxnResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	succeeded := true
+	for _, cmp := range req.Compare {
+		var rev int64
+		if kv, ok := s.kvs[string(cmp.Key)]; ok {
+			rev = kv.CreateRevision
+		}
+		succeeded = succeeded && rev == cmp.GetCreateRevision()
+	}
+	ops := req.Failure
+	if succeeded {
+		ops = req.Success
+	}
+	s.revision++
+	s.appendLocked(&regattapb.Command{Type: regattapb.Command_TXN, Table: req.Table, Txn: &regattapb.Txn{Compare: req.Compare, Success: req.Success, Failure: req.Failure}})
+	s.results[uint64(s.revision)] = fsm.ResultFailure
+	if succeeded {
+		s.results[uint64(s.revision)] = fsm.ResultSuccess
+	}
+	res := &regattapb.TxnResponse{Succeeded: succeeded, Header: &regattapb.ResponseHeader{Revision: uint64(s.revision)}}
+	for _, op := range ops {
+		switch o := op.Request.(type) {
+		case *regattapb.RequestOp_RequestRange:
+			rng := s.rangeLocked(s.kvs, o.RequestRange.Key, o.RequestRange.RangeEnd, 0, 0)
+			res.Responses = append(res.Responses, &regattapb.ResponseOp{Response: &regattapb.ResponseOp_ResponseRange{ResponseRange: &regattapb.ResponseOp_Range{Kvs: rng.Kvs}}})
+		case *regattapb.RequestOp_RequestPut:
+			kv := &regattapb.KeyValue{Key: o.RequestPut.Key, Value: o.RequestPut.Value, Lease: o.RequestPut.Lease, CreateRevision: s.revision, ModRevision: s.revision}
+			if prev, ok := s.kvs[string(kv.Key)]; ok {
+				kv.CreateRevision = prev.CreateRevision
+			}
+			s.kvs[string(kv.Key)] = kv
+			res.Responses = append(res.Responses, &regattapb.ResponseOp{Response: &regattapb.ResponseOp_ResponsePut{ResponsePut: &regattapb.ResponseOp_Put{}}})
+		case *regattapb.RequestOp_RequestDeleteRange:
+			delete(s.kvs, string(o.RequestDeleteRange.Key))
+			res.Responses = append(res.Responses, &regattapb.ResponseOp{Response: &regattapb.ResponseOp_ResponseDeleteRange{ResponseDeleteRange: &regattapb.ResponseOp_DeleteRange{}}})
+		}
+	}
+	s.snapshotLocked()
+	return res, nil
+}
+
+func newLockServer(storage *memoryStorage) *LockServer {
+	return &LockServer{Storage: storage, Tables: memoryTables{storage: storage}, LogReader: storage}
+}
+
+func TestLockServer_LockInvalidArgument(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *regattapb.LockRequest
+		wantErr error
+	}{
+		{
+			name:    "missing table",
+			req:     &regattapb.LockRequest{Name: []byte("lock"), Lease: 1},
+			wantErr: status.Error(codes.InvalidArgument, "table must be set"),
+		},
+		{
+			name:    "missing name",
+			req:     &regattapb.LockRequest{Table: table1Name, Lease: 1},
+			wantErr: status.Error(codes.InvalidArgument, "name must be set"),
+		},
+		{
+			name:    "missing lease",
+			req:     &regattapb.LockRequest{Table: table1Name, Name: []byte("lock")},
+			wantErr: status.Error(codes.InvalidArgument, "lease must be set"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ls := newLockServer(newMemoryStorage())
+			_, err := ls.Lock(context.Background(), tt.req)
+			require.EqualError(t, err, tt.wantErr.Error())
+		})
+	}
+}
+
+func TestLockServer_Lock(t *testing.T) {
+	r := require.New(t)
+	storage := newMemoryStorage()
+	ls := newLockServer(storage)
+
+	first, err := ls.Lock(context.Background(), &regattapb.LockRequest{Table: table1Name, Name: []byte("lock"), Lease: 10})
+	r.NoError(err)
+	r.Equal([]byte("lock/a"), first.Key)
+	r.Equal(int64(1), first.Revision)
+
+	t.Log("lock again with the same lease")
+	again, err := ls.Lock(context.Background(), &regattapb.LockRequest{Table: table1Name, Name: []byte("lock"), Lease: 10})
+	r.NoError(err)
+	r.Equal(first.Key, again.Key)
+	r.Equal(first.Revision, again.Revision)
+
+	t.Log("lock is released when the waiting is cancelled")
+	ctx, cancel := context.WithTimeout(context.Background(), 3*watchPollInterval)
+	defer cancel()
+	_, err = ls.Lock(ctx, &regattapb.LockRequest{Table: table1Name, Name: []byte("lock"), Lease: 11})
+	r.EqualError(err, status.Error(codes.DeadlineExceeded, context.DeadlineExceeded.Error()).Error())
+	rng, err := storage.Range(context.Background(), &regattapb.RangeRequest{Key: []byte("lock/b")})
+	r.NoError(err)
+	r.Empty(rng.Kvs)
+
+	t.Log("existing key is kept when the waiting is cancelled")
+	_, err = storage.Txn(context.Background(), &regattapb.TxnRequest{Success: []*regattapb.RequestOp{
+		{Request: &regattapb.RequestOp_RequestPut{RequestPut: &regattapb.RequestOp_Put{Key: []byte("lock/b"), Lease: 11}}},
+	}})
+	r.NoError(err)
+	ctx, cancel = context.WithTimeout(context.Background(), 3*watchPollInterval)
+	defer cancel()
+	_, err = ls.Lock(ctx, &regattapb.LockRequest{Table: table1Name, Name: []byte("lock"), Lease: 11})
+	r.EqualError(err, status.Error(codes.DeadlineExceeded, context.DeadlineExceeded.Error()).Error())
+	rng, err = storage.Range(context.Background(), &regattapb.RangeRequest{Key: []byte("lock/b")})
+	r.NoError(err)
+	r.Len(rng.Kvs, 1)
+	_, err = ls.Unlock(context.Background(), &regattapb.UnlockRequest{Table: table1Name, Key: []byte("lock/b")})
+	r.NoError(err)
+
+	t.Log("lock is acquired once the holder unlocks it")
+	acquired := make(chan *regattapb.LockResponse)
+	go func() {
+		res, err := ls.Lock(context.Background(), &regattapb.LockRequest{Table: table1Name, Name: []byte("lock"), Lease: 12})
+		assert.NoError(t, err)
+		acquired <- res
+	}()
+	select {
+	case <-acquired:
+		r.Fail("lock acquired while held")
+	case <-time.After(3 * watchPollInterval):
+	}
+	_, err = ls.Unlock(context.Background(), &regattapb.UnlockRequest{Table: table1Name, Key: first.Key})
+	r.NoError(err)
+	select {
+	case res := <-acquired:
+		r.Equal([]byte("lock/c"), res.Key)
+		r.Greater(res.Revision, first.Revision)
+	case <-time.After(5 * time.Second):
+		r.Fail("lock not acquired")
+	}
+}
+
+func Test_waitPredecessors(t *testing.T) {
+	r := require.New(t)
+	storage := newMemoryStorage()
+	put := func(key string) {
+		_, err := storage.Txn(context.Background(), &regattapb.TxnRequest{Table: table1Name, Success: []*regattapb.RequestOp{
+			{Request: &regattapb.RequestOp_RequestPut{RequestPut: &regattapb.RequestOp_Put{Key: []byte(key)}}},
+		}})
+		r.NoError(err)
+	}
+	put("lock/a")
+	put("lock/b")
+	put("lock/c")
+
+	done := make(chan error)
+	go func() {
+		done <- waitPredecessors(context.Background(), storage, memoryTables{storage: storage}, storage, table1Name, []byte("lock"), 3)
+	}()
+
+	t.Log("older predecessor is still awaited")
+	_, err := storage.Delete(context.Background(), &regattapb.DeleteRangeRequest{Table: table1Name, Key: []byte("lock/b")})
+	r.NoError(err)
+	select {
+	case <-done:
+		r.Fail("predecessor lock/a still exists")
+	case <-time.After(3 * watchPollInterval):
+	}
+
+	t.Log("waiting ends once all the predecessors are deleted")
+	_, err = storage.Delete(context.Background(), &regattapb.DeleteRangeRequest{Table: table1Name, Key: []byte("lock/a")})
+	r.NoError(err)
+	select {
+	case err := <-done:
+		r.NoError(err)
+	case <-time.After(5 * time.Second):
+		r.Fail("predecessors not awaited")
+	}
+
+	t.Log("waiting fails when the context is done")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = waitPredecessors(ctx, storage, memoryTables{storage: storage}, storage, table1Name, []byte("lock"), 4)
+	r.EqualError(err, status.Error(codes.Canceled, context.Canceled.Error()).Error())
+}
+
+func TestLockServer_Unlock(t *testing.T) {
+	r := require.New(t)
+	ls := newLockServer(newMemoryStorage())
+	_, err := ls.Unlock(context.Background(), &regattapb.UnlockRequest{Key: []byte("lock/a")})
+	r.EqualError(err, status.Error(codes.InvalidArgument, "table must be set").Error())
+	_, err = ls.Unlock(context.Background(), &regattapb.UnlockRequest{Table: table1Name})
+	r.EqualError(err, status.Error(codes.InvalidArgument, "key must be set").Error())
+}
+
+func Test_prefixEnd(t *testing.T) {
+	r := require.New(t)
+	r.Equal([]byte("lock0"), prefixEnd([]byte("lock/")))
+	r.Equal([]byte("b"), prefixEnd([]byte("a\xff")))
+	r.Equal([]byte{0}, prefixEnd([]byte{0xff}))
+}
@@ -9,10 +9,13 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"time"
 
 	"github.com/jamf/regatta/regattapb"
 	"github.com/jamf/regatta/replication/snapshot"
+	serrors "github.com/jamf/regatta/storage/errors"
+	"github.com/jamf/regatta/storage/table"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -64,6 +67,7 @@ func (m *ResetServer) Reset(ctx context.Context, req *regattapb.ResetRequest) (*
 type BackupServer struct {
 	regattapb.UnimplementedMaintenanceServer
 	Tables TableService
+	Auth   AuthService
 }
 
 func (m *BackupServer) Backup(req *regattapb.BackupRequest, srv regattapb.Maintenance_BackupServer) error {
@@ -105,6 +109,240 @@ func (m *BackupServer) Backup(req *regattapb.BackupRequest, srv regattapb.Mainte
 	return err
 }
 
+// Compact implements proto/maintenance.proto Maintenance.Compact method.
+func (m *BackupServer) Compact(ctx context.Context, req *regattapb.CompactRequest) (*regattapb.CompactResponse, error) {
+	if len(req.GetTable()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "table must be set")
+	}
+	if req.GetRevision() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "revision must be a positive number")
+	}
+
+	t, err := m.Tables.GetTable(string(req.GetTable()))
+	if err != nil {
+		if errors.Is(err, serrors.ErrTableNotFound) {
+			return nil, status.Error(codes.NotFound, "table not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		dctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+		ctx = dctx
+	}
+
+	if err := t.Compact(ctx, uint64(req.GetRevision())); err != nil {
+		if errors.Is(err, serrors.ErrCompacted) || errors.Is(err, serrors.ErrFutureRevision) {
+			return nil, status.Error(codes.OutOfRange, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &regattapb.CompactResponse{}, nil
+}
+
+// CreateTable implements proto/maintenance.proto Maintenance.CreateTable method.
+func (m *BackupServer) CreateTable(_ context.Context, req *regattapb.CreateTableRequest) (*regattapb.CreateTableResponse, error) {
+	if len(req.GetName()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "name must be set")
+	}
+
+	if err := m.Tables.CreateTableWithOptions(string(req.GetName()), table.OptionsFromProto(req.GetOptions())); err != nil {
+		if errors.Is(err, serrors.ErrTableExists) {
+			return nil, status.Error(codes.AlreadyExists, "table already exists")
+		}
+		if errors.Is(err, serrors.ErrInvalidTableOptions) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &regattapb.CreateTableResponse{}, nil
+}
+
+// UpdateTable implements proto/maintenance.proto Maintenance.UpdateTable method.
+func (m *BackupServer) UpdateTable(_ context.Context, req *regattapb.UpdateTableRequest) (*regattapb.UpdateTableResponse, error) {
+	if len(req.GetName()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "name must be set")
+	}
+
+	if err := m.Tables.UpdateTableOptions(string(req.GetName()), table.OptionsFromProto(req.GetOptions())); err != nil {
+		if errors.Is(err, serrors.ErrTableNotFound) {
+			return nil, status.Error(codes.NotFound, "table not found")
+		}
+		if errors.Is(err, serrors.ErrInvalidTableOptions) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &regattapb.UpdateTableResponse{}, nil
+}
+
+// DeleteTable implements proto/maintenance.proto Maintenance.DeleteTable method.
+func (m *BackupServer) DeleteTable(_ context.Context, req *regattapb.DeleteTableRequest) (*regattapb.DeleteTableResponse, error) {
+	if len(req.GetName()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "name must be set")
+	}
+
+	if err := m.Tables.DeleteTable(string(req.GetName())); err != nil {
+		if errors.Is(err, serrors.ErrTableNotFound) {
+			return nil, status.Error(codes.NotFound, "table not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &regattapb.DeleteTableResponse{}, nil
+}
+
+// ListTables implements proto/maintenance.proto Maintenance.ListTables method.
+func (m *BackupServer) ListTables(ctx context.Context, _ *regattapb.ListTablesRequest) (*regattapb.ListTablesResponse, error) {
+	tables, err := m.Tables.GetTables()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	sort.Slice(tables, func(i, j int) bool {
+		return tables[i].Name < tables[j].Name
+	})
+
+	resp := &regattapb.ListTablesResponse{Tables: make([]*regattapb.TableInfo, 0, len(tables))}
+	for _, t := range tables {
+		info := &regattapb.TableInfo{Name: []byte(t.Name), ClusterId: t.ClusterID, Options: table.OptionsToProto(t.Options)}
+		at, err := m.Tables.GetTable(t.Name)
+		if err != nil {
+			if errors.Is(err, serrors.ErrTableNotFound) {
+				// The table was deleted concurrently.
+				continue
+			}
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		// The table may not be started on this node yet, the size and leader are left empty in such case.
+		if size, err := at.Size(ctx); err == nil {
+			info.Size = size.Size
+		}
+		if leader, ok, err := at.Leader(); err == nil && ok {
+			info.Leader = leader
+		}
+		resp.Tables = append(resp.Tables, info)
+	}
+	return resp, nil
+}
+
+// PutUser implements proto/maintenance.proto Maintenance.PutUser method.
+func (m *BackupServer) PutUser(_ context.Context, req *regattapb.PutUserRequest) (*regattapb.PutUserResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name must be set")
+	}
+	user := table.User{Name: req.GetName(), Roles: req.GetRoles()}
+	if req.GetToken() != "" {
+		user.TokenHash = table.HashToken(req.GetToken())
+	}
+	if err := m.Auth.PutUser(user); err != nil {
+		if errors.Is(err, serrors.ErrInvalidAuth) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &regattapb.PutUserResponse{}, nil
+}
+
+// DeleteUser implements proto/maintenance.proto Maintenance.DeleteUser method.
+func (m *BackupServer) DeleteUser(_ context.Context, req *regattapb.DeleteUserRequest) (*regattapb.DeleteUserResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name must be set")
+	}
+	if err := m.Auth.DeleteUser(req.GetName()); err != nil {
+		if errors.Is(err, serrors.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &regattapb.DeleteUserResponse{}, nil
+}
+
+// ListUsers implements proto/maintenance.proto Maintenance.ListUsers method.
+func (m *BackupServer) ListUsers(_ context.Context, _ *regattapb.ListUsersRequest) (*regattapb.ListUsersResponse, error) {
+	users, err := m.Auth.GetUsers()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].Name < users[j].Name
+	})
+	resp := &regattapb.ListUsersResponse{Users: make([]*regattapb.UserInfo, 0, len(users))}
+	for _, u := range users {
+		resp.Users = append(resp.Users, &regattapb.UserInfo{Name: u.Name, Roles: u.Roles, HasToken: u.TokenHash != ""})
+	}
+	return resp, nil
+}
+
+// PutRole implements proto/maintenance.proto Maintenance.PutRole method.
+func (m *BackupServer) PutRole(_ context.Context, req *regattapb.PutRoleRequest) (*regattapb.PutRoleResponse, error) {
+	if req.GetRole().GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "role name must be set")
+	}
+	if err := m.Auth.PutRole(roleFromProto(req.GetRole())); err != nil {
+		if errors.Is(err, serrors.ErrInvalidAuth) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &regattapb.PutRoleResponse{}, nil
+}
+
+// DeleteRole implements proto/maintenance.proto Maintenance.DeleteRole method.
+func (m *BackupServer) DeleteRole(_ context.Context, req *regattapb.DeleteRoleRequest) (*regattapb.DeleteRoleResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name must be set")
+	}
+	if err := m.Auth.DeleteRole(req.GetName()); err != nil {
+		if errors.Is(err, serrors.ErrRoleNotFound) {
+			return nil, status.Error(codes.NotFound, "role not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &regattapb.DeleteRoleResponse{}, nil
+}
+
+// ListRoles implements proto/maintenance.proto Maintenance.ListRoles method.
+func (m *BackupServer) ListRoles(_ context.Context, _ *regattapb.ListRolesRequest) (*regattapb.ListRolesResponse, error) {
+	roles, err := m.Auth.GetRoles()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	sort.Slice(roles, func(i, j int) bool {
+		return roles[i].Name < roles[j].Name
+	})
+	resp := &regattapb.ListRolesResponse{Roles: make([]*regattapb.Role, 0, len(roles))}
+	for _, r := range roles {
+		resp.Roles = append(resp.Roles, roleToProto(r))
+	}
+	return resp, nil
+}
+
+func roleFromProto(role *regattapb.Role) table.Role {
+	res := table.Role{Name: role.GetName()}
+	for _, p := range role.GetPermissions() {
+		res.Permissions = append(res.Permissions, table.Permission{
+			Table:     string(p.GetTable()),
+			KeyPrefix: p.GetKeyPrefix(),
+			Read:      p.GetRead(),
+			Write:     p.GetWrite(),
+		})
+	}
+	return res
+}
+
+func roleToProto(role table.Role) *regattapb.Role {
+	res := &regattapb.Role{Name: role.Name}
+	for _, p := range role.Permissions {
+		res.Permissions = append(res.Permissions, &regattapb.Permission{
+			Table:     []byte(p.Table),
+			KeyPrefix: p.KeyPrefix,
+			Read:      p.Read,
+			Write:     p.Write,
+		})
+	}
+	return res
+}
+
 func (m *BackupServer) Restore(srv regattapb.Maintenance_RestoreServer) error {
 	msg, err := srv.Recv()
 	if err != nil {
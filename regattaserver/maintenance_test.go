@@ -1,3 +1,114 @@
 // Copyright JAMF Software, LLC
 
 package regattaserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jamf/regatta/regattapb"
+	serrors "github.com/jamf/regatta/storage/errors"
+	"github.com/jamf/regatta/storage/table"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBackupServer_CreateTable(t *testing.T) {
+	r := require.New(t)
+	bs := &BackupServer{Tables: MockTableService{}}
+	_, err := bs.CreateTable(context.Background(), &regattapb.CreateTableRequest{})
+	r.EqualError(err, status.Error(codes.InvalidArgument, "name must be set").Error())
+
+	_, err = bs.CreateTable(context.Background(), &regattapb.CreateTableRequest{Name: table1Name})
+	r.NoError(err)
+
+	bs = &BackupServer{Tables: MockTableService{error: serrors.ErrTableExists}}
+	_, err = bs.CreateTable(context.Background(), &regattapb.CreateTableRequest{Name: table1Name})
+	r.EqualError(err, status.Error(codes.AlreadyExists, "table already exists").Error())
+}
+
+func TestBackupServer_DeleteTable(t *testing.T) {
+	r := require.New(t)
+	bs := &BackupServer{Tables: MockTableService{}}
+	_, err := bs.DeleteTable(context.Background(), &regattapb.DeleteTableRequest{})
+	r.EqualError(err, status.Error(codes.InvalidArgument, "name must be set").Error())
+
+	_, err = bs.DeleteTable(context.Background(), &regattapb.DeleteTableRequest{Name: table1Name})
+	r.NoError(err)
+
+	bs = &BackupServer{Tables: MockTableService{error: serrors.ErrTableNotFound}}
+	_, err = bs.DeleteTable(context.Background(), &regattapb.DeleteTableRequest{Name: table1Name})
+	r.EqualError(err, status.Error(codes.NotFound, "table not found").Error())
+}
+
+func TestBackupServer_ListTables(t *testing.T) {
+	r := require.New(t)
+	bs := &BackupServer{Tables: MockTableService{tables: []table.Table{}}}
+	res, err := bs.ListTables(context.Background(), &regattapb.ListTablesRequest{})
+	r.NoError(err)
+	r.Empty(res.Tables)
+
+	bs = &BackupServer{Tables: MockTableService{error: serrors.ErrStateMachineClosed}}
+	_, err = bs.ListTables(context.Background(), &regattapb.ListTablesRequest{})
+	r.EqualError(err, status.Error(codes.Internal, serrors.ErrStateMachineClosed.Error()).Error())
+}
+
+func TestBackupServer_UpdateTable(t *testing.T) {
+	r := require.New(t)
+	bs := &BackupServer{Tables: MockTableService{}}
+	_, err := bs.UpdateTable(context.Background(), &regattapb.UpdateTableRequest{})
+	r.EqualError(err, status.Error(codes.InvalidArgument, "name must be set").Error())
+
+	_, err = bs.UpdateTable(context.Background(), &regattapb.UpdateTableRequest{Name: table1Name, Options: &regattapb.TableOptions{MaxValueSize: 1024}})
+	r.NoError(err)
+
+	bs = &BackupServer{Tables: MockTableService{error: serrors.ErrTableNotFound}}
+	_, err = bs.UpdateTable(context.Background(), &regattapb.UpdateTableRequest{Name: table1Name})
+	r.EqualError(err, status.Error(codes.NotFound, "table not found").Error())
+
+	bs = &BackupServer{Tables: MockTableService{error: serrors.ErrInvalidTableOptions}}
+	_, err = bs.UpdateTable(context.Background(), &regattapb.UpdateTableRequest{Name: table1Name})
+	r.EqualError(err, status.Error(codes.InvalidArgument, "invalid table options").Error())
+}
+
+func TestBackupServer_PutUser(t *testing.T) {
+	r := require.New(t)
+	auth := &MockAuthService{}
+	bs := &BackupServer{Auth: auth}
+	_, err := bs.PutUser(context.Background(), &regattapb.PutUserRequest{})
+	r.EqualError(err, status.Error(codes.InvalidArgument, "name must be set").Error())
+
+	_, err = bs.PutUser(context.Background(), &regattapb.PutUserRequest{Name: "user", Token: "secret", Roles: []string{"role"}})
+	r.NoError(err)
+	r.Equal([]table.User{{Name: "user", TokenHash: table.HashToken("secret"), Roles: []string{"role"}}}, auth.users)
+
+	res, err := bs.ListUsers(context.Background(), &regattapb.ListUsersRequest{})
+	r.NoError(err)
+	r.Equal([]*regattapb.UserInfo{{Name: "user", Roles: []string{"role"}, HasToken: true}}, res.Users)
+
+	bs = &BackupServer{Auth: &MockAuthService{error: serrors.ErrUserNotFound}}
+	_, err = bs.DeleteUser(context.Background(), &regattapb.DeleteUserRequest{Name: "user"})
+	r.EqualError(err, status.Error(codes.NotFound, "user not found").Error())
+}
+
+func TestBackupServer_PutRole(t *testing.T) {
+	r := require.New(t)
+	auth := &MockAuthService{}
+	bs := &BackupServer{Auth: auth}
+	_, err := bs.PutRole(context.Background(), &regattapb.PutRoleRequest{})
+	r.EqualError(err, status.Error(codes.InvalidArgument, "role name must be set").Error())
+
+	role := &regattapb.Role{Name: "role", Permissions: []*regattapb.Permission{{Table: table1Name, KeyPrefix: []byte("prefix/"), Read: true}}}
+	_, err = bs.PutRole(context.Background(), &regattapb.PutRoleRequest{Role: role})
+	r.NoError(err)
+	r.Equal([]table.Role{{Name: "role", Permissions: []table.Permission{{Table: string(table1Name), KeyPrefix: []byte("prefix/"), Read: true}}}}, auth.roles)
+
+	res, err := bs.ListRoles(context.Background(), &regattapb.ListRolesRequest{})
+	r.NoError(err)
+	r.Equal([]*regattapb.Role{role}, res.Roles)
+
+	bs = &BackupServer{Auth: &MockAuthService{error: serrors.ErrRoleNotFound}}
+	_, err = bs.DeleteRole(context.Background(), &regattapb.DeleteRoleRequest{Name: "role"})
+	r.EqualError(err, status.Error(codes.NotFound, "role not found").Error())
+}
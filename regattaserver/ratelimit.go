@@ -0,0 +1,248 @@
+// Copyright JAMF Software, LLC
+
+package regattaserver
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jamf/regatta/regattapb"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// limiterIdleTimeout how long are the limiters of the clients kept since their last request.
+const limiterIdleTimeout = 10 * time.Minute
+
+// unknownTable is the table the requests to the tables that do not exist are limited and counted by.
+const unknownTable = ""
+
+// RateLimitConfig configures the quotas of every client per table, zero rate disables the quota.
+type RateLimitConfig struct {
+	// Requests is the number of requests per second.
+	Requests float64
+	// RequestsBurst is the number of requests allowed to exceed the rate at once.
+	RequestsBurst int
+	// WriteBytes is the size of the write requests in bytes per second.
+	WriteBytes float64
+	// WriteBytesBurst is the size of the write requests in bytes allowed to exceed the rate at once,
+	// larger requests are counted as the burst.
+	WriteBytesBurst int
+}
+
+// withDefaults returns the config with the bursts defaulted to the rates.
+func (c RateLimitConfig) withDefaults() RateLimitConfig {
+	if c.RequestsBurst < 1 {
+		c.RequestsBurst = max(1, int(c.Requests))
+	}
+	if c.WriteBytesBurst < 1 {
+		c.WriteBytesBurst = max(1, int(c.WriteBytes))
+	}
+	return c
+}
+
+// RateLimiter limits the request rate and the size of the write requests of the API clients per table. Clients are told apart
+// by the user authenticated by the Authorizer or by the peer address. Requests over the quota fail with the ResourceExhausted status.
+// The requests to the tables that do not exist share a single quota of the client so that the arbitrary table names
+// in the requests do not grow the limiters and the metric labels.
+type RateLimiter struct {
+	tables    TableService
+	cfg       RateLimitConfig
+	overrides map[string]RateLimitConfig
+	now       func() time.Time
+	throttled *prometheus.CounterVec
+
+	mu        sync.Mutex
+	limiters  map[limiterKey]*clientLimiter
+	lastSweep time.Time
+}
+
+type limiterKey struct {
+	client string
+	table  string
+}
+
+type clientLimiter struct {
+	requests   *rate.Limiter
+	writeBytes *rate.Limiter
+	lastUsed   time.Time
+}
+
+// NewRateLimiter returns the RateLimiter applying the quotas of cfg to every table of the tables service except for the tables
+// the quotas are overridden for, the bursts default to the rates if not set.
+func NewRateLimiter(tables TableService, cfg RateLimitConfig, overrides map[string]RateLimitConfig) *RateLimiter {
+	o := make(map[string]RateLimitConfig, len(overrides))
+	for name, tc := range overrides {
+		o[name] = tc.withDefaults()
+	}
+	return &RateLimiter{
+		tables:    tables,
+		cfg:       cfg.withDefaults(),
+		overrides: o,
+		now:       time.Now,
+		throttled: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "regatta_api_throttled_requests_total",
+				Help: "Regatta API requests rejected by the rate limits",
+			}, []string{"table", "limit"},
+		),
+		limiters: make(map[limiterKey]*clientLimiter),
+	}
+}
+
+func (l *RateLimiter) Describe(descs chan<- *prometheus.Desc) {
+	l.throttled.Describe(descs)
+}
+
+func (l *RateLimiter) Collect(metrics chan<- prometheus.Metric) {
+	l.throttled.Collect(metrics)
+}
+
+// UnaryServerInterceptor returns the interceptor limiting the unary calls.
+func (l *RateLimiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if r, ok := req.(interface{ GetTable() []byte }); ok {
+			if err := l.allow(ctx, info.FullMethod, r.GetTable(), req, true); err != nil {
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns the interceptor limiting the streaming calls. The stream is counted as a single request
+// by its first message, every received message of the write streams is counted towards the write quota.
+func (l *RateLimiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &limitedStream{ServerStream: ss, limiter: l, method: info.FullMethod})
+	}
+}
+
+type limitedStream struct {
+	grpc.ServerStream
+	limiter  *RateLimiter
+	method   string
+	table    []byte
+	received bool
+}
+
+func (s *limitedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	// The follow-up messages of the client streams do not carry the table.
+	if r, ok := m.(interface{ GetTable() []byte }); ok && len(r.GetTable()) > 0 {
+		s.table = r.GetTable()
+	}
+	first := !s.received
+	s.received = true
+	return s.limiter.allow(s.Context(), s.method, s.table, m, first)
+}
+
+// allow checks the quotas of the request to the table, the request rate is checked only if countRequest is set.
+func (l *RateLimiter) allow(ctx context.Context, method string, table []byte, req interface{}, countRequest bool) error {
+	if !strings.HasPrefix(method, apiServicesPrefix) {
+		return nil
+	}
+	name := string(table)
+	if _, err := l.tables.GetTable(name); err != nil {
+		name = unknownTable
+	}
+	cfg := l.config(name)
+	if cfg.Requests <= 0 && cfg.WriteBytes <= 0 {
+		return nil
+	}
+	lim := l.limiter(limiterKey{client: clientID(ctx), table: name}, cfg)
+	now := l.now()
+	if countRequest && lim.requests != nil && !lim.requests.AllowN(now, 1) {
+		l.throttled.WithLabelValues(name, "requests").Inc()
+		return status.Errorf(codes.ResourceExhausted, "request rate limit of table %q exceeded", table)
+	}
+	if lim.writeBytes == nil || !isWrite(method, req) {
+		return nil
+	}
+	size := 0
+	if m, ok := req.(interface{ SizeVT() int }); ok {
+		size = m.SizeVT()
+	}
+	if burst := lim.writeBytes.Burst(); size > burst {
+		size = burst
+	}
+	if !lim.writeBytes.AllowN(now, size) {
+		l.throttled.WithLabelValues(name, "write_bytes").Inc()
+		return status.Errorf(codes.ResourceExhausted, "write bytes limit of table %q exceeded", table)
+	}
+	return nil
+}
+
+// limiter returns the limiter of the client and the table created by cfg if missing, the limiters idle for limiterIdleTimeout are dropped.
+func (l *RateLimiter) limiter(key limiterKey, cfg RateLimitConfig) *clientLimiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if now.Sub(l.lastSweep) > limiterIdleTimeout {
+		for k, lim := range l.limiters {
+			if now.Sub(lim.lastUsed) > limiterIdleTimeout {
+				delete(l.limiters, k)
+			}
+		}
+		l.lastSweep = now
+	}
+	lim, ok := l.limiters[key]
+	if !ok {
+		lim = &clientLimiter{}
+		if cfg.Requests > 0 {
+			lim.requests = rate.NewLimiter(rate.Limit(cfg.Requests), cfg.RequestsBurst)
+		}
+		if cfg.WriteBytes > 0 {
+			lim.writeBytes = rate.NewLimiter(rate.Limit(cfg.WriteBytes), cfg.WriteBytesBurst)
+		}
+		l.limiters[key] = lim
+	}
+	lim.lastUsed = now
+	return lim
+}
+
+// config returns the quotas of the table.
+func (l *RateLimiter) config(table string) RateLimitConfig {
+	if cfg, ok := l.overrides[table]; ok {
+		return cfg
+	}
+	return l.cfg
+}
+
+// clientID returns the name of the authenticated user or the peer host the client is identified by.
+func clientID(ctx context.Context) string {
+	if user, ok := UserFromContext(ctx); ok {
+		return "user:" + user
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	addr := p.Addr.String()
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return "host:" + host
+	}
+	return "host:" + addr
+}
+
+// isWrite returns true for the requests writing into the table.
+func isWrite(method string, req interface{}) bool {
+	switch method {
+	case kvServicePrefix + "Put", kvServicePrefix + "DeleteRange", kvServicePrefix + "Increment", kvServicePrefix + "PutLarge":
+		return true
+	case kvServicePrefix + "Txn":
+		txn, ok := req.(*regattapb.TxnRequest)
+		return ok && !isReadonlyTransaction(txn)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright JAMF Software, LLC
+
+package regattaserver
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/jamf/regatta/regattapb"
+	"github.com/jamf/regatta/storage/table"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+var rateLimitTables = MockTableService{tables: []table.Table{{Name: string(table1Name)}, {Name: string(table2Name)}, {Name: "other"}}}
+
+func peerContext(addr string) context.Context {
+	tcp, err := net.ResolveTCPAddr("tcp", addr)
+	if err != nil {
+		panic(err)
+	}
+	return peer.NewContext(context.Background(), &peer.Peer{Addr: tcp})
+}
+
+func TestRateLimiter_UnaryServerInterceptor(t *testing.T) {
+	r := require.New(t)
+	now := time.Now()
+	l := NewRateLimiter(rateLimitTables, RateLimitConfig{Requests: 1, RequestsBurst: 2, WriteBytes: 100, WriteBytesBurst: 100}, nil)
+	l.now = func() time.Time { return now }
+	call := func(ctx context.Context, method string, req interface{}) error {
+		_, err := l.UnaryServerInterceptor()(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		})
+		return err
+	}
+	rangeReq := &regattapb.RangeRequest{Table: table1Name, Key: key1Name}
+
+	t.Log("burst of requests is allowed")
+	client := peerContext("10.0.0.1:1000")
+	r.NoError(call(client, "/regatta.v1.KV/Range", rangeReq))
+	r.NoError(call(peerContext("10.0.0.1:2000"), "/regatta.v1.KV/Range", rangeReq))
+	err := call(client, "/regatta.v1.KV/Range", rangeReq)
+	r.Equal(codes.ResourceExhausted, status.Code(err))
+	r.Equal(float64(1), testutil.ToFloat64(l.throttled.WithLabelValues(string(table1Name), "requests")))
+
+	t.Log("limits are kept per client and table")
+	r.NoError(call(peerContext("10.0.0.2:1000"), "/regatta.v1.KV/Range", rangeReq))
+	r.NoError(call(client, "/regatta.v1.KV/Range", &regattapb.RangeRequest{Table: table2Name, Key: key1Name}))
+	r.NoError(call(context.WithValue(client, userKey{}, "user"), "/regatta.v1.KV/Range", rangeReq))
+
+	t.Log("requests are allowed once the rate allows")
+	now = now.Add(time.Second)
+	r.NoError(call(client, "/regatta.v1.KV/Range", rangeReq))
+
+	t.Log("write bytes are limited")
+	writer := peerContext("10.0.0.3:1000")
+	put := &regattapb.PutRequest{Table: table1Name, Key: key1Name, Value: make([]byte, 60)}
+	r.NoError(call(writer, "/regatta.v1.KV/Put", put))
+	err = call(writer, "/regatta.v1.KV/Put", put)
+	r.Equal(codes.ResourceExhausted, status.Code(err))
+	r.Equal(float64(1), testutil.ToFloat64(l.throttled.WithLabelValues(string(table1Name), "write_bytes")))
+
+	t.Log("read-only txn is not counted as write")
+	txn := &regattapb.TxnRequest{Table: table1Name, Success: []*regattapb.RequestOp{
+		{Request: &regattapb.RequestOp_RequestRange{RequestRange: &regattapb.RequestOp_Range{Key: make([]byte, 200)}}},
+	}}
+	r.NoError(call(peerContext("10.0.0.4:1000"), "/regatta.v1.KV/Txn", txn))
+
+	t.Log("other services are not limited")
+	for i := 0; i < 5; i++ {
+		r.NoError(call(client, "/grpc.health.v1.Health/Check", nil))
+	}
+}
+
+func TestRateLimiter_TableOverrides(t *testing.T) {
+	r := require.New(t)
+	now := time.Now()
+	l := NewRateLimiter(rateLimitTables, RateLimitConfig{Requests: 1}, map[string]RateLimitConfig{
+		string(table1Name): {Requests: 3, WriteBytes: 50},
+		string(table2Name): {},
+	})
+	l.now = func() time.Time { return now }
+	call := func(ctx context.Context, method string, req interface{}) error {
+		_, err := l.UnaryServerInterceptor()(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		})
+		return err
+	}
+	client := peerContext("10.0.0.1:1000")
+
+	t.Log("table quota overrides the global one")
+	for i := 0; i < 3; i++ {
+		r.NoError(call(client, "/regatta.v1.KV/Range", &regattapb.RangeRequest{Table: table1Name, Key: key1Name}))
+	}
+	err := call(client, "/regatta.v1.KV/Range", &regattapb.RangeRequest{Table: table1Name, Key: key1Name})
+	r.Equal(codes.ResourceExhausted, status.Code(err))
+
+	t.Log("table write quota is applied")
+	writer := peerContext("10.0.0.2:1000")
+	r.NoError(call(writer, "/regatta.v1.KV/Put", &regattapb.PutRequest{Table: table1Name, Key: key1Name, Value: make([]byte, 40)}))
+	err = call(writer, "/regatta.v1.KV/Put", &regattapb.PutRequest{Table: table1Name, Key: key1Name, Value: make([]byte, 40)})
+	r.Equal(codes.ResourceExhausted, status.Code(err))
+
+	t.Log("table without quotas is not limited")
+	for i := 0; i < 5; i++ {
+		r.NoError(call(client, "/regatta.v1.KV/Range", &regattapb.RangeRequest{Table: table2Name, Key: key1Name}))
+	}
+	r.NotContains(l.limiters, limiterKey{client: "host:10.0.0.1", table: string(table2Name)})
+
+	t.Log("other tables use the global quota")
+	r.NoError(call(client, "/regatta.v1.KV/Range", &regattapb.RangeRequest{Table: []byte("other"), Key: key1Name}))
+	err = call(client, "/regatta.v1.KV/Range", &regattapb.RangeRequest{Table: []byte("other"), Key: key1Name})
+	r.Equal(codes.ResourceExhausted, status.Code(err))
+}
+
+func TestRateLimiter_UnknownTables(t *testing.T) {
+	r := require.New(t)
+	now := time.Now()
+	l := NewRateLimiter(rateLimitTables, RateLimitConfig{Requests: 2}, nil)
+	l.now = func() time.Time { return now }
+	call := func(ctx context.Context, table string) error {
+		_, err := l.UnaryServerInterceptor()(ctx, &regattapb.RangeRequest{Table: []byte(table)}, &grpc.UnaryServerInfo{FullMethod: "/regatta.v1.KV/Range"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		})
+		return err
+	}
+	client := peerContext("10.0.0.1:1000")
+
+	t.Log("unknown tables share a single quota")
+	r.NoError(call(client, "missing-1"))
+	r.NoError(call(client, "missing-2"))
+	err := call(client, "missing-3")
+	r.Equal(codes.ResourceExhausted, status.Code(err))
+	r.Equal(float64(1), testutil.ToFloat64(l.throttled.WithLabelValues(unknownTable, "requests")))
+	r.Len(l.limiters, 1)
+	r.Contains(l.limiters, limiterKey{client: "host:10.0.0.1", table: unknownTable})
+
+	t.Log("existing tables are not affected")
+	r.NoError(call(client, string(table1Name)))
+	r.Len(l.limiters, 2)
+}
+
+func TestRateLimiter_StreamServerInterceptor(t *testing.T) {
+	r := require.New(t)
+	now := time.Now()
+	l := NewRateLimiter(rateLimitTables, RateLimitConfig{Requests: 1, WriteBytes: 50}, nil)
+	l.now = func() time.Time { return now }
+	info := &grpc.StreamServerInfo{FullMethod: "/regatta.v1.KV/PutLarge", IsClientStream: true}
+	recvAll := func(_ interface{}, ss grpc.ServerStream) error {
+		for i := 0; i < 3; i++ {
+			if err := ss.RecvMsg(&regattapb.PutLargeRequest{}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	msgs := func() []proto.Message {
+		return []proto.Message{&regattapb.PutLargeRequest{Table: table1Name, Key: key1Name}, &regattapb.PutLargeRequest{}, &regattapb.PutLargeRequest{}}
+	}
+
+	t.Log("stream is counted as a single request")
+	err := l.StreamServerInterceptor()(nil, &testServerStream{ctx: peerContext("10.0.0.1:1000"), msgs: msgs()}, info, recvAll)
+	r.NoError(err)
+	err = l.StreamServerInterceptor()(nil, &testServerStream{ctx: peerContext("10.0.0.1:1000"), msgs: msgs()}, info, recvAll)
+	r.Equal(codes.ResourceExhausted, status.Code(err))
+}
+
+func TestRateLimiter_limiter(t *testing.T) {
+	r := require.New(t)
+	now := time.Now()
+	l := NewRateLimiter(rateLimitTables, RateLimitConfig{Requests: 10}, map[string]RateLimitConfig{"o": {Requests: 5, WriteBytes: 100, WriteBytesBurst: 200}})
+	l.now = func() time.Time { return now }
+	r.Equal(10, l.cfg.RequestsBurst)
+	r.Equal(RateLimitConfig{Requests: 5, RequestsBurst: 5, WriteBytes: 100, WriteBytesBurst: 200}, l.config("o"))
+	r.Equal(l.cfg, l.config("t"))
+
+	l.limiter(limiterKey{client: "a", table: "t"}, l.cfg)
+	now = now.Add(limiterIdleTimeout / 2)
+	l.limiter(limiterKey{client: "b", table: "t"}, l.cfg)
+	r.Len(l.limiters, 2)
+
+	t.Log("idle limiters are dropped")
+	now = now.Add(limiterIdleTimeout/2 + time.Second)
+	l.limiter(limiterKey{client: "b", table: "t"}, l.cfg)
+	r.Len(l.limiters, 1)
+	r.Contains(l.limiters, limiterKey{client: "b", table: "t"})
+}
@@ -15,8 +15,24 @@ import (
 type KVService interface {
 	Range(ctx context.Context, req *regattapb.RangeRequest) (*regattapb.RangeResponse, error)
 	Put(ctx context.Context, req *regattapb.PutRequest) (*regattapb.PutResponse, error)
+	Increment(ctx context.Context, req *regattapb.IncrementRequest) (*regattapb.IncrementResponse, error)
 	Delete(ctx context.Context, req *regattapb.DeleteRangeRequest) (*regattapb.DeleteRangeResponse, error)
 	Txn(ctx context.Context, req *regattapb.TxnRequest) (*regattapb.TxnResponse, error)
+	Cursor(ctx context.Context, req *regattapb.RangeRequest, send func(*regattapb.RangeResponse) error) error
+	PutLarge(ctx context.Context, req *regattapb.PutLargeRequest, value io.Reader) (*regattapb.PutLargeResponse, error)
+	GetLarge(ctx context.Context, req *regattapb.GetLargeRequest, send func(*regattapb.GetLargeResponse) error) error
+}
+
+// LeaderIndexService reads how far the local replicas of the tables have replicated the log of the leader cluster.
+type LeaderIndexService interface {
+	LeaderIndex(ctx context.Context, table []byte) (uint64, error)
+}
+
+type LeaseService interface {
+	LeaseGrant(ctx context.Context, req *regattapb.LeaseGrantRequest) (*regattapb.LeaseGrantResponse, error)
+	LeaseRevoke(ctx context.Context, req *regattapb.LeaseRevokeRequest) (*regattapb.LeaseRevokeResponse, error)
+	LeaseKeepAlive(ctx context.Context, req *regattapb.LeaseKeepAliveRequest) (*regattapb.LeaseKeepAliveResponse, error)
+	LeaseTimeToLive(ctx context.Context, req *regattapb.LeaseTimeToLiveRequest) (*regattapb.LeaseTimeToLiveResponse, error)
 }
 
 type SnapshotService interface {
@@ -27,6 +43,19 @@ type TableService interface {
 	GetTables() ([]table.Table, error)
 	GetTable(name string) (table.ActiveTable, error)
 	Restore(name string, reader io.Reader) error
+	CreateTableWithOptions(name string, opts table.Options) error
+	UpdateTableOptions(name string, opts table.Options) error
+	DeleteTable(name string) error
+}
+
+// AuthService manages the users and roles of the KV API.
+type AuthService interface {
+	GetUsers() ([]table.User, error)
+	PutUser(user table.User) error
+	DeleteUser(name string) error
+	GetRoles() ([]table.Role, error)
+	PutRole(role table.Role) error
+	DeleteRole(name string) error
 }
 
 type LogReaderService interface {
@@ -7,6 +7,7 @@ import (
 	"io"
 
 	"github.com/jamf/regatta/regattapb"
+	serrors "github.com/jamf/regatta/storage/errors"
 	"github.com/jamf/regatta/storage/table"
 )
 
@@ -16,9 +17,15 @@ type MockStorage struct {
 	putResponse         regattapb.PutResponse
 	deleteRangeResponse regattapb.DeleteRangeResponse
 	txnResponse         regattapb.TxnResponse
+	incrementResponse   regattapb.IncrementResponse
+	cursorResponses     []*regattapb.RangeResponse
+	putLargeResponse    regattapb.PutLargeResponse
+	putLargeValue       []byte
+	getLargeResponses   []*regattapb.GetLargeResponse
 	rangeError          error
 	putError            error
 	deleteError         error
+	incrementError      error
 }
 
 func (s *MockStorage) Range(_ context.Context, _ *regattapb.RangeRequest) (*regattapb.RangeResponse, error) {
@@ -29,10 +36,41 @@ func (s *MockStorage) Put(_ context.Context, _ *regattapb.PutRequest) (*regattap
 	return &s.putResponse, s.putError
 }
 
+func (s *MockStorage) Increment(_ context.Context, _ *regattapb.IncrementRequest) (*regattapb.IncrementResponse, error) {
+	return &s.incrementResponse, s.incrementError
+}
+
 func (s *MockStorage) Delete(_ context.Context, _ *regattapb.DeleteRangeRequest) (*regattapb.DeleteRangeResponse, error) {
 	return &s.deleteRangeResponse, s.deleteError
 }
 
+func (s *MockStorage) Cursor(_ context.Context, _ *regattapb.RangeRequest, send func(*regattapb.RangeResponse) error) error {
+	for _, r := range s.cursorResponses {
+		if err := send(r); err != nil {
+			return err
+		}
+	}
+	return s.rangeError
+}
+
+func (s *MockStorage) PutLarge(_ context.Context, _ *regattapb.PutLargeRequest, value io.Reader) (*regattapb.PutLargeResponse, error) {
+	v, err := io.ReadAll(value)
+	if err != nil {
+		return nil, err
+	}
+	s.putLargeValue = v
+	return &s.putLargeResponse, s.putError
+}
+
+func (s *MockStorage) GetLarge(_ context.Context, _ *regattapb.GetLargeRequest, send func(*regattapb.GetLargeResponse) error) error {
+	for _, r := range s.getLargeResponses {
+		if err := send(r); err != nil {
+			return err
+		}
+	}
+	return s.rangeError
+}
+
 func (s *MockStorage) Txn(_ context.Context, _ *regattapb.TxnRequest) (*regattapb.TxnResponse, error) {
 	return &s.txnResponse, s.deleteError
 }
@@ -47,9 +85,61 @@ func (t MockTableService) GetTables() ([]table.Table, error) {
 }
 
 func (t MockTableService) GetTable(name string) (table.ActiveTable, error) {
-	return t.tables[0].AsActive(nil), t.error
+	if t.error != nil {
+		return table.ActiveTable{}, t.error
+	}
+	for _, tab := range t.tables {
+		if tab.Name == name {
+			return table.ActiveTable{Table: tab}, nil
+		}
+	}
+	return table.ActiveTable{}, serrors.ErrTableNotFound
 }
 
 func (t MockTableService) Restore(name string, reader io.Reader) error {
 	return t.error
 }
+
+func (t MockTableService) CreateTableWithOptions(name string, opts table.Options) error {
+	return t.error
+}
+
+func (t MockTableService) UpdateTableOptions(name string, opts table.Options) error {
+	return t.error
+}
+
+func (t MockTableService) DeleteTable(name string) error {
+	return t.error
+}
+
+type MockAuthService struct {
+	users []table.User
+	roles []table.Role
+	error error
+}
+
+func (a *MockAuthService) GetUsers() ([]table.User, error) {
+	return a.users, a.error
+}
+
+func (a *MockAuthService) PutUser(user table.User) error {
+	a.users = append(a.users, user)
+	return a.error
+}
+
+func (a *MockAuthService) DeleteUser(name string) error {
+	return a.error
+}
+
+func (a *MockAuthService) GetRoles() ([]table.Role, error) {
+	return a.roles, a.error
+}
+
+func (a *MockAuthService) PutRole(role table.Role) error {
+	a.roles = append(a.roles, role)
+	return a.error
+}
+
+func (a *MockAuthService) DeleteRole(name string) error {
+	return a.error
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/jamf/regatta/regattapb"
 	"github.com/jamf/regatta/replication/snapshot"
 	serrors "github.com/jamf/regatta/storage/errors"
+	"github.com/jamf/regatta/storage/table"
 	"github.com/lni/dragonboat/v4"
 	"github.com/lni/dragonboat/v4/raftpb"
 	"go.uber.org/zap"
@@ -29,6 +30,8 @@ const (
 type MetadataServer struct {
 	regattapb.UnimplementedMetadataServer
 	Tables TableService
+	// Auth the users and roles are replicated as well if set.
+	Auth AuthService
 }
 
 func (m *MetadataServer) Get(context.Context, *regattapb.MetadataRequest) (*regattapb.MetadataResponse, error) {
@@ -39,10 +42,29 @@ func (m *MetadataServer) Get(context.Context, *regattapb.MetadataRequest) (*rega
 	resp := &regattapb.MetadataResponse{}
 	for _, tab := range tabs {
 		resp.Tables = append(resp.Tables, &regattapb.Table{
-			Type: regattapb.Table_REPLICATED,
-			Name: tab.Name,
+			Type:    regattapb.Table_REPLICATED,
+			Name:    tab.Name,
+			Options: table.OptionsToProto(tab.Options),
 		})
 	}
+	if m.Auth == nil {
+		return resp, nil
+	}
+	users, err := m.Auth.GetUsers()
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "unknown err %v", err)
+	}
+	resp.Auth = &regattapb.Auth{}
+	for _, u := range users {
+		resp.Auth.Users = append(resp.Auth.Users, &regattapb.User{Name: u.Name, TokenHash: u.TokenHash, Roles: u.Roles})
+	}
+	roles, err := m.Auth.GetRoles()
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "unknown err %v", err)
+	}
+	for _, r := range roles {
+		resp.Auth.Roles = append(resp.Auth.Roles, roleToProto(r))
+	}
 	return resp, nil
 }
 
@@ -231,5 +253,7 @@ func entryToCommand(e raftpb.Entry) (*regattapb.Command, error) {
 		return nil, err
 	}
 	cmd.LeaderIndex = &e.Index
+	// The trace of the proposing request ends in the leader cluster.
+	cmd.Traceparent = ""
 	return cmd, nil
 }
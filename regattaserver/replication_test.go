@@ -17,6 +17,7 @@ import (
 func TestMetadataServer_Get(t *testing.T) {
 	type fields struct {
 		TableManager TableService
+		Auth         AuthService
 	}
 	tests := []struct {
 		name    string
@@ -46,8 +47,9 @@ func TestMetadataServer_Get(t *testing.T) {
 			},
 			want: &regattapb.MetadataResponse{Tables: []*regattapb.Table{
 				{
-					Name: "foo",
-					Type: regattapb.Table_REPLICATED,
+					Name:    "foo",
+					Type:    regattapb.Table_REPLICATED,
+					Options: &regattapb.TableOptions{},
 				},
 			}},
 		},
@@ -60,22 +62,47 @@ func TestMetadataServer_Get(t *testing.T) {
 							Name: "foo",
 						},
 						{
-							Name: "bar",
+							Name:    "bar",
+							Options: table.Options{MaxValueSize: 1024, Compression: table.CompressionZstd},
 						},
 					},
 				},
 			},
 			want: &regattapb.MetadataResponse{Tables: []*regattapb.Table{
 				{
-					Name: "foo",
-					Type: regattapb.Table_REPLICATED,
+					Name:    "foo",
+					Type:    regattapb.Table_REPLICATED,
+					Options: &regattapb.TableOptions{},
 				},
 				{
-					Name: "bar",
-					Type: regattapb.Table_REPLICATED,
+					Name:    "bar",
+					Type:    regattapb.Table_REPLICATED,
+					Options: &regattapb.TableOptions{MaxValueSize: 1024, Compression: regattapb.TableOptions_ZSTD},
 				},
 			}},
 		},
+		{
+			name: "Get metadata - auth",
+			fields: fields{
+				TableManager: MockTableService{},
+				Auth: &MockAuthService{
+					users: []table.User{{Name: "client", TokenHash: "hash", Roles: []string{"reader"}}},
+					roles: []table.Role{{Name: "reader", Permissions: []table.Permission{{Table: "foo", Read: true}}}},
+				},
+			},
+			want: &regattapb.MetadataResponse{Auth: &regattapb.Auth{
+				Users: []*regattapb.User{{Name: "client", TokenHash: "hash", Roles: []string{"reader"}}},
+				Roles: []*regattapb.Role{{Name: "reader", Permissions: []*regattapb.Permission{{Table: []byte("foo"), Read: true}}}},
+			}},
+		},
+		{
+			name: "Get metadata - auth without users",
+			fields: fields{
+				TableManager: MockTableService{},
+				Auth:         &MockAuthService{},
+			},
+			want: &regattapb.MetadataResponse{Auth: &regattapb.Auth{}},
+		},
 		{
 			name: "Get metadata - deadline exceeded",
 			fields: fields{
@@ -91,6 +118,7 @@ func TestMetadataServer_Get(t *testing.T) {
 			r := require.New(t)
 			m := &MetadataServer{
 				Tables: tt.fields.TableManager,
+				Auth:   tt.fields.Auth,
 			}
 			got, err := m.Get(context.TODO(), &regattapb.MetadataRequest{})
 			if tt.wantErr != nil {
@@ -104,6 +132,12 @@ func TestMetadataServer_Get(t *testing.T) {
 
 func TestEntryToCommand(t *testing.T) {
 	zero := uint64(0)
+	traced, err := (&regattapb.Command{
+		Table:       []byte("regatta-test"),
+		Kv:          &regattapb.KeyValue{Key: []byte("key"), Value: []byte("value")},
+		Traceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	}).MarshalVT()
+	require.NoError(t, err)
 	tests := []struct {
 		name    string
 		entry   raftpb.Entry
@@ -132,6 +166,19 @@ func TestEntryToCommand(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "Traced Entry",
+			entry: raftpb.Entry{
+				Type: raftpb.EncodedEntry,
+				Cmd:  append([]byte{0}, traced...),
+			},
+			wantCmd: &regattapb.Command{
+				Kv:          &regattapb.KeyValue{Key: []byte("key"), Value: []byte("value")},
+				Table:       []byte("regatta-test"),
+				LeaderIndex: &zero,
+			},
+			wantErr: nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -148,6 +195,7 @@ func TestEntryToCommand(t *testing.T) {
 			r.Equal(tt.wantCmd.LeaderIndex, gotCmd.LeaderIndex)
 			r.Equal(tt.wantCmd.Table, gotCmd.Table)
 			r.Equal(tt.wantCmd.Type, gotCmd.Type)
+			r.Equal(tt.wantCmd.Traceparent, gotCmd.Traceparent)
 			if tt.wantCmd.Kv != nil {
 				r.Equal(tt.wantCmd.Kv.Value, gotCmd.Kv.Value)
 				r.Equal(tt.wantCmd.Kv.Key, gotCmd.Kv.Key)
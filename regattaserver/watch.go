@@ -0,0 +1,408 @@
+// Copyright JAMF Software, LLC
+
+package regattaserver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/jamf/regatta/regattapb"
+	serrors "github.com/jamf/regatta/storage/errors"
+	"github.com/jamf/regatta/storage/table"
+	"github.com/jamf/regatta/storage/table/fsm"
+	"github.com/lni/dragonboat/v4"
+	"github.com/lni/dragonboat/v4/raftpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// watchPollInterval how often is the table checked for newly applied entries.
+	watchPollInterval = 100 * time.Millisecond
+	// maxWatchResponseSize the target maximum size of a single WatchResponse,
+	// the response is sent as soon as the size is reached, so it could get slightly larger.
+	maxWatchResponseSize = DefaultMaxGRPCSize / 4
+)
+
+// watcher streams the events of the watched range read from the table's raft log.
+type watcher struct {
+	table     table.ActiveTable
+	logReader LogReaderService
+	rng       watchRange
+	send      func(*regattapb.WatchResponse) error
+}
+
+// run streams the events starting from the startRevision (inclusive) until the context is done.
+// If the startRevision is 0 only the events applied after the call are streamed.
+func (w *watcher) run(ctx context.Context, startRevision uint64) error {
+	applied, err := w.appliedIndex(ctx)
+	if err != nil {
+		return err
+	}
+	next := startRevision
+	if next == 0 {
+		next = applied + 1
+	}
+
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+	for {
+		if next <= applied {
+			entries, err := w.logReader.QueryRaftLog(ctx, w.table.ClusterID, dragonboat.LogRange{FirstIndex: next, LastIndex: applied + 1}, DefaultMaxGRPCSize)
+			switch {
+			case errors.Is(err, serrors.ErrLogAhead):
+				return status.Errorf(codes.OutOfRange, "revision %d has been compacted", next)
+			case err != nil:
+				return status.Error(codes.Unavailable, err.Error())
+			}
+			if len(entries) > 0 {
+				if err := w.sendEntries(ctx, entries); err != nil {
+					return err
+				}
+				next = entries[len(entries)-1].Index + 1
+				continue
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		applied, err = w.appliedIndex(ctx)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (w *watcher) appliedIndex(ctx context.Context) (uint64, error) {
+	idx, err := w.table.LocalIndex(ctx, false)
+	if err != nil {
+		return 0, status.Error(codes.Unavailable, err.Error())
+	}
+	return idx.Index, nil
+}
+
+// sendEntries transforms the entries into events and sends them in responses of approximately maxWatchResponseSize.
+func (w *watcher) sendEntries(ctx context.Context, entries []raftpb.Entry) error {
+	resp := &regattapb.WatchResponse{}
+	size := 0
+	for _, e := range entries {
+		cmd, err := entryToCommand(e)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		events, err := w.commandEvents(ctx, nil, cmd, e.Index)
+		if err != nil {
+			return err
+		}
+		for _, ev := range events {
+			size += ev.SizeVT()
+		}
+		resp.Events = append(resp.Events, events...)
+
+		if size >= maxWatchResponseSize {
+			resp.Header = &regattapb.ResponseHeader{ShardId: w.table.ClusterID, Revision: e.Index}
+			if err := w.send(resp); err != nil {
+				return err
+			}
+			resp = &regattapb.WatchResponse{}
+			size = 0
+		}
+	}
+	if len(resp.Events) == 0 {
+		return nil
+	}
+	resp.Header = &regattapb.ResponseHeader{ShardId: w.table.ClusterID, Revision: entries[len(entries)-1].Index}
+	return w.send(resp)
+}
+
+// commandEvents appends the events of the command applied at the revision that match the watched range, the events
+// are the events of the preceding commands of the same sequence.
+func (w *watcher) commandEvents(ctx context.Context, events []*regattapb.Event, cmd *regattapb.Command, revision uint64) ([]*regattapb.Event, error) {
+	if len(cmd.RequestId) > 0 {
+		// The retried command is not applied again so there are no events to emit.
+		result, _, err := w.commandResult(ctx, cmd, revision)
+		if err != nil {
+			return nil, err
+		}
+		if result == fsm.ResultDuplicate {
+			return events, nil
+		}
+	}
+	switch cmd.Type {
+	case regattapb.Command_PUT, regattapb.Command_PUT_LARGE:
+		// The event of the large value does not carry the value, it could be read by the KV.GetLarge method.
+		result, _, err := w.commandResult(ctx, cmd, revision)
+		if err != nil {
+			return nil, err
+		}
+		if result == fsm.ResultSuccess {
+			events = w.appendPut(events, cmd.Kv, revision)
+		}
+	case regattapb.Command_DELETE:
+		var err error
+		events, err = w.appendDelete(ctx, events, cmd.Kv.Key, cmd.RangeEnd, revision)
+		if err != nil {
+			return nil, err
+		}
+	case regattapb.Command_PUT_BATCH:
+		for _, kv := range cmd.Batch {
+			events = w.appendPut(events, kv, revision)
+		}
+	case regattapb.Command_DELETE_BATCH:
+		for _, kv := range cmd.Batch {
+			var err error
+			events, err = w.appendDelete(ctx, events, kv.Key, nil, revision)
+			if err != nil {
+				return nil, err
+			}
+		}
+	case regattapb.Command_TXN:
+		result, nested, err := w.commandResult(ctx, cmd, revision)
+		if err != nil {
+			return nil, err
+		}
+		var ops []*regattapb.RequestOp
+		switch result {
+		case fsm.ResultSuccess:
+			ops = cmd.Txn.Success
+		case fsm.ResultFailure:
+			ops = cmd.Txn.Failure
+		}
+		events, _, err = w.txnEvents(ctx, events, ops, nested, revision)
+		if err != nil {
+			return nil, err
+		}
+	case regattapb.Command_LEASE_REVOKE:
+		result, _, err := w.commandResult(ctx, cmd, revision)
+		if err != nil {
+			return nil, err
+		}
+		if result == fsm.ResultSuccess {
+			for _, kv := range cmd.Batch {
+				events, err = w.appendDelete(ctx, events, kv.Key, nil, revision)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	case regattapb.Command_SEQUENCE:
+		for _, c := range cmd.Sequence {
+			var err error
+			events, err = w.commandEvents(ctx, events, c, revision)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return events, nil
+}
+
+// txnEvents appends the events of the applied transaction operations, the nested are the outcomes of the nested transactions
+// (see fsm.TxnResultResponse) starting with the first nested transaction of the operations. The outcomes which were not
+// consumed by the operations are returned.
+func (w *watcher) txnEvents(ctx context.Context, events []*regattapb.Event, ops []*regattapb.RequestOp, nested []bool, revision uint64) ([]*regattapb.Event, []bool, error) {
+	for _, op := range ops {
+		switch o := op.Request.(type) {
+		case *regattapb.RequestOp_RequestPut:
+			events = w.appendPut(events, &regattapb.KeyValue{Key: o.RequestPut.Key, Value: o.RequestPut.Value, Lease: o.RequestPut.Lease}, revision)
+		case *regattapb.RequestOp_RequestDeleteRange:
+			var err error
+			events, err = w.appendDelete(ctx, events, o.RequestDeleteRange.Key, o.RequestDeleteRange.RangeEnd, revision)
+			if err != nil {
+				return nil, nil, err
+			}
+		case *regattapb.RequestOp_RequestIncrement:
+			if !w.rng.intersects(o.RequestIncrement.Key, nil) {
+				continue
+			}
+			kv, err := w.valueAt(ctx, o.RequestIncrement.Key, revision)
+			if err != nil {
+				return nil, nil, err
+			}
+			events = w.appendPut(events, kv, revision)
+		case *regattapb.RequestOp_RequestTxn:
+			if len(nested) == 0 {
+				return nil, nil, status.Errorf(codes.Internal, "outcome of the nested transaction at revision %d is missing", revision)
+			}
+			branch := o.RequestTxn.Failure
+			if nested[0] {
+				branch = o.RequestTxn.Success
+			}
+			var err error
+			events, nested, err = w.txnEvents(ctx, events, branch, nested[1:], revision)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	return events, nested, nil
+}
+
+// commandResult resolves the result of the command applied at the revision along with the outcomes of its nested transactions,
+// the commands which are not conditional always succeed.
+func (w *watcher) commandResult(ctx context.Context, cmd *regattapb.Command, revision uint64) (fsm.UpdateResult, []bool, error) {
+	if !fsm.IsConditional(cmd) {
+		return fsm.ResultSuccess, nil, nil
+	}
+	res, err := w.table.TxnResult(ctx, revision)
+	if err != nil {
+		return fsm.ResultFailure, nil, status.Error(codes.Unavailable, err.Error())
+	}
+	if !res.Found {
+		return fsm.ResultFailure, nil, status.Errorf(codes.OutOfRange, "revision %d has been compacted", revision)
+	}
+	return res.Result, res.NestedTxns, nil
+}
+
+// valueAt reads the key as it was at the revision, the resulting value of an increment is not stored in the log.
+func (w *watcher) valueAt(ctx context.Context, key []byte, revision uint64) (*regattapb.KeyValue, error) {
+	res, err := w.table.Range(ctx, &regattapb.RangeRequest{Key: key, Revision: int64(revision)})
+	switch {
+	case errors.Is(err, serrors.ErrCompacted):
+		return nil, status.Errorf(codes.OutOfRange, "revision %d has been compacted", revision)
+	case err != nil:
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	if len(res.Kvs) == 0 {
+		return &regattapb.KeyValue{Key: key}, nil
+	}
+	return res.Kvs[0], nil
+}
+
+func (w *watcher) appendPut(events []*regattapb.Event, kv *regattapb.KeyValue, revision uint64) []*regattapb.Event {
+	if !w.rng.intersects(kv.Key, nil) {
+		return events
+	}
+	return append(events, &regattapb.Event{
+		Type: regattapb.Event_PUT,
+		Kv:   &regattapb.KeyValue{Key: kv.Key, Value: kv.Value, ModRevision: int64(revision), Lease: kv.Lease},
+	})
+}
+
+// appendDelete appends a DELETE event for every watched key of the range [key, rangeEnd) which existed before the delete,
+// i.e. the keys which existed at the preceding revision unless deleted by a preceding operation of the same command,
+// and the keys put by the preceding operations of the same command.
+func (w *watcher) appendDelete(ctx context.Context, events []*regattapb.Event, key, rangeEnd []byte, revision uint64) ([]*regattapb.Event, error) {
+	if !w.rng.intersects(key, rangeEnd) {
+		return events, nil
+	}
+	rng := w.rng.intersection(watchRange{key: key, rangeEnd: rangeEnd})
+	// The events of the command so far hold the keys modified by its preceding operations.
+	modified := make(map[string]regattapb.Event_EventType)
+	for _, ev := range events {
+		if ev.Kv.ModRevision == int64(revision) {
+			modified[string(ev.Kv.Key)] = ev.Type
+		}
+	}
+	existed := make(map[string]struct{})
+	keys, err := w.keysAt(ctx, rng, revision-1)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range keys {
+		if _, ok := modified[string(k)]; !ok {
+			existed[string(k)] = struct{}{}
+		}
+	}
+	for k, typ := range modified {
+		if typ == regattapb.Event_PUT && rng.intersects([]byte(k), nil) {
+			existed[k] = struct{}{}
+		}
+	}
+	deleted := make([]string, 0, len(existed))
+	for k := range existed {
+		deleted = append(deleted, k)
+	}
+	sort.Strings(deleted)
+	for _, k := range deleted {
+		events = append(events, &regattapb.Event{
+			Type: regattapb.Event_DELETE,
+			Kv:   &regattapb.KeyValue{Key: []byte(k), ModRevision: int64(revision)},
+		})
+	}
+	return events, nil
+}
+
+// keysAt reads the keys of the range as they were at the revision, there are no keys before the first revision.
+func (w *watcher) keysAt(ctx context.Context, rng watchRange, revision uint64) ([][]byte, error) {
+	if revision == 0 {
+		return nil, nil
+	}
+	var keys [][]byte
+	req := &regattapb.RangeRequest{Key: rng.key, RangeEnd: rng.rangeEnd, KeysOnly: true, Revision: int64(revision)}
+	for {
+		res, err := w.table.Range(ctx, req)
+		switch {
+		case errors.Is(err, serrors.ErrCompacted):
+			return nil, status.Errorf(codes.OutOfRange, "revision %d has been compacted", revision)
+		case err != nil:
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+		for _, kv := range res.Kvs {
+			keys = append(keys, kv.Key)
+		}
+		if !res.More || len(res.Kvs) == 0 {
+			return keys, nil
+		}
+		// The next page starts right after the last key read.
+		req.Key = append(bytes.Clone(res.Kvs[len(res.Kvs)-1].Key), 0)
+	}
+}
+
+// watchRange is the watched range of keys [key, rangeEnd), see RangeRequest for the semantics of the range_end.
+type watchRange struct {
+	key      []byte
+	rangeEnd []byte
+}
+
+// end returns the exclusive end of the range, nil if the range is not bounded.
+func (r watchRange) end() []byte {
+	switch {
+	case len(r.rangeEnd) == 0:
+		return append(bytes.Clone(r.key), 0)
+	case bytes.Equal(r.rangeEnd, []byte{0}):
+		return nil
+	default:
+		return r.rangeEnd
+	}
+}
+
+// intersection returns the range of the keys falling into both the ranges, the ranges must intersect.
+func (r watchRange) intersection(other watchRange) watchRange {
+	key := r.key
+	if bytes.Compare(other.key, key) > 0 {
+		key = other.key
+	}
+	end, otherEnd := r.end(), other.end()
+	if end == nil || (otherEnd != nil && bytes.Compare(otherEnd, end) < 0) {
+		end = otherEnd
+	}
+	if end == nil {
+		end = []byte{0}
+	}
+	return watchRange{key: key, rangeEnd: end}
+}
+
+// intersects reports whether any key of the range [key, rangeEnd) falls into the watched range.
+func (r watchRange) intersects(key, rangeEnd []byte) bool {
+	return startsBeforeEnd(r.key, key, rangeEnd) && startsBeforeEnd(key, r.key, r.rangeEnd)
+}
+
+// startsBeforeEnd reports whether the start key lies before the end of the range [key, rangeEnd).
+func startsBeforeEnd(start, key, rangeEnd []byte) bool {
+	switch {
+	case len(rangeEnd) == 0:
+		return bytes.Compare(start, key) <= 0
+	case bytes.Equal(rangeEnd, []byte{0}):
+		return true
+	default:
+		return bytes.Compare(start, rangeEnd) < 0
+	}
+}
@@ -0,0 +1,416 @@
+// Copyright JAMF Software, LLC
+
+package regattaserver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jamf/regatta/regattapb"
+	serrors "github.com/jamf/regatta/storage/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestWatchRange_Intersects(t *testing.T) {
+	tests := []struct {
+		name     string
+		rng      watchRange
+		key      []byte
+		rangeEnd []byte
+		want     bool
+	}{
+		{
+			name: "single key match",
+			rng:  watchRange{key: []byte("key_1")},
+			key:  []byte("key_1"),
+			want: true,
+		},
+		{
+			name: "single key mismatch",
+			rng:  watchRange{key: []byte("key_1")},
+			key:  []byte("key_2"),
+			want: false,
+		},
+		{
+			name: "key in prefix range",
+			rng:  watchRange{key: []byte("key_"), rangeEnd: []byte("key`")},
+			key:  []byte("key_2"),
+			want: true,
+		},
+		{
+			name: "key equal to range end",
+			rng:  watchRange{key: []byte("key_1"), rangeEnd: []byte("key_3")},
+			key:  []byte("key_3"),
+			want: false,
+		},
+		{
+			name: "key in open range",
+			rng:  watchRange{key: []byte("key_1"), rangeEnd: []byte{0}},
+			key:  []byte("key_9"),
+			want: true,
+		},
+		{
+			name: "key before open range",
+			rng:  watchRange{key: []byte("key_1"), rangeEnd: []byte{0}},
+			key:  []byte("key_0"),
+			want: false,
+		},
+		{
+			name: "all keys",
+			rng:  watchRange{key: []byte{0}, rangeEnd: []byte{0}},
+			key:  []byte("key_0"),
+			want: true,
+		},
+		{
+			name:     "overlapping ranges",
+			rng:      watchRange{key: []byte("key_1"), rangeEnd: []byte("key_3")},
+			key:      []byte("key_2"),
+			rangeEnd: []byte("key_5"),
+			want:     true,
+		},
+		{
+			name:     "disjoint ranges",
+			rng:      watchRange{key: []byte("key_1"), rangeEnd: []byte("key_3")},
+			key:      []byte("key_3"),
+			rangeEnd: []byte("key_5"),
+			want:     false,
+		},
+		{
+			name:     "open range covering watched key",
+			rng:      watchRange{key: []byte("key_5")},
+			key:      []byte("key_1"),
+			rangeEnd: []byte{0},
+			want:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.rng.intersects(tt.key, tt.rangeEnd))
+		})
+	}
+}
+
+func TestWatchRange_Intersection(t *testing.T) {
+	tests := []struct {
+		name  string
+		rng   watchRange
+		other watchRange
+		want  watchRange
+	}{
+		{
+			name:  "single key in range",
+			rng:   watchRange{key: []byte("key_1"), rangeEnd: []byte("key_3")},
+			other: watchRange{key: []byte("key_2")},
+			want:  watchRange{key: []byte("key_2"), rangeEnd: []byte("key_2\x00")},
+		},
+		{
+			name:  "overlapping ranges",
+			rng:   watchRange{key: []byte("key_1"), rangeEnd: []byte("key_3")},
+			other: watchRange{key: []byte("key_2"), rangeEnd: []byte("key_5")},
+			want:  watchRange{key: []byte("key_2"), rangeEnd: []byte("key_3")},
+		},
+		{
+			name:  "open range",
+			rng:   watchRange{key: []byte("key_1"), rangeEnd: []byte("key_3")},
+			other: watchRange{key: []byte("key_0"), rangeEnd: []byte{0}},
+			want:  watchRange{key: []byte("key_1"), rangeEnd: []byte("key_3")},
+		},
+		{
+			name:  "open ranges",
+			rng:   watchRange{key: []byte{0}, rangeEnd: []byte{0}},
+			other: watchRange{key: []byte("key_2"), rangeEnd: []byte{0}},
+			want:  watchRange{key: []byte("key_2"), rangeEnd: []byte{0}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.rng.intersection(tt.other))
+		})
+	}
+}
+
+func TestWatcher_CommandEvents(t *testing.T) {
+	storage := newMemoryStorage()
+	storage.revision = 9
+	for _, key := range [][]byte{key2Name, []byte("key_25"), key3Name} {
+		storage.kvs[string(key)] = &regattapb.KeyValue{Key: key, Value: table1Value1, CreateRevision: 9, ModRevision: 9}
+	}
+	tab, _ := memoryTables{storage: storage}.GetTable(string(table1Name))
+	w := &watcher{table: tab, rng: watchRange{key: []byte("key_1"), rangeEnd: []byte("key_3")}}
+	tests := []struct {
+		name string
+		cmd  *regattapb.Command
+		want []*regattapb.Event
+	}{
+		{
+			name: "put",
+			cmd: &regattapb.Command{
+				Type: regattapb.Command_PUT,
+				Kv:   &regattapb.KeyValue{Key: key1Name, Value: table1Value1},
+			},
+			want: []*regattapb.Event{
+				{Type: regattapb.Event_PUT, Kv: &regattapb.KeyValue{Key: key1Name, Value: table1Value1, ModRevision: 10}},
+			},
+		},
+		{
+			name: "put outside of range",
+			cmd: &regattapb.Command{
+				Type: regattapb.Command_PUT,
+				Kv:   &regattapb.KeyValue{Key: key3Name, Value: table1Value1},
+			},
+		},
+		{
+			name: "delete range",
+			cmd: &regattapb.Command{
+				Type:     regattapb.Command_DELETE,
+				Kv:       &regattapb.KeyValue{Key: key2Name},
+				RangeEnd: []byte{0},
+			},
+			want: []*regattapb.Event{
+				{Type: regattapb.Event_DELETE, Kv: &regattapb.KeyValue{Key: key2Name, ModRevision: 10}},
+				{Type: regattapb.Event_DELETE, Kv: &regattapb.KeyValue{Key: []byte("key_25"), ModRevision: 10}},
+			},
+		},
+		{
+			name: "delete missing key",
+			cmd: &regattapb.Command{
+				Type: regattapb.Command_DELETE,
+				Kv:   &regattapb.KeyValue{Key: key1Name},
+			},
+		},
+		{
+			name: "put batch",
+			cmd: &regattapb.Command{
+				Type: regattapb.Command_PUT_BATCH,
+				Batch: []*regattapb.KeyValue{
+					{Key: key1Name, Value: table1Value1},
+					{Key: key2Name, Value: table1Value2},
+					{Key: key3Name, Value: table1Value2},
+				},
+			},
+			want: []*regattapb.Event{
+				{Type: regattapb.Event_PUT, Kv: &regattapb.KeyValue{Key: key1Name, Value: table1Value1, ModRevision: 10}},
+				{Type: regattapb.Event_PUT, Kv: &regattapb.KeyValue{Key: key2Name, Value: table1Value2, ModRevision: 10}},
+			},
+		},
+		{
+			name: "delete batch",
+			cmd: &regattapb.Command{
+				Type:  regattapb.Command_DELETE_BATCH,
+				Batch: []*regattapb.KeyValue{{Key: key2Name}, {Key: key3Name}},
+			},
+			want: []*regattapb.Event{
+				{Type: regattapb.Event_DELETE, Kv: &regattapb.KeyValue{Key: key2Name, ModRevision: 10}},
+			},
+		},
+		{
+			name: "unconditional txn",
+			cmd: &regattapb.Command{
+				Type: regattapb.Command_TXN,
+				Txn: &regattapb.Txn{
+					Success: []*regattapb.RequestOp{
+						{Request: &regattapb.RequestOp_RequestRange{RequestRange: &regattapb.RequestOp_Range{Key: key1Name}}},
+						{Request: &regattapb.RequestOp_RequestPut{RequestPut: &regattapb.RequestOp_Put{Key: key1Name, Value: table1Value1}}},
+						{Request: &regattapb.RequestOp_RequestDeleteRange{RequestDeleteRange: &regattapb.RequestOp_DeleteRange{Key: key2Name}}},
+					},
+				},
+			},
+			want: []*regattapb.Event{
+				{Type: regattapb.Event_PUT, Kv: &regattapb.KeyValue{Key: key1Name, Value: table1Value1, ModRevision: 10}},
+				{Type: regattapb.Event_DELETE, Kv: &regattapb.KeyValue{Key: key2Name, ModRevision: 10}},
+			},
+		},
+		{
+			name: "txn deleting the put key",
+			cmd: &regattapb.Command{
+				Type: regattapb.Command_TXN,
+				Txn: &regattapb.Txn{
+					Success: []*regattapb.RequestOp{
+						{Request: &regattapb.RequestOp_RequestPut{RequestPut: &regattapb.RequestOp_Put{Key: key1Name, Value: table1Value1}}},
+						{Request: &regattapb.RequestOp_RequestDeleteRange{RequestDeleteRange: &regattapb.RequestOp_DeleteRange{Key: key1Name, RangeEnd: key2Name}}},
+					},
+				},
+			},
+			want: []*regattapb.Event{
+				{Type: regattapb.Event_PUT, Kv: &regattapb.KeyValue{Key: key1Name, Value: table1Value1, ModRevision: 10}},
+				{Type: regattapb.Event_DELETE, Kv: &regattapb.KeyValue{Key: key1Name, ModRevision: 10}},
+			},
+		},
+		{
+			name: "sequence",
+			cmd: &regattapb.Command{
+				Type: regattapb.Command_SEQUENCE,
+				Sequence: []*regattapb.Command{
+					{Type: regattapb.Command_PUT, Kv: &regattapb.KeyValue{Key: key1Name, Value: table1Value1}},
+					{Type: regattapb.Command_DUMMY},
+					{Type: regattapb.Command_DELETE, Kv: &regattapb.KeyValue{Key: key2Name}},
+				},
+			},
+			want: []*regattapb.Event{
+				{Type: regattapb.Event_PUT, Kv: &regattapb.KeyValue{Key: key1Name, Value: table1Value1, ModRevision: 10}},
+				{Type: regattapb.Event_DELETE, Kv: &regattapb.KeyValue{Key: key2Name, ModRevision: 10}},
+			},
+		},
+		{
+			name: "sequence deleting the key twice",
+			cmd: &regattapb.Command{
+				Type: regattapb.Command_SEQUENCE,
+				Sequence: []*regattapb.Command{
+					{Type: regattapb.Command_DELETE, Kv: &regattapb.KeyValue{Key: key2Name}},
+					{Type: regattapb.Command_DELETE, Kv: &regattapb.KeyValue{Key: key2Name}},
+				},
+			},
+			want: []*regattapb.Event{
+				{Type: regattapb.Event_DELETE, Kv: &regattapb.KeyValue{Key: key2Name, ModRevision: 10}},
+			},
+		},
+		{
+			name: "dummy",
+			cmd:  &regattapb.Command{Type: regattapb.Command_DUMMY},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := w.commandEvents(context.Background(), nil, tt.cmd, 10)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWatcher_TxnEvents(t *testing.T) {
+	r := require.New(t)
+	w := &watcher{rng: watchRange{key: []byte("key_1"), rangeEnd: []byte("key_3")}}
+	put := func(key []byte) *regattapb.RequestOp {
+		return &regattapb.RequestOp{Request: &regattapb.RequestOp_RequestPut{RequestPut: &regattapb.RequestOp_Put{Key: key, Value: table1Value1}}}
+	}
+	nested := func(success, failure []*regattapb.RequestOp) *regattapb.RequestOp {
+		return &regattapb.RequestOp{Request: &regattapb.RequestOp_RequestTxn{RequestTxn: &regattapb.Txn{Success: success, Failure: failure}}}
+	}
+	ops := []*regattapb.RequestOp{
+		nested([]*regattapb.RequestOp{nested([]*regattapb.RequestOp{put(key1Name)}, nil)}, []*regattapb.RequestOp{put(key3Name)}),
+		nested(nil, []*regattapb.RequestOp{put(key2Name)}),
+	}
+
+	events, rest, err := w.txnEvents(context.Background(), nil, ops, []bool{true, true, false, true}, 10)
+	r.NoError(err)
+	r.Equal([]bool{true}, rest)
+	r.Equal([]*regattapb.Event{
+		{Type: regattapb.Event_PUT, Kv: &regattapb.KeyValue{Key: key1Name, Value: table1Value1, ModRevision: 10}},
+		{Type: regattapb.Event_PUT, Kv: &regattapb.KeyValue{Key: key2Name, Value: table1Value1, ModRevision: 10}},
+	}, events)
+
+	_, _, err = w.txnEvents(context.Background(), nil, ops, []bool{true}, 10)
+	r.Equal(codes.Internal, status.Code(err))
+}
+
+func TestWatcher_Run(t *testing.T) {
+	r := require.New(t)
+	storage := newMemoryStorage()
+	put := func(key string) {
+		_, err := storage.Txn(context.Background(), &regattapb.TxnRequest{Table: table1Name, Success: []*regattapb.RequestOp{
+			{Request: &regattapb.RequestOp_RequestPut{RequestPut: &regattapb.RequestOp_Put{Key: []byte(key), Value: []byte("value")}}},
+		}})
+		r.NoError(err)
+	}
+	start := func(ctx context.Context, revision uint64) (<-chan *regattapb.Event, <-chan error) {
+		events := make(chan *regattapb.Event, 100)
+		done := make(chan error, 1)
+		tab, _ := memoryTables{storage: storage}.GetTable(string(table1Name))
+		w := &watcher{
+			table:     tab,
+			logReader: storage,
+			rng:       watchRange{key: []byte("key_"), rangeEnd: []byte("key`")},
+			send: func(res *regattapb.WatchResponse) error {
+				for _, ev := range res.Events {
+					events <- ev
+				}
+				return nil
+			},
+		}
+		go func() { done <- w.run(ctx, revision) }()
+		return events, done
+	}
+	next := func(events <-chan *regattapb.Event) *regattapb.Event {
+		select {
+		case ev := <-events:
+			return ev
+		case <-time.After(5 * time.Second):
+			r.FailNow("event not received")
+			return nil
+		}
+	}
+	put("key_1")
+	put("other")
+	put("key_2")
+
+	t.Log("events are streamed from the past revision")
+	ctx, cancel := context.WithCancel(context.Background())
+	events, done := start(ctx, 2)
+	ev := next(events)
+	r.Equal([]byte("key_2"), ev.Kv.Key)
+	r.Equal(int64(3), ev.Kv.ModRevision)
+
+	t.Log("live events are streamed once applied")
+	_, err := storage.Delete(context.Background(), &regattapb.DeleteRangeRequest{Table: table1Name, Key: []byte("key_1")})
+	r.NoError(err)
+	ev = next(events)
+	r.Equal(regattapb.Event_DELETE, ev.Type)
+	r.Equal([]byte("key_1"), ev.Kv.Key)
+	r.Equal(int64(4), ev.Kv.ModRevision)
+
+	t.Log("deletion of a missing key is not streamed")
+	_, err = storage.Delete(context.Background(), &regattapb.DeleteRangeRequest{Table: table1Name, Key: []byte("key_1")})
+	r.NoError(err)
+	put("key_4")
+	ev = next(events)
+	r.Equal(regattapb.Event_PUT, ev.Type)
+	r.Equal([]byte("key_4"), ev.Kv.Key)
+	r.Equal(int64(6), ev.Kv.ModRevision)
+
+	t.Log("watch ends when the context is cancelled")
+	cancel()
+	r.NoError(<-done)
+	r.Empty(events)
+
+	t.Log("only the new events are streamed from the zero revision")
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	events, done = start(ctx, 0)
+	time.Sleep(2 * watchPollInterval)
+	put("key_3")
+	ev = next(events)
+	r.Equal([]byte("key_3"), ev.Kv.Key)
+	r.Equal(int64(7), ev.Kv.ModRevision)
+
+	t.Log("failed read of the applied index ends the watch")
+	storage.mu.Lock()
+	storage.readErr = errors.New("read failed")
+	storage.mu.Unlock()
+	err = <-done
+	r.Equal(codes.Unavailable, status.Code(err))
+	storage.mu.Lock()
+	storage.readErr = nil
+	storage.mu.Unlock()
+
+	t.Log("compacted revision is out of range")
+	storage.mu.Lock()
+	storage.logErr = serrors.ErrLogAhead
+	storage.mu.Unlock()
+	_, done = start(context.Background(), 1)
+	err = <-done
+	r.Equal(codes.OutOfRange, status.Code(err))
+	r.EqualError(err, status.Error(codes.OutOfRange, "revision 1 has been compacted").Error())
+
+	t.Log("failed read of the log ends the watch")
+	storage.mu.Lock()
+	storage.logErr = errors.New("query failed")
+	storage.mu.Unlock()
+	_, done = start(context.Background(), 1)
+	err = <-done
+	r.Equal(codes.Unavailable, status.Code(err))
+}
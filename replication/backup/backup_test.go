@@ -113,13 +113,13 @@ func TestBackup_Backup(t *testing.T) {
 						Name:     "regatta-test",
 						Type:     "REPLICATED",
 						FileName: "regatta-test.bak",
-						MD5:      "5cc50dc8f85f6ab733c9cff534a398dd",
+						MD5:      "59631897677e9d0eb2cf45ef88c59178",
 					},
 					{
 						Name:     "regatta-test2",
 						Type:     "REPLICATED",
 						FileName: "regatta-test2.bak",
-						MD5:      "df74e3ebc3b31f884245cf0efb3c9b6e",
+						MD5:      "fba24c43f53d2c99dc4169283e36d0b6",
 					},
 				},
 			},
@@ -5,6 +5,7 @@ package replication
 import (
 	"context"
 	"errors"
+	"reflect"
 	"sync"
 	"time"
 
@@ -143,12 +144,55 @@ func (m *Manager) reconcileTables() error {
 	if err != nil {
 		return err
 	}
+	tbs, err := m.tm.GetTables()
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]table.Options, len(tbs))
+	for _, tbl := range tbs {
+		existing[tbl.Name] = tbl.Options
+	}
 	for _, tabs := range response.GetTables() {
-		if err := m.tm.CreateTable(tabs.Name); err != nil && !errors.Is(err, serrors.ErrTableExists) {
-			return err
+		opts := table.OptionsFromProto(tabs.GetOptions())
+		current, ok := existing[tabs.Name]
+		switch {
+		case !ok:
+			if err := m.tm.CreateTableWithOptions(tabs.Name, opts); err != nil && !errors.Is(err, serrors.ErrTableExists) {
+				return err
+			}
+		case !reflect.DeepEqual(current, opts):
+			if err := m.tm.UpdateTableOptions(tabs.Name, opts); err != nil {
+				return err
+			}
 		}
 	}
-	return nil
+	if response.Auth == nil {
+		// The leader does not replicate the users and roles, the follower keeps its own.
+		return nil
+	}
+	return m.tm.SetAuth(authFromMetadata(response.Auth))
+}
+
+// authFromMetadata returns the users and roles of the leader cluster the follower cluster mirrors.
+func authFromMetadata(auth *regattapb.Auth) ([]table.User, []table.Role) {
+	users := make([]table.User, 0, len(auth.GetUsers()))
+	for _, u := range auth.GetUsers() {
+		users = append(users, table.User{Name: u.GetName(), TokenHash: u.GetTokenHash(), Roles: u.GetRoles()})
+	}
+	roles := make([]table.Role, 0, len(auth.GetRoles()))
+	for _, r := range auth.GetRoles() {
+		role := table.Role{Name: r.GetName()}
+		for _, p := range r.GetPermissions() {
+			role.Permissions = append(role.Permissions, table.Permission{
+				Table:     string(p.GetTable()),
+				KeyPrefix: p.GetKeyPrefix(),
+				Read:      p.GetRead(),
+				Write:     p.GetWrite(),
+			})
+		}
+		roles = append(roles, role)
+	}
+	return users, roles
 }
 
 func (m *Manager) reconcileWorkers() error {
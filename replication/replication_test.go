@@ -86,6 +86,8 @@ func TestManager_reconcile(t *testing.T) {
 	r.NoError(followerTM.Start())
 	r.NoError(followerTM.WaitUntilReady())
 	defer followerTM.Close()
+	user := table.User{Name: "client", TokenHash: table.HashToken("secret")}
+	r.NoError(followerTM.PutUser(user))
 
 	conn := testServer(t, func(server *grpc.Server) {
 		s := testReplicationServer{metaResp: &regattapb.MetadataResponse{Tables: []*regattapb.Table{
@@ -118,6 +120,11 @@ func TestManager_reconcile(t *testing.T) {
 	r.Eventually(func() bool {
 		return m.hasWorker("test2")
 	}, 10*time.Second, 250*time.Millisecond, "replication worker not found in registry")
+
+	t.Log("users are kept if the leader does not replicate them")
+	users, err := followerTM.GetUsers()
+	r.NoError(err)
+	r.Equal([]table.User{user}, users)
 	m.Close()
 	r.Empty(m.workers.registry)
 }
@@ -143,14 +150,37 @@ func TestManager_reconcileTables(t *testing.T) {
 		return err == nil
 	}, 10*time.Second, 200*time.Millisecond, "table not created in time")
 
-	t.Log("create another table")
-	r.NoError(leaderTM.CreateTable("test2"))
+	t.Log("create another table with options")
+	opts := table.Options{MaxValueSize: 1024, Compression: table.CompressionZstd}
+	r.NoError(leaderTM.CreateTableWithOptions("test2", opts))
 	r.NoError(m.reconcileTables())
 	r.Eventually(func() bool {
-		_, err := followerTM.GetTable("test2")
-		return err == nil
+		tbl, err := followerTM.GetTable("test2")
+		return err == nil && tbl.Options == opts
 	}, 10*time.Second, 200*time.Millisecond, "table not created in time")
 
+	t.Log("update table options")
+	opts = table.Options{MaxValueSize: 2048, SnapshotEntries: 100}
+	r.NoError(leaderTM.UpdateTableOptions("test2", opts))
+	r.NoError(m.reconcileTables())
+	r.Eventually(func() bool {
+		tbl, err := followerTM.GetTable("test2")
+		return err == nil && tbl.Options == opts
+	}, 10*time.Second, 200*time.Millisecond, "table options not updated in time")
+
+	t.Log("replicate users and roles")
+	role := table.Role{Name: "reader", Permissions: []table.Permission{{Table: "test", Read: true}}}
+	user := table.User{Name: "client", TokenHash: table.HashToken("secret"), Roles: []string{"reader"}}
+	r.NoError(leaderTM.PutRole(role))
+	r.NoError(leaderTM.PutUser(user))
+	r.NoError(m.reconcileTables())
+	users, err := followerTM.GetUsers()
+	r.NoError(err)
+	r.Equal([]table.User{user}, users)
+	roles, err := followerTM.GetRoles()
+	r.NoError(err)
+	r.Equal([]table.Role{role}, roles)
+
 	t.Log("skip network errors")
 	r.NoError(conn.Close())
 
@@ -274,7 +304,7 @@ func prepareLeaderAndFollowerRaft(t *testing.T) (leaderTM *table.Manager, follow
 func startReplicationServer(manager *table.Manager, nh *dragonboat.NodeHost) *regattaserver.RegattaServer {
 	testNodeAddress := fmt.Sprintf("127.0.0.1:%d", getTestPort())
 	server := regattaserver.NewServer(testNodeAddress, false)
-	regattapb.RegisterMetadataServer(server, &regattaserver.MetadataServer{Tables: manager})
+	regattapb.RegisterMetadataServer(server, &regattaserver.MetadataServer{Tables: manager, Auth: manager})
 	regattapb.RegisterSnapshotServer(server, &regattaserver.SnapshotServer{Tables: manager})
 	regattapb.RegisterLogServer(
 		server,
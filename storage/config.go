@@ -3,6 +3,8 @@
 package storage
 
 import (
+	"time"
+
 	"github.com/jamf/regatta/storage/table"
 	"github.com/lni/vfs"
 )
@@ -96,6 +98,17 @@ type Config struct {
 	Table TableConfig
 	// Meta is a configuration for metadata inmemory state machine.
 	Meta MetaConfig
+	// ExpireLeases if the expired key leases of the tables led by this node should be revoked. It must be disabled
+	// in follower clusters as they replicate the revocations from the leader cluster.
+	ExpireLeases bool
+	// HistoryRetention is the number of the most recent revisions whose history is kept in the tables led by this node,
+	// the older history is compacted periodically. Zero disables the automatic compaction, it must be disabled
+	// in follower clusters as they replicate the compactions from the leader cluster.
+	HistoryRetention uint64
+	// HeartbeatInterval is how often are the heartbeats proposed into the tables led by this node, the heartbeats
+	// bound the staleness of the serializable reads. Zero disables the heartbeats, it must be disabled
+	// in follower clusters as they replicate the heartbeats from the leader cluster.
+	HeartbeatInterval time.Duration
 	// LogDBImplementation underlying LogDB implementation Pebble (default) or Tan.
 	LogDBImplementation LogDBImplementation
 	// LogCacheSize specifies the size of the log cache.
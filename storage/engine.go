@@ -4,12 +4,14 @@ package storage
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/jamf/regatta/regattapb"
 	"github.com/jamf/regatta/storage/cluster"
 	"github.com/jamf/regatta/storage/logreader"
 	"github.com/jamf/regatta/storage/table"
+	"github.com/jamf/regatta/tracing"
 	"github.com/lni/dragonboat/v4"
 	"github.com/lni/dragonboat/v4/config"
 	"github.com/lni/dragonboat/v4/plugin/tan"
@@ -32,9 +34,12 @@ func New(cfg Config) (*Engine, error) {
 		nh,
 		cfg.InitialMembers,
 		table.Config{
-			NodeID: cfg.NodeID,
-			Table:  table.TableConfig(cfg.Table),
-			Meta:   table.MetaConfig(cfg.Meta),
+			NodeID:            cfg.NodeID,
+			Table:             table.TableConfig(cfg.Table),
+			Meta:              table.MetaConfig(cfg.Meta),
+			ExpireLeases:      cfg.ExpireLeases,
+			HistoryRetention:  cfg.HistoryRetention,
+			HeartbeatInterval: cfg.HeartbeatInterval,
 		},
 	)
 	if cfg.LogCacheSize > 0 {
@@ -72,6 +77,8 @@ func (e *Engine) Close() error {
 }
 
 func (e *Engine) Range(ctx context.Context, req *regattapb.RangeRequest) (*regattapb.RangeResponse, error) {
+	ctx, span := tracing.Start(ctx, "Engine.Range", tracing.Attr("table", string(req.Table)))
+	defer span.End()
 	t, err := e.Manager.GetTable(string(req.Table))
 	if err != nil {
 		return nil, err
@@ -80,11 +87,26 @@ func (e *Engine) Range(ctx context.Context, req *regattapb.RangeRequest) (*regat
 	if err != nil {
 		return nil, err
 	}
-	rng.Header = e.getHeader(nil, t.ClusterID)
+	rng.Header = e.getHeader(rng.Header, t.ClusterID)
 	return rng, nil
 }
 
+func (e *Engine) Cursor(ctx context.Context, req *regattapb.RangeRequest, send func(*regattapb.RangeResponse) error) error {
+	ctx, span := tracing.Start(ctx, "Engine.Cursor", tracing.Attr("table", string(req.Table)))
+	defer span.End()
+	t, err := e.Manager.GetTable(string(req.Table))
+	if err != nil {
+		return err
+	}
+	return t.Cursor(ctx, req, func(response *regattapb.RangeResponse) error {
+		response.Header = e.getHeader(response.Header, t.ClusterID)
+		return send(response)
+	})
+}
+
 func (e *Engine) Put(ctx context.Context, req *regattapb.PutRequest) (*regattapb.PutResponse, error) {
+	ctx, span := tracing.Start(ctx, "Engine.Put", tracing.Attr("table", string(req.Table)))
+	defer span.End()
 	t, err := e.Manager.GetTable(string(req.Table))
 	if err != nil {
 		return nil, err
@@ -97,7 +119,54 @@ func (e *Engine) Put(ctx context.Context, req *regattapb.PutRequest) (*regattapb
 	return put, nil
 }
 
+func (e *Engine) PutLarge(ctx context.Context, req *regattapb.PutLargeRequest, value io.Reader) (*regattapb.PutLargeResponse, error) {
+	ctx, span := tracing.Start(ctx, "Engine.PutLarge", tracing.Attr("table", string(req.Table)))
+	defer span.End()
+	t, err := e.Manager.GetTable(string(req.Table))
+	if err != nil {
+		return nil, err
+	}
+	put, err := t.PutLarge(ctx, req, value)
+	if err != nil {
+		return nil, err
+	}
+	put.Header = e.getHeader(put.Header, t.ClusterID)
+	return put, nil
+}
+
+func (e *Engine) GetLarge(ctx context.Context, req *regattapb.GetLargeRequest, send func(*regattapb.GetLargeResponse) error) error {
+	ctx, span := tracing.Start(ctx, "Engine.GetLarge", tracing.Attr("table", string(req.Table)))
+	defer span.End()
+	t, err := e.Manager.GetTable(string(req.Table))
+	if err != nil {
+		return err
+	}
+	return t.GetLarge(ctx, req, func(response *regattapb.GetLargeResponse) error {
+		if response.Header != nil {
+			response.Header = e.getHeader(response.Header, t.ClusterID)
+		}
+		return send(response)
+	})
+}
+
+func (e *Engine) Increment(ctx context.Context, req *regattapb.IncrementRequest) (*regattapb.IncrementResponse, error) {
+	ctx, span := tracing.Start(ctx, "Engine.Increment", tracing.Attr("table", string(req.Table)))
+	defer span.End()
+	t, err := e.Manager.GetTable(string(req.Table))
+	if err != nil {
+		return nil, err
+	}
+	inc, err := withDefaultTimeout(ctx, req, t.Increment)
+	if err != nil {
+		return nil, err
+	}
+	inc.Header = e.getHeader(inc.Header, t.ClusterID)
+	return inc, nil
+}
+
 func (e *Engine) Delete(ctx context.Context, req *regattapb.DeleteRangeRequest) (*regattapb.DeleteRangeResponse, error) {
+	ctx, span := tracing.Start(ctx, "Engine.Delete", tracing.Attr("table", string(req.Table)))
+	defer span.End()
 	t, err := e.Manager.GetTable(string(req.Table))
 	if err != nil {
 		return nil, err
@@ -111,6 +180,8 @@ func (e *Engine) Delete(ctx context.Context, req *regattapb.DeleteRangeRequest)
 }
 
 func (e *Engine) Txn(ctx context.Context, req *regattapb.TxnRequest) (*regattapb.TxnResponse, error) {
+	ctx, span := tracing.Start(ctx, "Engine.Txn", tracing.Attr("table", string(req.Table)))
+	defer span.End()
 	t, err := e.Manager.GetTable(string(req.Table))
 	if err != nil {
 		return nil, err
@@ -123,6 +194,79 @@ func (e *Engine) Txn(ctx context.Context, req *regattapb.TxnRequest) (*regattapb
 	return tx, nil
 }
 
+// LeaderIndex returns the index of the leader cluster log applied by the local replica of the table.
+func (e *Engine) LeaderIndex(ctx context.Context, table []byte) (uint64, error) {
+	t, err := e.Manager.GetTable(string(table))
+	if err != nil {
+		return 0, err
+	}
+	res, err := t.LeaderIndex(ctx, false)
+	if err != nil {
+		return 0, err
+	}
+	return res.Index, nil
+}
+
+func (e *Engine) LeaseGrant(ctx context.Context, req *regattapb.LeaseGrantRequest) (*regattapb.LeaseGrantResponse, error) {
+	ctx, span := tracing.Start(ctx, "Engine.LeaseGrant", tracing.Attr("table", string(req.Table)))
+	defer span.End()
+	t, err := e.Manager.GetTable(string(req.Table))
+	if err != nil {
+		return nil, err
+	}
+	lg, err := withDefaultTimeout(ctx, req, t.LeaseGrant)
+	if err != nil {
+		return nil, err
+	}
+	lg.Header = e.getHeader(lg.Header, t.ClusterID)
+	return lg, nil
+}
+
+func (e *Engine) LeaseRevoke(ctx context.Context, req *regattapb.LeaseRevokeRequest) (*regattapb.LeaseRevokeResponse, error) {
+	ctx, span := tracing.Start(ctx, "Engine.LeaseRevoke", tracing.Attr("table", string(req.Table)))
+	defer span.End()
+	t, err := e.Manager.GetTable(string(req.Table))
+	if err != nil {
+		return nil, err
+	}
+	lr, err := withDefaultTimeout(ctx, req, t.LeaseRevoke)
+	if err != nil {
+		return nil, err
+	}
+	lr.Header = e.getHeader(lr.Header, t.ClusterID)
+	return lr, nil
+}
+
+func (e *Engine) LeaseKeepAlive(ctx context.Context, req *regattapb.LeaseKeepAliveRequest) (*regattapb.LeaseKeepAliveResponse, error) {
+	ctx, span := tracing.Start(ctx, "Engine.LeaseKeepAlive", tracing.Attr("table", string(req.Table)))
+	defer span.End()
+	t, err := e.Manager.GetTable(string(req.Table))
+	if err != nil {
+		return nil, err
+	}
+	ka, err := withDefaultTimeout(ctx, req, t.LeaseKeepAlive)
+	if err != nil {
+		return nil, err
+	}
+	ka.Header = e.getHeader(ka.Header, t.ClusterID)
+	return ka, nil
+}
+
+func (e *Engine) LeaseTimeToLive(ctx context.Context, req *regattapb.LeaseTimeToLiveRequest) (*regattapb.LeaseTimeToLiveResponse, error) {
+	ctx, span := tracing.Start(ctx, "Engine.LeaseTimeToLive", tracing.Attr("table", string(req.Table)))
+	defer span.End()
+	t, err := e.Manager.GetTable(string(req.Table))
+	if err != nil {
+		return nil, err
+	}
+	ttl, err := withDefaultTimeout(ctx, req, t.LeaseTimeToLive)
+	if err != nil {
+		return nil, err
+	}
+	ttl.Header = e.getHeader(ttl.Header, t.ClusterID)
+	return ttl, nil
+}
+
 func (e *Engine) getHeader(header *regattapb.ResponseHeader, shardID uint64) *regattapb.ResponseHeader {
 	if header == nil {
 		header = &regattapb.ResponseHeader{}
@@ -3,6 +3,7 @@
 package storage
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -15,6 +16,7 @@ import (
 	pvfs "github.com/cockroachdb/pebble/vfs"
 	"github.com/jamf/regatta/regattapb"
 	"github.com/jamf/regatta/storage/cluster"
+	serrors "github.com/jamf/regatta/storage/errors"
 	"github.com/jamf/regatta/storage/logreader"
 	"github.com/jamf/regatta/storage/table"
 	lvfs "github.com/lni/vfs"
@@ -187,9 +189,10 @@ func TestEngine_Range(t *testing.T) {
 				Header: &regattapb.ResponseHeader{
 					ReplicaId: 1,
 					ShardId:   10001,
+					Revision:  3,
 				},
 				Kvs: []*regattapb.KeyValue{
-					{Key: []byte("key"), Value: []byte("value")},
+					{Key: []byte("key"), Value: []byte("value"), CreateRevision: 3, ModRevision: 3, Version: 1},
 				},
 				Count: 1,
 			},
@@ -213,9 +216,10 @@ func TestEngine_Range(t *testing.T) {
 				Header: &regattapb.ResponseHeader{
 					ReplicaId: 1,
 					ShardId:   10001,
+					Revision:  3,
 				},
 				Kvs: []*regattapb.KeyValue{
-					{Key: []byte("key"), Value: []byte("value")},
+					{Key: []byte("key"), Value: []byte("value"), CreateRevision: 3, ModRevision: 3, Version: 1},
 				},
 				Count: 1,
 			},
@@ -331,8 +335,11 @@ func TestEngine_Put(t *testing.T) {
 					Revision:  4,
 				},
 				PrevKv: &regattapb.KeyValue{
-					Key:   []byte("key"),
-					Value: []byte("value"),
+					Key:            []byte("key"),
+					Value:          []byte("value"),
+					CreateRevision: 3,
+					ModRevision:    3,
+					Version:        1,
 				},
 			},
 			wantErr: require.NoError,
@@ -450,7 +457,7 @@ func TestEngine_Delete(t *testing.T) {
 				},
 				Deleted: 1,
 				PrevKvs: []*regattapb.KeyValue{
-					{Key: []byte("key"), Value: []byte("value")},
+					{Key: []byte("key"), Value: []byte("value"), CreateRevision: 3, ModRevision: 3, Version: 1},
 				},
 			},
 			wantErr: require.NoError,
@@ -606,7 +613,7 @@ func TestEngine_Txn(t *testing.T) {
 				Succeeded: false,
 				Responses: []*regattapb.ResponseOp{{Response: &regattapb.ResponseOp_ResponsePut{
 					ResponsePut: &regattapb.ResponseOp_Put{
-						PrevKv: &regattapb.KeyValue{Key: []byte("key"), Value: []byte("value")},
+						PrevKv: &regattapb.KeyValue{Key: []byte("key"), Value: []byte("value"), CreateRevision: 3, ModRevision: 3, Version: 1},
 					},
 				}}},
 			},
@@ -629,6 +636,259 @@ func TestEngine_Txn(t *testing.T) {
 	}
 }
 
+func TestEngine_Cursor(t *testing.T) {
+	r := require.New(t)
+	e := newTestEngine(newTestConfig())
+	defer e.Close()
+	r.NoError(e.Start())
+	r.NoError(e.WaitUntilReady())
+	createTable(t, e)
+	ctx := context.Background()
+
+	for _, k := range []string{"key_1", "key_2", "key_3"} {
+		_, err := e.Put(ctx, &regattapb.PutRequest{Table: []byte(testTableName), Key: []byte(k), Value: []byte("value")})
+		r.NoError(err)
+	}
+
+	var responses []*regattapb.RangeResponse
+	err := e.Cursor(ctx, &regattapb.RangeRequest{Table: []byte(testTableName), Key: []byte{0}, RangeEnd: []byte{0}, Linearizable: true, BatchSize: 2}, func(response *regattapb.RangeResponse) error {
+		responses = append(responses, response)
+		return nil
+	})
+	r.NoError(err)
+	r.Len(responses, 2)
+	r.True(responses[0].More)
+	r.False(responses[1].More)
+	r.Equal(int64(2), responses[0].Count)
+	r.Equal(int64(1), responses[1].Count)
+	r.Equal(uint64(10001), responses[1].Header.ShardId)
+	r.Equal(uint64(5), responses[1].Header.Revision)
+
+	err = e.Cursor(ctx, &regattapb.RangeRequest{Table: []byte("missing"), Key: []byte{0}}, func(*regattapb.RangeResponse) error { return nil })
+	r.ErrorIs(err, serrors.ErrTableNotFound)
+}
+
+func TestEngine_RangeRevision(t *testing.T) {
+	r := require.New(t)
+	e := newTestEngine(newTestConfig())
+	defer e.Close()
+	r.NoError(e.Start())
+	r.NoError(e.WaitUntilReady())
+	createTable(t, e)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var revisions []uint64
+	for _, v := range []string{"value_1", "value_2", "value_3"} {
+		put, err := e.Put(ctx, &regattapb.PutRequest{Table: []byte(testTableName), Key: []byte("key"), Value: []byte(v)})
+		r.NoError(err)
+		revisions = append(revisions, put.Header.Revision)
+	}
+
+	rng, err := e.Range(ctx, &regattapb.RangeRequest{Table: []byte(testTableName), Key: []byte("key"), Linearizable: true, Revision: int64(revisions[0])})
+	r.NoError(err)
+	r.Equal([]byte("value_1"), rng.Kvs[0].Value)
+
+	tab, err := e.GetTable(testTableName)
+	r.NoError(err)
+	r.NoError(tab.Compact(ctx, revisions[1]))
+	r.ErrorIs(tab.Compact(ctx, revisions[1]), serrors.ErrCompacted)
+	r.ErrorIs(tab.Compact(ctx, revisions[2]+100), serrors.ErrFutureRevision)
+
+	_, err = e.Range(ctx, &regattapb.RangeRequest{Table: []byte(testTableName), Key: []byte("key"), Linearizable: true, Revision: int64(revisions[0])})
+	r.ErrorIs(err, serrors.ErrCompacted)
+	rng, err = e.Range(ctx, &regattapb.RangeRequest{Table: []byte(testTableName), Key: []byte("key"), Linearizable: true, Revision: int64(revisions[1])})
+	r.NoError(err)
+	r.Equal([]byte("value_2"), rng.Kvs[0].Value)
+}
+
+func TestEngine_Increment(t *testing.T) {
+	r := require.New(t)
+	e := newTestEngine(newTestConfig())
+	defer e.Close()
+	r.NoError(e.Start())
+	r.NoError(e.WaitUntilReady())
+	createTable(t, e)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	inc, err := e.Increment(ctx, &regattapb.IncrementRequest{Table: []byte(testTableName), Key: []byte("counter"), Delta: 1, Initial: 10})
+	r.NoError(err)
+	r.Equal(int64(11), inc.Value)
+	inc, err = e.Increment(ctx, &regattapb.IncrementRequest{Table: []byte(testTableName), Key: []byte("counter"), Delta: -5})
+	r.NoError(err)
+	r.Equal(int64(6), inc.Value)
+
+	rng, err := e.Range(ctx, &regattapb.RangeRequest{Table: []byte(testTableName), Key: []byte("counter"), Linearizable: true})
+	r.NoError(err)
+	r.Equal([]byte("6"), rng.Kvs[0].Value)
+	r.Equal(inc.Header.Revision, uint64(rng.Kvs[0].ModRevision))
+
+	lower := int64(5)
+	_, err = e.Increment(ctx, &regattapb.IncrementRequest{Table: []byte(testTableName), Key: []byte("counter"), Delta: -2, Min: &lower})
+	r.ErrorIs(err, serrors.ErrValueOutOfBounds)
+
+	_, err = e.Put(ctx, &regattapb.PutRequest{Table: []byte(testTableName), Key: []byte("key"), Value: []byte("value")})
+	r.NoError(err)
+	_, err = e.Increment(ctx, &regattapb.IncrementRequest{Table: []byte(testTableName), Key: []byte("key"), Delta: 1})
+	r.ErrorIs(err, serrors.ErrValueNotInteger)
+}
+
+func TestEngine_TableInfo(t *testing.T) {
+	r := require.New(t)
+	e := newTestEngine(newTestConfig())
+	defer e.Close()
+	r.NoError(e.Start())
+	r.NoError(e.WaitUntilReady())
+	createTable(t, e)
+
+	tab, err := e.GetTable(testTableName)
+	r.NoError(err)
+	leader, ok, err := tab.Leader()
+	r.NoError(err)
+	r.True(ok)
+	r.Equal(uint64(1), leader)
+	size, err := tab.Size(context.Background())
+	r.NoError(err)
+	r.NotZero(size.Size)
+}
+
+func TestEngine_Lease(t *testing.T) {
+	r := require.New(t)
+	e := newTestEngine(newTestConfig())
+	defer e.Close()
+	r.NoError(e.Start())
+	r.NoError(e.WaitUntilReady())
+	createTable(t, e)
+	ctx := context.Background()
+
+	grant, err := e.LeaseGrant(ctx, &regattapb.LeaseGrantRequest{Table: []byte(testTableName), TTL: 60})
+	r.NoError(err)
+	r.Equal(int64(60), grant.TTL)
+	r.NotZero(grant.ID)
+
+	_, err = e.LeaseGrant(ctx, &regattapb.LeaseGrantRequest{Table: []byte(testTableName), TTL: 60, ID: grant.ID})
+	r.ErrorIs(err, serrors.ErrLeaseExists)
+
+	put, err := e.Put(ctx, &regattapb.PutRequest{Table: []byte(testTableName), Key: []byte("key"), Value: []byte("value"), Lease: grant.ID})
+	r.NoError(err)
+	_, err = e.Put(ctx, &regattapb.PutRequest{Table: []byte(testTableName), Key: []byte("key"), Value: []byte("value"), Lease: grant.ID + 1})
+	r.ErrorIs(err, serrors.ErrLeaseNotFound)
+
+	ttl, err := e.LeaseTimeToLive(ctx, &regattapb.LeaseTimeToLiveRequest{Table: []byte(testTableName), ID: grant.ID, Keys: true})
+	r.NoError(err)
+	r.Equal(int64(60), ttl.GrantedTTL)
+	r.Equal([][]byte{[]byte("key")}, ttl.Keys)
+	r.GreaterOrEqual(ttl.Header.Revision, put.Header.Revision)
+
+	ka, err := e.LeaseKeepAlive(ctx, &regattapb.LeaseKeepAliveRequest{Table: []byte(testTableName), ID: grant.ID})
+	r.NoError(err)
+	r.Equal(int64(60), ka.TTL)
+
+	_, err = e.LeaseRevoke(ctx, &regattapb.LeaseRevokeRequest{Table: []byte(testTableName), ID: grant.ID})
+	r.NoError(err)
+	_, err = e.LeaseRevoke(ctx, &regattapb.LeaseRevokeRequest{Table: []byte(testTableName), ID: grant.ID})
+	r.ErrorIs(err, serrors.ErrLeaseNotFound)
+
+	res, err := e.Range(ctx, &regattapb.RangeRequest{Table: []byte(testTableName), Key: []byte("key"), Linearizable: true})
+	r.NoError(err)
+	r.Empty(res.Kvs)
+}
+
+func TestEngine_ExpireLeases(t *testing.T) {
+	r := require.New(t)
+	e := newTestEngine(newTestConfig())
+	defer e.Close()
+	r.NoError(e.Start())
+	r.NoError(e.WaitUntilReady())
+	createTable(t, e)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	grant, err := e.LeaseGrant(ctx, &regattapb.LeaseGrantRequest{Table: []byte(testTableName), TTL: 1})
+	r.NoError(err)
+	_, err = e.Put(ctx, &regattapb.PutRequest{Table: []byte(testTableName), Key: []byte("key"), Value: []byte("value"), Lease: grant.ID})
+	r.NoError(err)
+
+	tab, err := e.GetTable(testTableName)
+	r.NoError(err)
+	r.NoError(tab.ExpireLeases(ctx, time.Now()))
+	ttl, err := e.LeaseTimeToLive(ctx, &regattapb.LeaseTimeToLiveRequest{Table: []byte(testTableName), ID: grant.ID})
+	r.NoError(err, "lease must not expire before its TTL")
+	r.Equal(grant.ID, ttl.ID)
+
+	r.NoError(tab.ExpireLeases(ctx, time.Now().Add(2*time.Second)))
+	_, err = e.LeaseTimeToLive(ctx, &regattapb.LeaseTimeToLiveRequest{Table: []byte(testTableName), ID: grant.ID})
+	r.ErrorIs(err, serrors.ErrLeaseNotFound)
+	res, err := e.Range(ctx, &regattapb.RangeRequest{Table: []byte(testTableName), Key: []byte("key"), Linearizable: true})
+	r.NoError(err)
+	r.Empty(res.Kvs)
+}
+
+func TestEngine_RangeMaxStaleness(t *testing.T) {
+	r := require.New(t)
+	cfg := newTestConfig()
+	cfg.HeartbeatInterval = 50 * time.Millisecond
+	e := newTestEngine(cfg)
+	defer e.Close()
+	r.NoError(e.Start())
+	r.NoError(e.WaitUntilReady())
+	createTable(t, e)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := e.Put(ctx, &regattapb.PutRequest{Table: []byte(testTableName), Key: []byte("key"), Value: []byte("value")})
+	r.NoError(err)
+	r.Eventually(func() bool {
+		res, err := e.Range(ctx, &regattapb.RangeRequest{Table: []byte(testTableName), Key: []byte("key"), MaxStalenessMs: 1000})
+		return err == nil && len(res.Kvs) == 1
+	}, 5*time.Second, 50*time.Millisecond)
+
+	tab, err := e.GetTable(testTableName)
+	r.NoError(err)
+	hb, err := tab.LastHeartbeat(context.Background())
+	r.NoError(err)
+	r.WithinDuration(time.Now(), time.Unix(0, hb.Timestamp), time.Second)
+}
+
+func TestEngine_PutLarge(t *testing.T) {
+	r := require.New(t)
+	e := newTestEngine(newTestConfig())
+	defer e.Close()
+	r.NoError(e.Start())
+	r.NoError(e.WaitUntilReady())
+	createTable(t, e)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	value := bytes.Repeat([]byte("large"), 500_000)
+	put, err := e.PutLarge(ctx, &regattapb.PutLargeRequest{Table: []byte(testTableName), Key: []byte("key")}, bytes.NewReader(value))
+	r.NoError(err)
+	r.Equal(uint64(len(value)), put.Size)
+
+	t.Log("range reassembles the large value")
+	rng, err := e.Range(ctx, &regattapb.RangeRequest{Table: []byte(testTableName), Key: []byte("key"), Linearizable: true})
+	r.NoError(err)
+	r.Equal(value, rng.Kvs[0].Value)
+
+	t.Log("large value is streamed in chunks")
+	var parts [][]byte
+	err = e.GetLarge(ctx, &regattapb.GetLargeRequest{Table: []byte(testTableName), Key: []byte("key"), Linearizable: true}, func(res *regattapb.GetLargeResponse) error {
+		if len(parts) == 0 {
+			r.Equal(put.Header.Revision, uint64(res.Kv.ModRevision))
+			r.Equal(put.Header.ShardId, res.Header.ShardId)
+		}
+		parts = append(parts, bytes.Clone(res.Value))
+		return nil
+	})
+	r.NoError(err)
+	r.Len(parts, 3)
+	r.Equal(value, bytes.Join(parts, nil))
+
+	err = e.GetLarge(ctx, &regattapb.GetLargeRequest{Table: []byte(testTableName), Key: []byte("missing")}, func(*regattapb.GetLargeResponse) error { return nil })
+	r.ErrorIs(err, serrors.ErrKeyNotFound)
+}
+
 func TestNew(t *testing.T) {
 	type args struct {
 		cfg Config
@@ -709,9 +969,10 @@ func newTestEngine(cfg Config) *Engine {
 		panic(err)
 	}
 	e.Manager = table.NewManager(nh, cfg.InitialMembers, table.Config{
-		NodeID: cfg.NodeID,
-		Table:  table.TableConfig(cfg.Table),
-		Meta:   table.MetaConfig(cfg.Meta),
+		NodeID:            cfg.NodeID,
+		Table:             table.TableConfig(cfg.Table),
+		Meta:              table.MetaConfig(cfg.Meta),
+		HeartbeatInterval: cfg.HeartbeatInterval,
 	})
 	return e
 }
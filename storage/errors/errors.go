@@ -29,6 +29,34 @@ var (
 	ErrNoResultFound = errors.New("FSM returned no results")
 	// ErrUnknownResultType FSM returned a result of the wrong type.
 	ErrUnknownResultType = errors.New("unknown result type")
+	// ErrLeaseNotFound returned when the key lease is not found.
+	ErrLeaseNotFound = errors.New("lease not found")
+	// ErrLeaseExists returned when the key lease with the requested ID is already granted.
+	ErrLeaseExists = errors.New("lease already exists")
+	// ErrCompacted returned when the requested revision of a table has been compacted.
+	ErrCompacted = errors.New("required revision has been compacted")
+	// ErrFutureRevision returned when the requested revision of a table has not been applied yet.
+	ErrFutureRevision = errors.New("required revision is a future revision")
+	// ErrInvalidTableOptions returned when the table options are not valid.
+	ErrInvalidTableOptions = errors.New("invalid table options")
+	// ErrValueNotInteger returned when the value to increment is not an integer.
+	ErrValueNotInteger = errors.New("value is not an integer")
+	// ErrValueOutOfBounds returned when the incremented value overflows or exceeds the requested bounds.
+	ErrValueOutOfBounds = errors.New("value out of bounds")
+	// ErrReplicaStale returned when the replica did not catch up within the requested max staleness.
+	ErrReplicaStale = errors.New("replica is staler than the requested max staleness")
+	// ErrRevisionNotApplied returned when the replica did not apply the requested min revision before the deadline.
+	ErrRevisionNotApplied = errors.New("replica has not applied the requested min revision")
+	// ErrValueTooLarge returned when the large value does not fit into a single response and has to be streamed.
+	ErrValueTooLarge = errors.New("value too large to be returned in a single response")
+	// ErrRequestIDReused returned when the request ID was already used by a different request.
+	ErrRequestIDReused = errors.New("request ID was already used by a different request")
+	// ErrUserNotFound returned when the user is not found.
+	ErrUserNotFound = errors.New("user not found")
+	// ErrRoleNotFound returned when the role is not found.
+	ErrRoleNotFound = errors.New("role not found")
+	// ErrInvalidAuth returned when the user or the role is not valid.
+	ErrInvalidAuth = errors.New("invalid user or role")
 
 	ErrTableExists             = errors.New("table already exists")
 	ErrManagerClosed           = errors.New("manager closed")
@@ -0,0 +1,188 @@
+// Copyright JAMF Software, LLC
+
+package table
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	serrors "github.com/jamf/regatta/storage/errors"
+	"github.com/jamf/regatta/storage/kv"
+)
+
+const (
+	usersKeyPrefix = "/auth/users/"
+	rolesKeyPrefix = "/auth/roles/"
+	// AnyTable is the table name of the permission granted on all the tables.
+	AnyTable = "*"
+)
+
+// User is the identity of the KV API clients, stored in the meta store.
+type User struct {
+	// Name of the user, the clients authenticated by the client certificate are matched by the certificate common name.
+	Name string `json:"name"`
+	// TokenHash is the hex encoded SHA-256 hash of the bearer token of the user, empty if the user has no token.
+	TokenHash string `json:"token_hash,omitempty"`
+	// Roles are the names of the roles granted to the user.
+	Roles []string `json:"roles,omitempty"`
+}
+
+// Role is a named set of permissions, stored in the meta store.
+type Role struct {
+	// Name of the role.
+	Name string `json:"name"`
+	// Permissions granted by the role.
+	Permissions []Permission `json:"permissions,omitempty"`
+}
+
+// Permission grants the read and/or write access to the table, optionally restricted to the keys with the prefix.
+type Permission struct {
+	// Table is the name of the table or AnyTable.
+	Table string `json:"table"`
+	// KeyPrefix restricts the permission to the keys with the prefix, all the keys are covered if empty.
+	KeyPrefix []byte `json:"key_prefix,omitempty"`
+	// Read grants the read access.
+	Read bool `json:"read,omitempty"`
+	// Write grants the write access.
+	Write bool `json:"write,omitempty"`
+}
+
+// HashToken returns the hex encoded SHA-256 hash of the bearer token as stored in User.TokenHash.
+func HashToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
+
+func validateAuthName(name string) error {
+	if name == "" || strings.ContainsAny(name, "/*?[]\\") {
+		return fmt.Errorf("%w: invalid name %q", serrors.ErrInvalidAuth, name)
+	}
+	return nil
+}
+
+// PutUser creates or replaces the user.
+func (m *Manager) PutUser(user User) error {
+	if err := validateAuthName(user.Name); err != nil {
+		return err
+	}
+	return m.putAuth(usersKeyPrefix+user.Name, user)
+}
+
+// DeleteUser deletes the user.
+func (m *Manager) DeleteUser(name string) error {
+	return m.deleteAuth(usersKeyPrefix+name, serrors.ErrUserNotFound)
+}
+
+// GetUsers returns all the users.
+func (m *Manager) GetUsers() ([]User, error) {
+	return getAuth[User](m.store, usersKeyPrefix)
+}
+
+// PutRole creates or replaces the role.
+func (m *Manager) PutRole(role Role) error {
+	if err := validateAuthName(role.Name); err != nil {
+		return err
+	}
+	for _, p := range role.Permissions {
+		if p.Table == "" {
+			return fmt.Errorf("%w: permission table must be set", serrors.ErrInvalidAuth)
+		}
+	}
+	return m.putAuth(rolesKeyPrefix+role.Name, role)
+}
+
+// DeleteRole deletes the role, the role is ignored by the users it is granted to.
+func (m *Manager) DeleteRole(name string) error {
+	return m.deleteAuth(rolesKeyPrefix+name, serrors.ErrRoleNotFound)
+}
+
+// GetRoles returns all the roles.
+func (m *Manager) GetRoles() ([]Role, error) {
+	return getAuth[Role](m.store, rolesKeyPrefix)
+}
+
+// SetAuth replaces all the stored users and roles, it is used by the follower clusters to mirror the leader cluster.
+func (m *Manager) SetAuth(users []User, roles []Role) error {
+	if err := syncAuth(m, usersKeyPrefix, users, func(u User) string { return u.Name }); err != nil {
+		return err
+	}
+	return syncAuth(m, rolesKeyPrefix, roles, func(r Role) string { return r.Name })
+}
+
+func syncAuth[T any](m *Manager, prefix string, items []T, name func(T) string) error {
+	stored, err := getAuth[T](m.store, prefix)
+	if err != nil {
+		return err
+	}
+	keep := make(map[string]struct{}, len(items))
+	for _, item := range items {
+		keep[name(item)] = struct{}{}
+		if err := m.putAuth(prefix+name(item), item); err != nil {
+			return err
+		}
+	}
+	for _, item := range stored {
+		if _, ok := keep[name(item)]; ok {
+			continue
+		}
+		if err := m.deleteAuth(prefix+name(item), nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Manager) putAuth(key string, value any) error {
+	bts, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	var ver uint64
+	p, err := m.store.Get(key)
+	switch {
+	case err == nil:
+		if p.Value == string(bts) {
+			return nil
+		}
+		ver = p.Ver
+	case !errors.Is(err, kv.ErrNotExist):
+		return err
+	}
+	_, err = m.store.Set(key, string(bts), ver)
+	return err
+}
+
+func (m *Manager) deleteAuth(key string, notFound error) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	p, err := m.store.Get(key)
+	if err != nil {
+		if errors.Is(err, kv.ErrNotExist) {
+			return notFound
+		}
+		return err
+	}
+	return m.store.Delete(key, p.Ver)
+}
+
+func getAuth[T any](s store, prefix string) ([]T, error) {
+	all, err := s.GetAll(prefix + "*")
+	if err != nil {
+		return nil, err
+	}
+	res := make([]T, 0, len(all))
+	for _, p := range all {
+		var item T
+		if err := json.Unmarshal([]byte(p.Value), &item); err != nil {
+			return nil, err
+		}
+		res = append(res, item)
+	}
+	return res, nil
+}
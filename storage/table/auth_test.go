@@ -0,0 +1,64 @@
+// Copyright JAMF Software, LLC
+
+package table
+
+import (
+	"testing"
+
+	serrors "github.com/jamf/regatta/storage/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_Auth(t *testing.T) {
+	r := require.New(t)
+	node, m := startRaftNode(t)
+	defer node.Close()
+
+	tm := NewManager(node, m, minimalTestConfig())
+	r.NoError(tm.Start())
+	defer tm.Close()
+	r.NoError(tm.WaitUntilReady())
+
+	t.Log("put role and user")
+	role := Role{Name: "reader", Permissions: []Permission{{Table: "test", KeyPrefix: []byte("tenant/"), Read: true}}}
+	r.NoError(tm.PutRole(role))
+	user := User{Name: "client", TokenHash: HashToken("secret"), Roles: []string{"reader"}}
+	r.NoError(tm.PutUser(user))
+	users, err := tm.GetUsers()
+	r.NoError(err)
+	r.Equal([]User{user}, users)
+	roles, err := tm.GetRoles()
+	r.NoError(err)
+	r.Equal([]Role{role}, roles)
+
+	t.Log("replace user")
+	user.Roles = []string{"reader", "writer"}
+	r.NoError(tm.PutUser(user))
+	users, err = tm.GetUsers()
+	r.NoError(err)
+	r.Equal([]User{user}, users)
+
+	t.Log("invalid user and role")
+	r.ErrorIs(tm.PutUser(User{Name: "a/b"}), serrors.ErrInvalidAuth)
+	r.ErrorIs(tm.PutRole(Role{Name: "empty", Permissions: []Permission{{Read: true}}}), serrors.ErrInvalidAuth)
+
+	t.Log("tables are not affected")
+	tables, err := tm.GetTables()
+	r.NoError(err)
+	r.Empty(tables)
+
+	t.Log("set auth")
+	other := User{Name: "other"}
+	r.NoError(tm.SetAuth([]User{other}, nil))
+	users, err = tm.GetUsers()
+	r.NoError(err)
+	r.Equal([]User{other}, users)
+	roles, err = tm.GetRoles()
+	r.NoError(err)
+	r.Empty(roles)
+
+	t.Log("delete user and role")
+	r.NoError(tm.DeleteUser("other"))
+	r.ErrorIs(tm.DeleteUser("other"), serrors.ErrUserNotFound)
+	r.ErrorIs(tm.DeleteRole("reader"), serrors.ErrRoleNotFound)
+}
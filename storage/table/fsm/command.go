@@ -16,6 +16,16 @@ type updateContext struct {
 	db          *pebble.DB
 	index       uint64
 	leaderIndex *uint64
+	// revision assigned to the keys modified by the command, it is the leader index for the replicated commands.
+	revision uint64
+	// nestedTxns the outcomes of the nested transactions applied by the command in the order they were applied.
+	nestedTxns []bool
+	// duplicate is set if the command was not applied as a duplicate of the command applied with the same request ID.
+	duplicate bool
+	// traceparent is the trace context of the request the command was proposed for, empty if the request is not traced.
+	traceparent string
+	// discardedHistoryKeys the number of keys the history was dropped of by the large range deletes of the batch.
+	discardedHistoryKeys uint64
 }
 
 func (c *updateContext) EnsureIndexed() error {
@@ -34,6 +44,49 @@ func (c *updateContext) EnsureIndexed() error {
 	return nil
 }
 
+// savepoint is the state of the batch the writes made afterwards could be rolled back to.
+type savepoint struct {
+	len        int
+	count      uint32
+	nestedTxns int
+}
+
+// Savepoint marks the current state of the batch, nothing is copied until the writes are rolled back.
+func (c *updateContext) Savepoint() savepoint {
+	return savepoint{len: c.batch.Len(), count: c.batch.Count(), nestedTxns: len(c.nestedTxns)}
+}
+
+// Rollback discards the writes made since the savepoint. The writes are appended to the batch representation,
+// so the batch is rebuilt from the part of the representation preceding them.
+func (c *updateContext) Rollback(sp savepoint) error {
+	c.nestedTxns = c.nestedTxns[:sp.nestedTxns]
+	if c.batch.Len() == sp.len {
+		return nil
+	}
+	repr := make([]byte, sp.len)
+	copy(repr, c.batch.Repr())
+	// The batch header holds the 8 bytes sequence number followed by the 4 bytes count of the batch entries.
+	binary.LittleEndian.PutUint32(repr[8:12], sp.count)
+	preceding := c.db.NewBatch()
+	defer func() {
+		_ = preceding.Close()
+	}()
+	if err := preceding.SetRepr(repr); err != nil {
+		return err
+	}
+	indexed := c.db.NewIndexedBatch()
+	if err := indexed.Apply(preceding, nil); err != nil {
+		_ = indexed.Close()
+		return err
+	}
+	if err := c.batch.Close(); err != nil {
+		_ = indexed.Close()
+		return err
+	}
+	c.batch = indexed
+	return nil
+}
+
 func (c *updateContext) Commit() error {
 	// Set leader index if present in the proposal
 	if c.leaderIndex != nil {
@@ -52,6 +105,38 @@ func (c *updateContext) Commit() error {
 	return c.batch.Commit(pebble.NoSync)
 }
 
+// SetTxnResult stores the result of a conditional command applied at the current index
+// followed by the outcomes of the nested transactions applied by the command.
+func (c *updateContext) SetTxnResult(result UpdateResult) error {
+	val := make([]byte, 1, 1+len(c.nestedTxns))
+	val[0] = byte(result)
+	for _, succeeded := range c.nestedTxns {
+		if succeeded {
+			val = append(val, 1)
+		} else {
+			val = append(val, 0)
+		}
+	}
+	return c.batch.Set(txnResultKey(c.index), val, nil)
+}
+
+// PruneTxnResults drops stored transaction results older than txnResultRetention entries, the pruning happens every txnResultPruneInterval entries.
+func (c *updateContext) PruneTxnResults() error {
+	if c.index%txnResultPruneInterval != 0 || c.index <= txnResultRetention {
+		return nil
+	}
+	return c.batch.DeleteRange(txnResultKey(0), txnResultKey(c.index-txnResultRetention), nil)
+}
+
+// PruneRequestResults drops the stored results of the commands applied with the request ID more than requestResultRetention
+// revisions ago, the pruning happens every txnResultPruneInterval entries.
+func (c *updateContext) PruneRequestResults() error {
+	if c.index%txnResultPruneInterval != 0 || c.revision <= requestResultRetention {
+		return nil
+	}
+	return pruneRequestResults(c, c.revision-requestResultRetention)
+}
+
 func (c *updateContext) Close() error {
 	if err := c.batch.Close(); err != nil {
 		return err
@@ -61,15 +146,34 @@ func (c *updateContext) Close() error {
 
 func parseCommand(c *updateContext, entry sm.Entry) (command, error) {
 	c.index = entry.Index
+	c.nestedTxns = c.nestedTxns[:0]
+	c.duplicate = false
 	cmd := &regattapb.Command{}
 	if err := cmd.UnmarshalVT(entry.Cmd); err != nil {
 		return commandDummy{}, err
 	}
 	c.leaderIndex = cmd.LeaderIndex
+	c.traceparent = cmd.Traceparent
+	c.setRevision(cmd)
 	return wrapCommand(cmd), nil
 }
 
+// setRevision sets the revision of the command, the replicated commands keep the revision of the leader table.
+func (c *updateContext) setRevision(cmd *regattapb.Command) {
+	c.revision = c.index
+	if cmd.LeaderIndex != nil {
+		c.revision = *cmd.LeaderIndex
+	}
+}
+
 func wrapCommand(cmd *regattapb.Command) command {
+	if len(cmd.RequestId) > 0 {
+		return commandIdempotent{Command: cmd, cmd: wrapCommandType(cmd)}
+	}
+	return wrapCommandType(cmd)
+}
+
+func wrapCommandType(cmd *regattapb.Command) command {
 	switch cmd.Type {
 	case regattapb.Command_PUT:
 		return commandPut{cmd}
@@ -83,8 +187,24 @@ func wrapCommand(cmd *regattapb.Command) command {
 		return commandTxn{cmd}
 	case regattapb.Command_SEQUENCE:
 		return commandSequence{cmd}
+	case regattapb.Command_LEASE_GRANT:
+		return commandLeaseGrant{cmd}
+	case regattapb.Command_LEASE_REVOKE:
+		return commandLeaseRevoke{cmd}
+	case regattapb.Command_LEASE_KEEP_ALIVE:
+		return commandLeaseKeepAlive{cmd}
+	case regattapb.Command_COMPACT:
+		return commandCompact{cmd}
 	case regattapb.Command_DUMMY:
-		return commandDummy{}
+		return commandDummy{cmd}
+	case regattapb.Command_PUT_CHUNK:
+		return commandPutChunk{cmd}
+	case regattapb.Command_PUT_LARGE:
+		return commandPutLarge{cmd}
+	case regattapb.Command_DISCARD_CHUNKS:
+		return commandDiscardChunks{cmd}
+	case regattapb.Command_REQUEST_RESULT:
+		return commandRequestResult{cmd}
 	}
 	panic("unknown command type")
 }
@@ -93,6 +213,59 @@ type command interface {
 	handle(*updateContext) (UpdateResult, *regattapb.CommandResult, error)
 }
 
+// conditionalCommand is implemented by the commands which could be conditional, see IsConditional.
+type conditionalCommand interface {
+	conditional() bool
+}
+
+func (c commandPut) conditional() bool         { return IsConditional(c.Command) }
+func (c commandPutLarge) conditional() bool    { return IsConditional(c.Command) }
+func (c commandTxn) conditional() bool         { return IsConditional(c.Command) }
+func (c commandLeaseRevoke) conditional() bool { return IsConditional(c.Command) }
+
+// IsConditional reports whether the outcome of the command depends on the state of the table. The results of conditional
+// commands are stored (see TxnResultRequest) so that it could be resolved later which of the command operations were applied.
+func IsConditional(cmd *regattapb.Command) bool {
+	if len(cmd.RequestId) > 0 {
+		// The command could be a duplicate which is not applied.
+		return true
+	}
+	switch cmd.Type {
+	case regattapb.Command_PUT, regattapb.Command_PUT_LARGE:
+		return cmd.Kv.GetLease() != 0
+	case regattapb.Command_TXN:
+		return len(cmd.Txn.GetCompare()) > 0 || txnHasLease(cmd.Txn.GetSuccess()) || txnHasLease(cmd.Txn.GetFailure()) ||
+			txnHasIncrement(cmd.Txn.GetSuccess()) || txnHasIncrement(cmd.Txn.GetFailure()) ||
+			txnHasNested(cmd.Txn.GetSuccess()) || txnHasNested(cmd.Txn.GetFailure())
+	case regattapb.Command_LEASE_REVOKE:
+		return true
+	}
+	return false
+}
+
+// txnHasLease reports whether any of the operations (including the operations of the nested transactions) attaches a key to a lease.
+func txnHasLease(ops []*regattapb.RequestOp) bool {
+	for _, op := range ops {
+		if op.GetRequestPut().GetLease() != 0 {
+			return true
+		}
+		if txn := op.GetRequestTxn(); txn != nil && (txnHasLease(txn.Success) || txnHasLease(txn.Failure)) {
+			return true
+		}
+	}
+	return false
+}
+
+// txnHasNested reports whether any of the operations is a nested transaction.
+func txnHasNested(ops []*regattapb.RequestOp) bool {
+	for _, op := range ops {
+		if op.GetRequestTxn() != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func wrapRequestOp(req pb.Message) *regattapb.RequestOp {
 	switch op := req.(type) {
 	case *regattapb.RequestOp_Range:
@@ -101,6 +274,10 @@ func wrapRequestOp(req pb.Message) *regattapb.RequestOp {
 		return &regattapb.RequestOp{Request: &regattapb.RequestOp_RequestPut{RequestPut: op}}
 	case *regattapb.RequestOp_DeleteRange:
 		return &regattapb.RequestOp{Request: &regattapb.RequestOp_RequestDeleteRange{RequestDeleteRange: op}}
+	case *regattapb.RequestOp_Increment:
+		return &regattapb.RequestOp{Request: &regattapb.RequestOp_RequestIncrement{RequestIncrement: op}}
+	case *regattapb.Txn:
+		return &regattapb.RequestOp{Request: &regattapb.RequestOp_RequestTxn{RequestTxn: op}}
 	}
 	return nil
 }
@@ -113,6 +290,10 @@ func wrapResponseOp(req pb.Message) *regattapb.ResponseOp {
 		return &regattapb.ResponseOp{Response: &regattapb.ResponseOp_ResponsePut{ResponsePut: op}}
 	case *regattapb.ResponseOp_DeleteRange:
 		return &regattapb.ResponseOp{Response: &regattapb.ResponseOp_ResponseDeleteRange{ResponseDeleteRange: op}}
+	case *regattapb.ResponseOp_Increment:
+		return &regattapb.ResponseOp{Response: &regattapb.ResponseOp_ResponseIncrement{ResponseIncrement: op}}
+	case *regattapb.ResponseOp_Txn:
+		return &regattapb.ResponseOp{Response: &regattapb.ResponseOp_ResponseTxn{ResponseTxn: op}}
 	}
 	return nil
 }
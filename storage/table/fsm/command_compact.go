@@ -0,0 +1,31 @@
+// Copyright JAMF Software, LLC
+
+package fsm
+
+import (
+	"github.com/jamf/regatta/regattapb"
+)
+
+type commandCompact struct {
+	*regattapb.Command
+}
+
+// handle discards the history of the keys older than the compact revision. The compaction fails if the revision
+// is newer than the current revision or if it is not newer than the revision the table is already compacted to.
+func (c commandCompact) handle(ctx *updateContext) (UpdateResult, *regattapb.CommandResult, error) {
+	if err := ctx.EnsureIndexed(); err != nil {
+		return ResultFailure, nil, err
+	}
+	revision := uint64(c.CompactRevision)
+	compacted, err := readLocalIndex(ctx.batch, sysCompactRevision)
+	if err != nil {
+		return ResultFailure, nil, err
+	}
+	if revision > ctx.revision || revision <= compacted {
+		return ResultFailure, &regattapb.CommandResult{Revision: ctx.index}, nil
+	}
+	if err := compactHistory(ctx, revision); err != nil {
+		return ResultFailure, nil, err
+	}
+	return ResultSuccess, &regattapb.CommandResult{Revision: ctx.index}, nil
+}
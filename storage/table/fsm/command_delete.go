@@ -37,6 +37,9 @@ func handleDelete(ctx *updateContext, del *regattapb.RequestOp_DeleteRange) (*re
 	if err := encodeUserKey(keyBuf, del.Key); err != nil {
 		return nil, err
 	}
+	if err := recordDeleteHistory(ctx, del); err != nil {
+		return nil, err
+	}
 
 	if del.RangeEnd != nil {
 		if del.PrevKv || del.Count {
@@ -3,11 +3,23 @@
 package fsm
 
 import (
+	"encoding/binary"
+
 	"github.com/jamf/regatta/regattapb"
 )
 
-type commandDummy struct{}
+type commandDummy struct {
+	*regattapb.Command
+}
 
 func (c commandDummy) handle(ctx *updateContext) (UpdateResult, *regattapb.CommandResult, error) {
+	// The dummy command with the timestamp is a heartbeat, see HeartbeatRequest.
+	if c.GetTimestamp() != 0 {
+		ts := make([]byte, 8)
+		binary.LittleEndian.PutUint64(ts, uint64(c.GetTimestamp()))
+		if err := ctx.batch.Set(sysHeartbeat, ts, nil); err != nil {
+			return ResultFailure, nil, err
+		}
+	}
 	return ResultSuccess, &regattapb.CommandResult{Revision: ctx.index}, nil
 }
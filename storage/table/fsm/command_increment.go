@@ -0,0 +1,87 @@
+// Copyright JAMF Software, LLC
+
+package fsm
+
+import (
+	"errors"
+	"math"
+	"strconv"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/jamf/regatta/regattapb"
+	serrors "github.com/jamf/regatta/storage/errors"
+)
+
+// handleIncrement adds the delta to the value of the key stored as a decimal string, the initial value is used if the key does not exist.
+// The key keeps its lease, serrors.ErrValueNotInteger or serrors.ErrValueOutOfBounds is returned if the value could not be incremented.
+func handleIncrement(ctx *updateContext, inc *regattapb.RequestOp_Increment) (*regattapb.ResponseOp_Increment, error) {
+	if err := ctx.EnsureIndexed(); err != nil {
+		return nil, err
+	}
+	current, lease, err := readInteger(ctx.batch, inc.Key, inc.Initial)
+	if err != nil {
+		return nil, err
+	}
+	value, err := incrementInteger(current, inc)
+	if err != nil {
+		return nil, err
+	}
+	val := storedValue{modRevision: ctx.revision, lease: lease, data: []byte(strconv.FormatInt(value, 10))}
+	if _, err := handleSet(ctx, inc.Key, val, false); err != nil {
+		return nil, err
+	}
+	return &regattapb.ResponseOp_Increment{Value: value}, nil
+}
+
+// readInteger reads the integer value of the key and its lease, the initial value is returned if the key does not exist.
+func readInteger(reader pebble.Reader, key []byte, initial int64) (int64, uint64, error) {
+	keyBuf := bufferPool.Get()
+	defer bufferPool.Put(keyBuf)
+	if err := encodeUserKey(keyBuf, key); err != nil {
+		return 0, 0, err
+	}
+	raw, closer, err := reader.Get(keyBuf.Bytes())
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return initial, 0, nil
+		}
+		return 0, 0, err
+	}
+	defer func() {
+		_ = closer.Close()
+	}()
+	value, err := decodeValue(raw)
+	if err != nil {
+		return 0, 0, err
+	}
+	i, err := strconv.ParseInt(string(value.data), 10, 64)
+	if err != nil {
+		return 0, 0, serrors.ErrValueNotInteger
+	}
+	return i, value.lease, nil
+}
+
+// incrementInteger adds the delta to the value and checks the result against the bounds of the increment.
+func incrementInteger(value int64, inc *regattapb.RequestOp_Increment) (int64, error) {
+	if (inc.Delta > 0 && value > math.MaxInt64-inc.Delta) || (inc.Delta < 0 && value < math.MinInt64-inc.Delta) {
+		return 0, serrors.ErrValueOutOfBounds
+	}
+	value += inc.Delta
+	if (inc.Min != nil && value < *inc.Min) || (inc.Max != nil && value > *inc.Max) {
+		return 0, serrors.ErrValueOutOfBounds
+	}
+	return value, nil
+}
+
+// txnHasIncrement reports whether any of the operations (including the operations of the nested transactions) is an increment.
+func txnHasIncrement(ops []*regattapb.RequestOp) bool {
+	for _, op := range ops {
+		if op.GetRequestIncrement() != nil {
+			return true
+		}
+		if txn := op.GetRequestTxn(); txn != nil && (txnHasIncrement(txn.Success) || txnHasIncrement(txn.Failure)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,162 @@
+// Copyright JAMF Software, LLC
+
+package fsm
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/vfs"
+	rp "github.com/jamf/regatta/pebble"
+	"github.com/jamf/regatta/regattapb"
+	serrors "github.com/jamf/regatta/storage/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_handleIncrement(t *testing.T) {
+	bound := func(i int64) *int64 { return &i }
+	tests := []struct {
+		name    string
+		value   []byte
+		inc     *regattapb.RequestOp_Increment
+		want    int64
+		wantErr error
+	}{
+		{
+			name: "missing key",
+			inc:  &regattapb.RequestOp_Increment{Key: []byte("key"), Delta: 1},
+			want: 1,
+		},
+		{
+			name: "missing key with initial value",
+			inc:  &regattapb.RequestOp_Increment{Key: []byte("key"), Delta: 5, Initial: 10},
+			want: 15,
+		},
+		{
+			name:  "existing key",
+			value: []byte("-10"),
+			inc:   &regattapb.RequestOp_Increment{Key: []byte("key"), Delta: 3, Initial: 100},
+			want:  -7,
+		},
+		{
+			name:  "decrement",
+			value: []byte("10"),
+			inc:   &regattapb.RequestOp_Increment{Key: []byte("key"), Delta: -10, Min: bound(0)},
+			want:  0,
+		},
+		{
+			name:    "not an integer",
+			value:   []byte("value"),
+			inc:     &regattapb.RequestOp_Increment{Key: []byte("key"), Delta: 1},
+			wantErr: serrors.ErrValueNotInteger,
+		},
+		{
+			name:    "lower bound",
+			value:   []byte("0"),
+			inc:     &regattapb.RequestOp_Increment{Key: []byte("key"), Delta: -1, Min: bound(0)},
+			wantErr: serrors.ErrValueOutOfBounds,
+		},
+		{
+			name:    "upper bound",
+			inc:     &regattapb.RequestOp_Increment{Key: []byte("key"), Delta: 1, Initial: 10, Max: bound(10)},
+			wantErr: serrors.ErrValueOutOfBounds,
+		},
+		{
+			name:    "overflow",
+			value:   []byte("9223372036854775807"),
+			inc:     &regattapb.RequestOp_Increment{Key: []byte("key"), Delta: 1},
+			wantErr: serrors.ErrValueOutOfBounds,
+		},
+		{
+			name:    "underflow",
+			inc:     &regattapb.RequestOp_Increment{Key: []byte("key"), Delta: math.MinInt64, Initial: -1},
+			wantErr: serrors.ErrValueOutOfBounds,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			db, err := rp.OpenDB("/", rp.WithFS(vfs.NewMem()))
+			r.NoError(err)
+			defer db.Close()
+
+			ctx := &updateContext{batch: db.NewBatch(), db: db, index: 1, revision: 1}
+			defer func() { _ = ctx.Close() }()
+			if tt.value != nil {
+				_, err := handlePut(ctx, &regattapb.RequestOp_Put{Key: tt.inc.Key, Value: tt.value})
+				r.NoError(err)
+			}
+
+			res, err := handleIncrement(ctx, tt.inc)
+			if tt.wantErr != nil {
+				r.ErrorIs(err, tt.wantErr)
+				return
+			}
+			r.NoError(err)
+			r.Equal(tt.want, res.Value)
+			v, err := lookup(ctx.batch, &regattapb.RequestOp_Range{Key: tt.inc.Key})
+			r.NoError(err)
+			r.Equal(tt.want, mustParseInt(t, v.Kvs[0].Value))
+		})
+	}
+}
+
+func Test_handleIncrementLease(t *testing.T) {
+	r := require.New(t)
+	db, err := rp.OpenDB("/", rp.WithFS(vfs.NewMem()))
+	r.NoError(err)
+	defer db.Close()
+	r.NoError(db.Set(leaseKey(1), mustMarshallProto(&regattapb.Lease{ID: 1, TTL: 10}), pebble.NoSync))
+
+	ctx := &updateContext{batch: db.NewBatch(), db: db, index: 2, revision: 2}
+	defer func() { _ = ctx.Close() }()
+	_, err = handlePut(ctx, &regattapb.RequestOp_Put{Key: []byte("key"), Value: []byte("1"), Lease: 1})
+	r.NoError(err)
+	_, err = handleIncrement(ctx, &regattapb.RequestOp_Increment{Key: []byte("key"), Delta: 1})
+	r.NoError(err)
+
+	v, err := lookup(ctx.batch, &regattapb.RequestOp_Range{Key: []byte("key")})
+	r.NoError(err)
+	r.Equal(&regattapb.KeyValue{Key: []byte("key"), Value: []byte("2"), CreateRevision: 2, ModRevision: 2, Version: 2, Lease: 1}, v.Kvs[0])
+}
+
+func Test_handleTxnIncrement(t *testing.T) {
+	r := require.New(t)
+	db, err := rp.OpenDB("/", rp.WithFS(vfs.NewMem()))
+	r.NoError(err)
+	defer db.Close()
+
+	ctx := &updateContext{batch: db.NewBatch(), db: db, index: 1, revision: 1}
+	defer func() { _ = ctx.Close() }()
+	_, err = handlePut(ctx, &regattapb.RequestOp_Put{Key: []byte("key_1"), Value: []byte("value")})
+	r.NoError(err)
+
+	succ, res, err := handleTxn(ctx, nil, []*regattapb.RequestOp{
+		{Request: &regattapb.RequestOp_RequestIncrement{RequestIncrement: &regattapb.RequestOp_Increment{Key: []byte("key_2"), Delta: 1}}},
+		{Request: &regattapb.RequestOp_RequestIncrement{RequestIncrement: &regattapb.RequestOp_Increment{Key: []byte("key_2"), Delta: 1}}},
+	}, nil)
+	r.NoError(err)
+	r.True(succ)
+	r.Equal([]*regattapb.ResponseOp{
+		{Response: &regattapb.ResponseOp_ResponseIncrement{ResponseIncrement: &regattapb.ResponseOp_Increment{Value: 1}}},
+		{Response: &regattapb.ResponseOp_ResponseIncrement{ResponseIncrement: &regattapb.ResponseOp_Increment{Value: 2}}},
+	}, res)
+
+	succ, _, err = handleTxn(ctx, nil, []*regattapb.RequestOp{
+		{Request: &regattapb.RequestOp_RequestDeleteRange{RequestDeleteRange: &regattapb.RequestOp_DeleteRange{Key: []byte("key_2")}}},
+		{Request: &regattapb.RequestOp_RequestIncrement{RequestIncrement: &regattapb.RequestOp_Increment{Key: []byte("key_1"), Delta: 1}}},
+	}, nil)
+	r.ErrorIs(err, serrors.ErrValueNotInteger)
+	r.False(succ)
+	v, err := lookup(ctx.batch, &regattapb.RequestOp_Range{Key: []byte("key_2")})
+	r.NoError(err, "no operation of the transaction must be applied")
+	r.Equal([]byte("2"), v.Kvs[0].Value)
+}
+
+func mustParseInt(t *testing.T, b []byte) int64 {
+	i, err := strconv.ParseInt(string(b), 10, 64)
+	require.NoError(t, err)
+	return i
+}
@@ -0,0 +1,73 @@
+// Copyright JAMF Software, LLC
+
+package fsm
+
+import (
+	"github.com/jamf/regatta/regattapb"
+)
+
+type commandPutChunk struct {
+	*regattapb.Command
+}
+
+func (c commandPutChunk) handle(ctx *updateContext) (UpdateResult, *regattapb.CommandResult, error) {
+	if err := ctx.batch.Set(chunkKey(c.Chunk.GetId(), c.Chunk.GetIndex()), c.Chunk.GetData(), nil); err != nil {
+		return ResultFailure, nil, err
+	}
+	return ResultSuccess, &regattapb.CommandResult{Revision: ctx.index}, nil
+}
+
+type commandPutLarge struct {
+	*regattapb.Command
+}
+
+func (c commandPutLarge) handle(ctx *updateContext) (UpdateResult, *regattapb.CommandResult, error) {
+	// Revisions and version are kept if present so that the restored keys keep the revisions of the source table.
+	val := storedValue{
+		createRevision: uint64(c.Kv.CreateRevision),
+		modRevision:    uint64(c.Kv.ModRevision),
+		version:        uint64(c.Kv.Version),
+		lease:          uint64(c.Kv.Lease),
+		large:          &largeValue{id: c.LargeValue.GetId(), chunks: uint64(c.LargeValue.GetChunks()), size: c.LargeValue.GetSize()},
+	}
+	restored := val.modRevision != 0
+	if !restored {
+		val.modRevision = ctx.revision
+	}
+	if err := ctx.EnsureIndexed(); err != nil {
+		return ResultFailure, nil, err
+	}
+	exists, err := leaseExists(ctx.batch, val.lease)
+	if err != nil {
+		return ResultFailure, nil, err
+	}
+	if !exists {
+		// The restored key is stored without the lease as the keys of the PUT_BATCH command are.
+		if !restored {
+			if err := discardChunks(ctx, val.large.id); err != nil {
+				return ResultFailure, nil, err
+			}
+			return ResultLeaseNotFound, &regattapb.CommandResult{Revision: ctx.index}, nil
+		}
+		val.lease = 0
+	}
+	resp, err := handleSet(ctx, c.Kv.Key, val, false)
+	if err != nil {
+		return ResultFailure, nil, err
+	}
+	return ResultSuccess, &regattapb.CommandResult{
+		Revision:  ctx.index,
+		Responses: []*regattapb.ResponseOp{wrapResponseOp(resp)},
+	}, nil
+}
+
+type commandDiscardChunks struct {
+	*regattapb.Command
+}
+
+func (c commandDiscardChunks) handle(ctx *updateContext) (UpdateResult, *regattapb.CommandResult, error) {
+	if err := discardChunks(ctx, c.LargeValue.GetId()); err != nil {
+		return ResultFailure, nil, err
+	}
+	return ResultSuccess, &regattapb.CommandResult{Revision: ctx.index}, nil
+}
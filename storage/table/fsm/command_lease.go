@@ -0,0 +1,123 @@
+// Copyright JAMF Software, LLC
+
+package fsm
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/jamf/regatta/regattapb"
+	serrors "github.com/jamf/regatta/storage/errors"
+)
+
+type commandLeaseGrant struct {
+	*regattapb.Command
+}
+
+// handle grants the lease, if the lease ID is not set the first free ID starting at the revision is assigned.
+// The expiration is kept if set (restored leases) or computed from the command timestamp.
+func (c commandLeaseGrant) handle(ctx *updateContext) (UpdateResult, *regattapb.CommandResult, error) {
+	if err := ctx.EnsureIndexed(); err != nil {
+		return ResultFailure, nil, err
+	}
+	id := uint64(c.GetLease().GetID())
+	if id == 0 {
+		for id = ctx.revision; ; id++ {
+			exists, err := leaseExists(ctx.batch, id)
+			if err != nil {
+				return ResultFailure, nil, err
+			}
+			if !exists {
+				break
+			}
+		}
+	} else {
+		exists, err := leaseExists(ctx.batch, id)
+		if err != nil {
+			return ResultFailure, nil, err
+		}
+		if exists {
+			return ResultFailure, &regattapb.CommandResult{Revision: ctx.index}, nil
+		}
+	}
+
+	lease := &regattapb.Lease{ID: int64(id), TTL: c.GetLease().GetTTL(), ExpiresAt: c.GetLease().GetExpiresAt()}
+	if lease.ExpiresAt == 0 {
+		lease.ExpiresAt = leaseExpiresAt(c.Timestamp, lease.TTL)
+	}
+	if err := writeLease(ctx.batch, lease); err != nil {
+		return ResultFailure, nil, err
+	}
+	return ResultSuccess, &regattapb.CommandResult{Revision: ctx.index, Lease: lease}, nil
+}
+
+type commandLeaseKeepAlive struct {
+	*regattapb.Command
+}
+
+// handle extends the expiration of the lease by its TTL from the command timestamp.
+func (c commandLeaseKeepAlive) handle(ctx *updateContext) (UpdateResult, *regattapb.CommandResult, error) {
+	if err := ctx.EnsureIndexed(); err != nil {
+		return ResultFailure, nil, err
+	}
+	lease, err := readLease(ctx.batch, uint64(c.GetLease().GetID()))
+	if err != nil {
+		if errors.Is(err, serrors.ErrLeaseNotFound) {
+			return ResultLeaseNotFound, &regattapb.CommandResult{Revision: ctx.index}, nil
+		}
+		return ResultFailure, nil, err
+	}
+	lease.ExpiresAt = leaseExpiresAt(c.Timestamp, lease.TTL)
+	if err := writeLease(ctx.batch, lease); err != nil {
+		return ResultFailure, nil, err
+	}
+	return ResultSuccess, &regattapb.CommandResult{Revision: ctx.index, Lease: lease}, nil
+}
+
+type commandLeaseRevoke struct {
+	*regattapb.Command
+}
+
+// handle revokes the lease and deletes the keys attached to it. The revocation fails if the keys attached to the lease
+// differ from the keys in the command batch so that the command always describes the keys it deleted.
+func (c commandLeaseRevoke) handle(ctx *updateContext) (UpdateResult, *regattapb.CommandResult, error) {
+	if err := ctx.EnsureIndexed(); err != nil {
+		return ResultFailure, nil, err
+	}
+	id := uint64(c.GetLease().GetID())
+	if _, err := readLease(ctx.batch, id); err != nil {
+		if errors.Is(err, serrors.ErrLeaseNotFound) {
+			return ResultLeaseNotFound, &regattapb.CommandResult{Revision: ctx.index}, nil
+		}
+		return ResultFailure, nil, err
+	}
+	keys, err := leaseKeys(ctx.batch, id)
+	if err != nil {
+		return ResultFailure, nil, err
+	}
+	if len(keys) != len(c.Batch) {
+		return ResultFailure, &regattapb.CommandResult{Revision: ctx.index}, nil
+	}
+	for i, kv := range c.Batch {
+		if !bytes.Equal(keys[i], kv.Key) {
+			return ResultFailure, &regattapb.CommandResult{Revision: ctx.index}, nil
+		}
+	}
+
+	res := &regattapb.CommandResult{Revision: ctx.index}
+	for _, k := range keys {
+		del, err := handleDelete(ctx, &regattapb.RequestOp_DeleteRange{Key: k})
+		if err != nil {
+			return ResultFailure, nil, err
+		}
+		res.Responses = append(res.Responses, wrapResponseOp(del))
+	}
+	prefix := leaseAttachmentKey(id, nil)
+	if err := ctx.batch.DeleteRange(prefix, incrementRightmostByte(append([]byte(nil), prefix...)), nil); err != nil {
+		return ResultFailure, nil, err
+	}
+	if err := ctx.batch.Delete(leaseKey(id), nil); err != nil {
+		return ResultFailure, nil, err
+	}
+	return ResultSuccess, res, nil
+}
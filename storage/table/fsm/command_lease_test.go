@@ -0,0 +1,213 @@
+// Copyright JAMF Software, LLC
+
+package fsm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/vfs"
+	rp "github.com/jamf/regatta/pebble"
+	"github.com/jamf/regatta/regattapb"
+	serrors "github.com/jamf/regatta/storage/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_commandLeaseGrant(t *testing.T) {
+	r := require.New(t)
+	db, err := rp.OpenDB("/", rp.WithFS(vfs.NewMem()))
+	r.NoError(err)
+	defer db.Close()
+
+	ts := time.Now().UnixNano()
+	tests := []struct {
+		name   string
+		lease  *regattapb.Lease
+		want   *regattapb.Lease
+		result UpdateResult
+	}{
+		{
+			name:   "assign ID from revision",
+			lease:  &regattapb.Lease{TTL: 10},
+			want:   &regattapb.Lease{ID: 1, TTL: 10, ExpiresAt: ts + 10*int64(time.Second)},
+			result: ResultSuccess,
+		},
+		{
+			name:   "assign next free ID",
+			lease:  &regattapb.Lease{TTL: 10},
+			want:   &regattapb.Lease{ID: 2, TTL: 10, ExpiresAt: ts + 10*int64(time.Second)},
+			result: ResultSuccess,
+		},
+		{
+			name:   "requested ID",
+			lease:  &regattapb.Lease{ID: 100, TTL: 5},
+			want:   &regattapb.Lease{ID: 100, TTL: 5, ExpiresAt: ts + 5*int64(time.Second)},
+			result: ResultSuccess,
+		},
+		{
+			name:   "requested ID already granted",
+			lease:  &regattapb.Lease{ID: 100, TTL: 5},
+			result: ResultFailure,
+		},
+		{
+			name:   "restored lease keeps expiration",
+			lease:  &regattapb.Lease{ID: 200, TTL: 5, ExpiresAt: 1000},
+			want:   &regattapb.Lease{ID: 200, TTL: 5, ExpiresAt: 1000},
+			result: ResultSuccess,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			ctx := &updateContext{batch: db.NewBatch(), db: db, index: 1, revision: 1}
+			defer func() { _ = ctx.Close() }()
+
+			result, res, err := commandLeaseGrant{&regattapb.Command{Type: regattapb.Command_LEASE_GRANT, Lease: tt.lease, Timestamp: ts}}.handle(ctx)
+			r.NoError(err)
+			r.Equal(tt.result, result)
+			r.NoError(ctx.Commit())
+			if tt.want == nil {
+				return
+			}
+			r.Equal(tt.want, res.Lease)
+			lease, err := readLease(db, uint64(tt.want.ID))
+			r.NoError(err)
+			r.Equal(tt.want, lease)
+		})
+	}
+}
+
+func Test_commandLeaseKeepAlive(t *testing.T) {
+	r := require.New(t)
+	db, err := rp.OpenDB("/", rp.WithFS(vfs.NewMem()))
+	r.NoError(err)
+	defer db.Close()
+	r.NoError(db.Set(leaseKey(1), mustMarshallProto(&regattapb.Lease{ID: 1, TTL: 10, ExpiresAt: 1000}), pebble.NoSync))
+
+	ctx := &updateContext{batch: db.NewBatch(), db: db, index: 2, revision: 2}
+	result, res, err := commandLeaseKeepAlive{&regattapb.Command{Type: regattapb.Command_LEASE_KEEP_ALIVE, Lease: &regattapb.Lease{ID: 1}, Timestamp: 2000}}.handle(ctx)
+	r.NoError(err)
+	r.Equal(ResultSuccess, result)
+	r.Equal(&regattapb.Lease{ID: 1, TTL: 10, ExpiresAt: 2000 + 10*int64(time.Second)}, res.Lease)
+
+	result, _, err = commandLeaseKeepAlive{&regattapb.Command{Type: regattapb.Command_LEASE_KEEP_ALIVE, Lease: &regattapb.Lease{ID: 2}, Timestamp: 2000}}.handle(ctx)
+	r.NoError(err)
+	r.Equal(ResultLeaseNotFound, result)
+	r.NoError(ctx.Commit())
+
+	lease, err := readLease(db, 1)
+	r.NoError(err)
+	r.Equal(2000+10*int64(time.Second), lease.ExpiresAt)
+}
+
+func Test_commandLeaseRevoke(t *testing.T) {
+	r := require.New(t)
+	db, err := rp.OpenDB("/", rp.WithFS(vfs.NewMem()))
+	r.NoError(err)
+	defer db.Close()
+	r.NoError(db.Set(leaseKey(1), mustMarshallProto(&regattapb.Lease{ID: 1, TTL: 10}), pebble.NoSync))
+
+	ctx := &updateContext{batch: db.NewBatch(), db: db, index: 2, revision: 2}
+	for _, k := range []string{"key_1", "key_2", "key_3"} {
+		_, err := handlePut(ctx, &regattapb.RequestOp_Put{Key: []byte(k), Value: []byte("value"), Lease: 1})
+		r.NoError(err)
+	}
+	// Re-put without the lease detaches the key.
+	_, err = handlePut(ctx, &regattapb.RequestOp_Put{Key: []byte("key_3"), Value: []byte("value")})
+	r.NoError(err)
+	// Deleted key stays in the attachments but it is not attached anymore.
+	_, err = handleDelete(ctx, &regattapb.RequestOp_DeleteRange{Key: []byte("key_2")})
+	r.NoError(err)
+	r.NoError(ctx.Commit())
+
+	keys, err := leaseKeys(db, 1)
+	r.NoError(err)
+	r.Equal([][]byte{[]byte("key_1")}, keys)
+
+	ctx = &updateContext{batch: db.NewBatch(), db: db, index: 3, revision: 3}
+	result, _, err := commandLeaseRevoke{&regattapb.Command{
+		Type:  regattapb.Command_LEASE_REVOKE,
+		Lease: &regattapb.Lease{ID: 1},
+		Batch: []*regattapb.KeyValue{{Key: []byte("key_1")}, {Key: []byte("key_2")}},
+	}}.handle(ctx)
+	r.NoError(err)
+	r.Equal(ResultFailure, result, "revocation must fail if the keys do not match")
+
+	result, res, err := commandLeaseRevoke{&regattapb.Command{
+		Type:  regattapb.Command_LEASE_REVOKE,
+		Lease: &regattapb.Lease{ID: 1},
+		Batch: []*regattapb.KeyValue{{Key: []byte("key_1")}},
+	}}.handle(ctx)
+	r.NoError(err)
+	r.Equal(ResultSuccess, result)
+	r.Len(res.Responses, 1)
+
+	result, _, err = commandLeaseRevoke{&regattapb.Command{Type: regattapb.Command_LEASE_REVOKE, Lease: &regattapb.Lease{ID: 1}}}.handle(ctx)
+	r.NoError(err)
+	r.Equal(ResultLeaseNotFound, result)
+	r.NoError(ctx.Commit())
+
+	_, err = readLease(db, 1)
+	r.ErrorIs(err, serrors.ErrLeaseNotFound)
+	_, _, err = db.Get(mustEncodeUserKey("key_1"))
+	r.ErrorIs(err, pebble.ErrNotFound)
+	_, _, err = db.Get(mustEncodeUserKey("key_3"))
+	r.NoError(err)
+
+	iter := db.NewIter(&pebble.IterOptions{LowerBound: leaseAttachmentKey(1, nil), UpperBound: leaseAttachmentKey(2, nil)})
+	r.False(iter.First(), "attachments must be removed")
+	r.NoError(iter.Close())
+}
+
+func Test_handlePutLease(t *testing.T) {
+	r := require.New(t)
+	db, err := rp.OpenDB("/", rp.WithFS(vfs.NewMem()))
+	r.NoError(err)
+	defer db.Close()
+	r.NoError(db.Set(leaseKey(1), mustMarshallProto(&regattapb.Lease{ID: 1, TTL: 10}), pebble.NoSync))
+	r.NoError(db.Set(leaseKey(2), mustMarshallProto(&regattapb.Lease{ID: 2, TTL: 10}), pebble.NoSync))
+
+	ctx := &updateContext{batch: db.NewBatch(), db: db, index: 3, revision: 3}
+	defer func() { _ = ctx.Close() }()
+
+	_, err = handlePut(ctx, &regattapb.RequestOp_Put{Key: []byte("key_1"), Value: []byte("value"), Lease: 3})
+	r.ErrorIs(err, serrors.ErrLeaseNotFound)
+
+	_, err = handlePut(ctx, &regattapb.RequestOp_Put{Key: []byte("key_1"), Value: []byte("value"), Lease: 1})
+	r.NoError(err)
+	res, err := handlePut(ctx, &regattapb.RequestOp_Put{Key: []byte("key_1"), Value: []byte("value"), Lease: 2, PrevKv: true})
+	r.NoError(err)
+	r.Equal(int64(1), res.PrevKv.Lease)
+
+	keys, err := leaseKeys(ctx.batch, 1)
+	r.NoError(err)
+	r.Empty(keys)
+	keys, err = leaseKeys(ctx.batch, 2)
+	r.NoError(err)
+	r.Equal([][]byte{[]byte("key_1")}, keys)
+
+	succ, _, err := handleTxn(ctx, nil, []*regattapb.RequestOp{
+		{Request: &regattapb.RequestOp_RequestDeleteRange{RequestDeleteRange: &regattapb.RequestOp_DeleteRange{Key: []byte("key_1")}}},
+		{Request: &regattapb.RequestOp_RequestPut{RequestPut: &regattapb.RequestOp_Put{Key: []byte("key_2"), Lease: 3}}},
+	}, nil)
+	r.ErrorIs(err, serrors.ErrLeaseNotFound)
+	r.False(succ)
+	_, closer, err := ctx.batch.Get(mustEncodeUserKey("key_1"))
+	r.NoError(err, "no operation of the transaction must be applied")
+	r.NoError(closer.Close())
+}
+
+func Test_expiredLeases(t *testing.T) {
+	r := require.New(t)
+	db, err := rp.OpenDB("/", rp.WithFS(vfs.NewMem()))
+	r.NoError(err)
+	defer db.Close()
+	r.NoError(db.Set(leaseKey(1), mustMarshallProto(&regattapb.Lease{ID: 1, TTL: 10, ExpiresAt: 1000}), pebble.NoSync))
+	r.NoError(db.Set(leaseKey(2), mustMarshallProto(&regattapb.Lease{ID: 2, TTL: 10, ExpiresAt: 2000}), pebble.NoSync))
+	r.NoError(db.Set(leaseKey(3), mustMarshallProto(&regattapb.Lease{ID: 3, TTL: 10, ExpiresAt: 3000}), pebble.NoSync))
+
+	ids, err := expiredLeases(db, time.Unix(0, 2000))
+	r.NoError(err)
+	r.Equal([]int64{1, 2}, ids)
+}
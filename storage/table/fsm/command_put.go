@@ -7,6 +7,7 @@ import (
 
 	"github.com/cockroachdb/pebble"
 	"github.com/jamf/regatta/regattapb"
+	serrors "github.com/jamf/regatta/storage/errors"
 )
 
 type commandPut struct {
@@ -18,8 +19,12 @@ func (c commandPut) handle(ctx *updateContext) (UpdateResult, *regattapb.Command
 		Key:    c.Kv.Key,
 		Value:  c.Kv.Value,
 		PrevKv: c.PrevKvs,
+		Lease:  c.Kv.Lease,
 	})
 	if err != nil {
+		if errors.Is(err, serrors.ErrLeaseNotFound) {
+			return ResultLeaseNotFound, &regattapb.CommandResult{Revision: ctx.index}, nil
+		}
 		return ResultFailure, nil, err
 	}
 	return ResultSuccess, &regattapb.CommandResult{
@@ -28,26 +33,91 @@ func (c commandPut) handle(ctx *updateContext) (UpdateResult, *regattapb.Command
 	}, nil
 }
 
+// handlePut stores the value under the key, serrors.ErrLeaseNotFound is returned (and nothing is stored) if the lease does not exist.
 func handlePut(ctx *updateContext, put *regattapb.RequestOp_Put) (*regattapb.ResponseOp_Put, error) {
+	if err := ctx.EnsureIndexed(); err != nil {
+		return nil, err
+	}
+	exists, err := leaseExists(ctx.batch, uint64(put.Lease))
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, serrors.ErrLeaseNotFound
+	}
+	return handleSet(ctx, put.Key, storedValue{modRevision: ctx.revision, lease: uint64(put.Lease), data: put.Value}, put.PrevKv)
+}
+
+// handleSet stores the value under the key, if the create revision is not set it is kept from the existing key
+// or set to the mod revision for a newly created key. If the version is not set the version of the key is incremented.
+// The key is attached to the lease of the value (which must exist) and detached from the lease of the previous value.
+func handleSet(ctx *updateContext, k []byte, val storedValue, prevKv bool) (*regattapb.ResponseOp_Put, error) {
 	resp := &regattapb.ResponseOp_Put{}
 	keyBuf := bufferPool.Get()
 	defer bufferPool.Put(keyBuf)
-	if err := encodeUserKey(keyBuf, put.Key); err != nil {
+	if err := encodeUserKey(keyBuf, k); err != nil {
 		return nil, err
 	}
-	if put.PrevKv {
-		if err := ctx.EnsureIndexed(); err != nil {
-			return nil, err
+	if err := ctx.EnsureIndexed(); err != nil {
+		return nil, err
+	}
+	var prevLease uint64
+	err := func() error {
+		raw, closer, err := ctx.batch.Get(keyBuf.Bytes())
+		if err != nil {
+			if errors.Is(err, pebble.ErrNotFound) {
+				return nil
+			}
+			return err
 		}
-		rng, err := singleLookup(ctx.batch, &regattapb.RequestOp_Range{Key: put.Key})
-		if err != nil && !errors.Is(err, pebble.ErrNotFound) {
-			return nil, err
+		defer func() {
+			_ = closer.Close()
+		}()
+		prev, err := decodeValue(raw)
+		if err != nil {
+			return err
+		}
+		if val.createRevision == 0 {
+			val.createRevision = prev.createRevision
+		}
+		if val.version == 0 {
+			val.version = prev.version + 1
+		}
+		prevLease = prev.lease
+		if err := recordHistory(ctx, k, prev); err != nil {
+			return err
+		}
+		if prevKv {
+			resp.PrevKv = newKeyValue(k, prev)
 		}
-		if !errors.Is(err, pebble.ErrNotFound) {
-			resp.PrevKv = rng.Kvs[0]
+		return nil
+	}()
+	if err != nil {
+		return nil, err
+	}
+	if val.createRevision == 0 {
+		val.createRevision = val.modRevision
+	}
+	if val.version == 0 {
+		val.version = 1
+	}
+	if prevLease != val.lease {
+		if prevLease != 0 {
+			if err := ctx.batch.Delete(leaseAttachmentKey(prevLease, k), nil); err != nil {
+				return nil, err
+			}
+		}
+		if val.lease != 0 {
+			if err := ctx.batch.Set(leaseAttachmentKey(val.lease, k), nil, nil); err != nil {
+				return nil, err
+			}
 		}
 	}
-	if err := ctx.batch.Set(keyBuf.Bytes(), put.Value, nil); err != nil {
+
+	valBuf := bufferPool.Get()
+	defer bufferPool.Put(valBuf)
+	valBuf.Write(appendValue(valBuf.AvailableBuffer(), val))
+	if err := ctx.batch.Set(keyBuf.Bytes(), valBuf.Bytes(), nil); err != nil {
 		return nil, err
 	}
 	return resp, nil
@@ -58,19 +128,28 @@ type commandPutBatch struct {
 }
 
 func (c commandPutBatch) handle(ctx *updateContext) (UpdateResult, *regattapb.CommandResult, error) {
-	req := make([]*regattapb.RequestOp_Put, len(c.Batch))
-	for i, kv := range c.Batch {
-		req[i] = &regattapb.RequestOp_Put{
-			Key:   kv.Key,
-			Value: kv.Value,
-		}
-	}
-	rop, err := handlePutBatch(ctx, req)
-	if err != nil {
-		return ResultFailure, nil, err
-	}
 	res := make([]*regattapb.ResponseOp, 0, len(c.Batch))
-	for _, put := range rop {
+	for _, kv := range c.Batch {
+		// Revisions and version are kept if present so that the restored keys keep the revisions of the source table.
+		val := storedValue{createRevision: uint64(kv.CreateRevision), modRevision: uint64(kv.ModRevision), version: uint64(kv.Version), lease: uint64(kv.Lease), data: kv.Value}
+		if val.modRevision == 0 {
+			val.modRevision = ctx.revision
+		}
+		// The batch is not conditional, the key is stored without the lease if the lease does not exist.
+		if err := ctx.EnsureIndexed(); err != nil {
+			return ResultFailure, nil, err
+		}
+		exists, err := leaseExists(ctx.batch, val.lease)
+		if err != nil {
+			return ResultFailure, nil, err
+		}
+		if !exists {
+			val.lease = 0
+		}
+		put, err := handleSet(ctx, kv.Key, val, false)
+		if err != nil {
+			return ResultFailure, nil, err
+		}
 		res = append(res, wrapResponseOp(put))
 	}
 	return ResultSuccess, &regattapb.CommandResult{
@@ -21,9 +21,10 @@ func Test_handlePut(t *testing.T) {
 	}
 
 	c := &updateContext{
-		batch: db.NewBatch(),
-		db:    db,
-		index: 1,
+		batch:    db.NewBatch(),
+		db:       db,
+		index:    1,
+		revision: 1,
 	}
 	defer func() { _ = c.Close() }()
 
@@ -36,6 +37,14 @@ func Test_handlePut(t *testing.T) {
 	r.NoError(err)
 	r.NoError(c.Commit())
 
+	c = &updateContext{
+		batch:    db.NewBatch(),
+		db:       db,
+		index:    2,
+		revision: 2,
+	}
+	defer func() { _ = c.Close() }()
+
 	// Make the PUT update.
 	req = &regattapb.RequestOp_Put{
 		Key:    []byte("key_1"),
@@ -44,7 +53,7 @@ func Test_handlePut(t *testing.T) {
 	}
 	res, err := handlePut(c, req)
 	r.NoError(err)
-	r.Equal(&regattapb.ResponseOp_Put{PrevKv: &regattapb.KeyValue{Key: []byte("key_1"), Value: []byte("value_1")}}, res)
+	r.Equal(&regattapb.ResponseOp_Put{PrevKv: &regattapb.KeyValue{Key: []byte("key_1"), Value: []byte("value_1"), CreateRevision: 1, ModRevision: 1, Version: 1}}, res)
 	r.NoError(c.Commit())
 
 	iter := db.NewIter(allUserKeysOpts())
@@ -54,7 +63,9 @@ func Test_handlePut(t *testing.T) {
 	decodeKey(t, iter, k)
 
 	r.Equal(req.Key, k.Key)
-	r.Equal(req.Value, iter.Value())
+	val, err := decodeValue(iter.Value())
+	r.NoError(err)
+	r.Equal(storedValue{createRevision: 1, modRevision: 2, version: 2, data: req.Value}, val)
 
 	// Assert that there are no more user keys.
 	iter.Next()
@@ -76,9 +87,10 @@ func Test_handlePutBatch(t *testing.T) {
 	}
 
 	c := &updateContext{
-		batch: db.NewBatch(),
-		db:    db,
-		index: 1,
+		batch:    db.NewBatch(),
+		db:       db,
+		index:    1,
+		revision: 1,
 	}
 	defer func() { _ = c.Close() }()
 
@@ -103,7 +115,9 @@ func Test_handlePutBatch(t *testing.T) {
 		decodeKey(t, iter, k)
 
 		r.Equal(ops[i].Key, k.Key)
-		r.Equal(ops[i].Value, iter.Value())
+		val, err := decodeValue(iter.Value())
+		r.NoError(err)
+		r.Equal(storedValue{createRevision: 1, modRevision: 1, version: 1, data: ops[i].Value}, val)
 		r.NoError(iter.Error())
 
 		i++
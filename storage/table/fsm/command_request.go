@@ -0,0 +1,114 @@
+// Copyright JAMF Software, LLC
+
+package fsm
+
+import (
+	"bytes"
+	"crypto/sha256"
+
+	"github.com/jamf/regatta/regattapb"
+)
+
+// commandIdempotent applies the wrapped command proposed with the request ID at most once within the deduplication window.
+type commandIdempotent struct {
+	*regattapb.Command
+	cmd command
+}
+
+func (c commandIdempotent) handle(ctx *updateContext) (UpdateResult, *regattapb.CommandResult, error) {
+	if err := ctx.EnsureIndexed(); err != nil {
+		return ResultFailure, nil, err
+	}
+	hash, err := commandHash(c.Command)
+	if err != nil {
+		return ResultFailure, nil, err
+	}
+	prev, err := readRequestResult(ctx.batch, c.RequestId)
+	if err != nil {
+		return ResultFailure, nil, err
+	}
+	if prev != nil && ctx.revision < prev.Revision+requestResultRetention {
+		ctx.duplicate = true
+		if !bytes.Equal(prev.CommandHash, hash) {
+			return ResultRequestMismatch, &regattapb.CommandResult{Revision: ctx.index}, nil
+		}
+		res := prev.CommandResult
+		if res == nil {
+			res = &regattapb.CommandResult{}
+		}
+		return UpdateResult(prev.Result), res, nil
+	}
+
+	result, res, err := c.cmd.handle(ctx)
+	if err != nil {
+		return result, res, err
+	}
+	stored := res
+	if res.SizeVT() > maxRequestResultSize {
+		stored = &regattapb.CommandResult{Responses: trimResponses(res.Responses), Revision: res.Revision, Lease: res.Lease}
+	}
+	if err := storeRequestResult(ctx, &regattapb.RequestResult{
+		RequestId:     c.RequestId,
+		Revision:      ctx.revision,
+		Result:        uint64(result),
+		CommandResult: stored,
+		CommandHash:   hash,
+	}); err != nil {
+		return ResultFailure, nil, err
+	}
+	return result, res, nil
+}
+
+// commandHash hashes the command without the fields which differ between the proposals of the same request.
+func commandHash(cmd *regattapb.Command) ([]byte, error) {
+	leaderIndex, timestamp, traceparent := cmd.LeaderIndex, cmd.Timestamp, cmd.Traceparent
+	cmd.LeaderIndex, cmd.Timestamp, cmd.Traceparent = nil, 0, ""
+	bts, err := cmd.MarshalVT()
+	cmd.LeaderIndex, cmd.Timestamp, cmd.Traceparent = leaderIndex, timestamp, traceparent
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.Sum256(bts)
+	return h[:], nil
+}
+
+// trimResponses returns the copy of the responses without the key-value pairs, the counts are kept.
+func trimResponses(responses []*regattapb.ResponseOp) []*regattapb.ResponseOp {
+	trimmed := make([]*regattapb.ResponseOp, 0, len(responses))
+	for _, op := range responses {
+		switch r := op.Response.(type) {
+		case *regattapb.ResponseOp_ResponseRange:
+			op = &regattapb.ResponseOp{Response: &regattapb.ResponseOp_ResponseRange{ResponseRange: &regattapb.ResponseOp_Range{
+				More:  r.ResponseRange.More,
+				Count: r.ResponseRange.Count,
+			}}}
+		case *regattapb.ResponseOp_ResponsePut:
+			op = &regattapb.ResponseOp{Response: &regattapb.ResponseOp_ResponsePut{ResponsePut: &regattapb.ResponseOp_Put{}}}
+		case *regattapb.ResponseOp_ResponseDeleteRange:
+			op = &regattapb.ResponseOp{Response: &regattapb.ResponseOp_ResponseDeleteRange{ResponseDeleteRange: &regattapb.ResponseOp_DeleteRange{
+				Deleted: r.ResponseDeleteRange.Deleted,
+			}}}
+		case *regattapb.ResponseOp_ResponseTxn:
+			op = &regattapb.ResponseOp{Response: &regattapb.ResponseOp_ResponseTxn{ResponseTxn: &regattapb.ResponseOp_Txn{
+				Succeeded: r.ResponseTxn.Succeeded,
+				Responses: trimResponses(r.ResponseTxn.Responses),
+			}}}
+		}
+		trimmed = append(trimmed, op)
+	}
+	return trimmed
+}
+
+// conditional the results of the commands with the request ID are always stored so that the duplicates could be told apart.
+func (c commandIdempotent) conditional() bool { return true }
+
+type commandRequestResult struct {
+	*regattapb.Command
+}
+
+func (c commandRequestResult) handle(ctx *updateContext) (UpdateResult, *regattapb.CommandResult, error) {
+	if err := storeRequestResult(ctx, c.RequestResult); err != nil {
+		return ResultFailure, nil, err
+	}
+	return ResultSuccess, &regattapb.CommandResult{Revision: ctx.index}, nil
+}
@@ -0,0 +1,255 @@
+// Copyright JAMF Software, LLC
+
+package fsm
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/pebble/vfs"
+	rp "github.com/jamf/regatta/pebble"
+	"github.com/jamf/regatta/regattapb"
+	sm "github.com/lni/dragonboat/v4/statemachine"
+	"github.com/stretchr/testify/require"
+)
+
+func incrementCommand(requestID []byte) *regattapb.Command {
+	return &regattapb.Command{
+		Table: []byte(testTable),
+		Type:  regattapb.Command_TXN,
+		Txn: &regattapb.Txn{Success: []*regattapb.RequestOp{{Request: &regattapb.RequestOp_RequestIncrement{
+			RequestIncrement: &regattapb.RequestOp_Increment{Key: []byte("counter"), Delta: 1},
+		}}}},
+		RequestId: requestID,
+	}
+}
+
+func TestSM_Update_RequestID(t *testing.T) {
+	r := require.New(t)
+	p := emptySM()
+	defer func() {
+		r.NoError(p.Close())
+	}()
+
+	entries := []sm.Entry{
+		{Index: 1, Cmd: mustMarshallProto(incrementCommand([]byte("first")))},
+		{Index: 2, Cmd: mustMarshallProto(incrementCommand([]byte("first")))},
+		{Index: 3, Cmd: mustMarshallProto(incrementCommand(nil))},
+		{Index: 4, Cmd: mustMarshallProto(incrementCommand([]byte("second")))},
+		{Index: 5, Cmd: mustMarshallProto(incrementCommand([]byte("first")))},
+	}
+	updated, err := p.Update(entries)
+	r.NoError(err)
+
+	t.Log("retries return the result of the original command")
+	results := make([]*regattapb.CommandResult, len(updated))
+	for i, e := range updated {
+		r.Equal(ResultSuccess, UpdateResult(e.Result.Value))
+		results[i] = &regattapb.CommandResult{}
+		r.NoError(results[i].UnmarshalVT(e.Result.Data))
+	}
+	r.Equal(uint64(1), results[0].Revision)
+	r.Equal(results[0].Revision, results[1].Revision)
+	r.Equal(results[0].Responses, results[1].Responses)
+	r.Equal(results[0].Responses, results[4].Responses)
+	r.Equal(int64(3), results[3].Responses[0].GetResponseIncrement().Value)
+
+	t.Log("retries are applied only once")
+	res, err := p.Lookup(&regattapb.RequestOp_Range{Key: []byte("counter")})
+	r.NoError(err)
+	r.Equal([]byte("3"), res.(*regattapb.ResponseOp_Range).Kvs[0].Value)
+
+	t.Log("retries are stored as duplicates")
+	for index, expected := range map[uint64]UpdateResult{1: ResultSuccess, 2: ResultDuplicate, 4: ResultSuccess, 5: ResultDuplicate} {
+		res, err = p.Lookup(TxnResultRequest{Index: index})
+		r.NoError(err)
+		r.Equal(expected, res.(*TxnResultResponse).Result, "index %d", index)
+	}
+
+	t.Log("request results are kept in the snapshot")
+	w := &commandsWriter{}
+	_, err = p.Lookup(SnapshotRequest{Writer: w, Stopper: make(chan struct{})})
+	r.NoError(err)
+	restored := emptySM()
+	defer func() {
+		r.NoError(restored.Close())
+	}()
+	entries = entries[:0]
+	for i, cmd := range w.cmds {
+		entries = append(entries, sm.Entry{Index: uint64(10 + i), Cmd: mustMarshallProto(cmd)})
+	}
+	retry := uint64(10 + len(w.cmds))
+	entries = append(entries, sm.Entry{Index: retry, Cmd: mustMarshallProto(incrementCommand([]byte("second")))})
+	updated, err = restored.Update(entries)
+	r.NoError(err)
+	retried := &regattapb.CommandResult{}
+	r.NoError(retried.UnmarshalVT(updated[len(updated)-1].Result.Data))
+	r.Equal(results[3].Revision, retried.Revision)
+	r.Equal(results[3].Responses, retried.Responses)
+	res, err = restored.Lookup(&regattapb.RequestOp_Range{Key: []byte("counter")})
+	r.NoError(err)
+	r.Equal([]byte("3"), res.(*regattapb.ResponseOp_Range).Kvs[0].Value)
+}
+
+func TestSM_Update_RequestIDMismatch(t *testing.T) {
+	r := require.New(t)
+	p := emptySM()
+	defer func() {
+		r.NoError(p.Close())
+	}()
+
+	put := &regattapb.Command{
+		Table:     []byte(testTable),
+		Type:      regattapb.Command_PUT,
+		Kv:        &regattapb.KeyValue{Key: []byte("key"), Value: []byte("value")},
+		RequestId: []byte("first"),
+	}
+	retry := &regattapb.Command{
+		Table:       []byte(testTable),
+		Type:        regattapb.Command_PUT,
+		Kv:          &regattapb.KeyValue{Key: []byte("key"), Value: []byte("value")},
+		RequestId:   []byte("first"),
+		Timestamp:   42,
+		Traceparent: "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+	}
+	updated, err := p.Update([]sm.Entry{
+		{Index: 1, Cmd: mustMarshallProto(put)},
+		{Index: 2, Cmd: mustMarshallProto(retry)},
+		{Index: 3, Cmd: mustMarshallProto(incrementCommand([]byte("first")))},
+	})
+	r.NoError(err)
+	r.Equal(ResultSuccess, UpdateResult(updated[0].Result.Value))
+
+	t.Log("retry proposed again is a duplicate")
+	r.Equal(ResultSuccess, UpdateResult(updated[1].Result.Value))
+	res, err := p.Lookup(TxnResultRequest{Index: 2})
+	r.NoError(err)
+	r.Equal(ResultDuplicate, res.(*TxnResultResponse).Result)
+
+	t.Log("different command with the same request ID is rejected")
+	r.Equal(ResultRequestMismatch, UpdateResult(updated[2].Result.Value))
+	res, err = p.Lookup(TxnResultRequest{Index: 3})
+	r.NoError(err)
+	r.Equal(ResultDuplicate, res.(*TxnResultResponse).Result)
+	res, err = p.Lookup(&regattapb.RequestOp_Range{Key: []byte("counter")})
+	r.NoError(err)
+	r.Empty(res.(*regattapb.ResponseOp_Range).Kvs)
+}
+
+func TestSM_Update_RequestIDLargeResult(t *testing.T) {
+	r := require.New(t)
+	p := emptySM()
+	defer func() {
+		r.NoError(p.Close())
+	}()
+
+	large := make([]byte, maxRequestResultSize)
+	put := func(value []byte, requestID []byte) *regattapb.Command {
+		return &regattapb.Command{
+			Table:     []byte(testTable),
+			Type:      regattapb.Command_PUT,
+			Kv:        &regattapb.KeyValue{Key: []byte("key"), Value: value},
+			PrevKvs:   true,
+			RequestId: requestID,
+		}
+	}
+	updated, err := p.Update([]sm.Entry{
+		{Index: 1, Cmd: mustMarshallProto(put(large, nil))},
+		{Index: 2, Cmd: mustMarshallProto(put([]byte("value"), []byte("first")))},
+		{Index: 3, Cmd: mustMarshallProto(put([]byte("value"), []byte("first")))},
+	})
+	r.NoError(err)
+
+	t.Log("the applied command returns the previous value")
+	applied := &regattapb.CommandResult{}
+	r.NoError(applied.UnmarshalVT(updated[1].Result.Data))
+	r.Equal(large, applied.Responses[0].GetResponsePut().PrevKv.Value)
+
+	t.Log("the retry returns the stored result without the previous value")
+	retried := &regattapb.CommandResult{}
+	r.NoError(retried.UnmarshalVT(updated[2].Result.Data))
+	r.Equal(applied.Revision, retried.Revision)
+	r.NotNil(retried.Responses[0].GetResponsePut())
+	r.Nil(retried.Responses[0].GetResponsePut().PrevKv)
+}
+
+func Test_trimResponses(t *testing.T) {
+	kvs := []*regattapb.KeyValue{{Key: []byte("key"), Value: []byte("value")}}
+	tests := []struct {
+		name      string
+		responses []*regattapb.ResponseOp
+		want      []*regattapb.ResponseOp
+	}{
+		{
+			name: "range",
+			responses: []*regattapb.ResponseOp{{Response: &regattapb.ResponseOp_ResponseRange{
+				ResponseRange: &regattapb.ResponseOp_Range{Kvs: kvs, More: true, Count: 2},
+			}}},
+			want: []*regattapb.ResponseOp{{Response: &regattapb.ResponseOp_ResponseRange{
+				ResponseRange: &regattapb.ResponseOp_Range{More: true, Count: 2},
+			}}},
+		},
+		{
+			name: "put",
+			responses: []*regattapb.ResponseOp{{Response: &regattapb.ResponseOp_ResponsePut{
+				ResponsePut: &regattapb.ResponseOp_Put{PrevKv: kvs[0]},
+			}}},
+			want: []*regattapb.ResponseOp{{Response: &regattapb.ResponseOp_ResponsePut{
+				ResponsePut: &regattapb.ResponseOp_Put{},
+			}}},
+		},
+		{
+			name: "nested delete",
+			responses: []*regattapb.ResponseOp{{Response: &regattapb.ResponseOp_ResponseTxn{ResponseTxn: &regattapb.ResponseOp_Txn{
+				Succeeded: true,
+				Responses: []*regattapb.ResponseOp{{Response: &regattapb.ResponseOp_ResponseDeleteRange{
+					ResponseDeleteRange: &regattapb.ResponseOp_DeleteRange{Deleted: 1, PrevKvs: kvs},
+				}}},
+			}}}},
+			want: []*regattapb.ResponseOp{{Response: &regattapb.ResponseOp_ResponseTxn{ResponseTxn: &regattapb.ResponseOp_Txn{
+				Succeeded: true,
+				Responses: []*regattapb.ResponseOp{{Response: &regattapb.ResponseOp_ResponseDeleteRange{
+					ResponseDeleteRange: &regattapb.ResponseOp_DeleteRange{Deleted: 1},
+				}}},
+			}}}},
+		},
+		{
+			name: "increment",
+			responses: []*regattapb.ResponseOp{{Response: &regattapb.ResponseOp_ResponseIncrement{
+				ResponseIncrement: &regattapb.ResponseOp_Increment{Value: 5},
+			}}},
+			want: []*regattapb.ResponseOp{{Response: &regattapb.ResponseOp_ResponseIncrement{
+				ResponseIncrement: &regattapb.ResponseOp_Increment{Value: 5},
+			}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, trimResponses(tt.responses))
+		})
+	}
+}
+
+func Test_pruneRequestResults(t *testing.T) {
+	r := require.New(t)
+	db, err := rp.OpenDB("/", rp.WithFS(vfs.NewMem()))
+	r.NoError(err)
+	defer func() { _ = db.Close() }()
+
+	c := &updateContext{batch: db.NewBatch(), db: db, index: 1, revision: 1}
+	r.NoError(storeRequestResult(c, &regattapb.RequestResult{RequestId: []byte("old"), Revision: 1}))
+	r.NoError(storeRequestResult(c, &regattapb.RequestResult{RequestId: []byte("reused"), Revision: 2}))
+	r.NoError(storeRequestResult(c, &regattapb.RequestResult{RequestId: []byte("reused"), Revision: 5}))
+	r.NoError(storeRequestResult(c, &regattapb.RequestResult{RequestId: []byte("new"), Revision: 6}))
+	r.NoError(c.Commit())
+
+	c = &updateContext{batch: db.NewBatch(), db: db, index: 2, revision: 2}
+	r.NoError(pruneRequestResults(c, 5))
+	r.NoError(c.Commit())
+
+	var ids []string
+	r.NoError(readRequestResults(db, func(res *regattapb.RequestResult) error {
+		ids = append(ids, string(res.RequestId))
+		return nil
+	}))
+	r.Equal([]string{"new", "reused"}, ids)
+}
@@ -13,6 +13,7 @@ type commandSequence struct {
 func (c commandSequence) handle(ctx *updateContext) (UpdateResult, *regattapb.CommandResult, error) {
 	res := &regattapb.CommandResult{Revision: ctx.index}
 	for _, cmd := range c.Sequence {
+		ctx.setRevision(cmd)
 		_, cmdRes, err := wrapCommand(cmd).handle(ctx)
 		if err != nil {
 			return ResultFailure, nil, err
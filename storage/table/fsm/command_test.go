@@ -20,8 +20,9 @@ func TestUpdateContext_Parse(t *testing.T) {
 		entry sm.Entry
 	}
 	type want struct {
-		index uint64
-		cmd   command
+		index    uint64
+		revision uint64
+		cmd      command
 	}
 	tests := []struct {
 		name    string
@@ -37,12 +38,17 @@ func TestUpdateContext_Parse(t *testing.T) {
 		{
 			name: "empty command with index",
 			args: args{entry: sm.Entry{Index: 200}},
-			want: want{index: 200, cmd: commandPut{}},
+			want: want{index: 200, revision: 200, cmd: commandPut{}},
 		},
 		{
 			name: "put command with index",
 			args: args{entry: sm.Entry{Index: 200, Cmd: mustMarshallProto(&regattapb.Command{Type: regattapb.Command_PUT, Table: []byte("test"), Kv: &regattapb.KeyValue{Key: []byte("key")}})}},
-			want: want{index: 200, cmd: commandPut{}},
+			want: want{index: 200, revision: 200, cmd: commandPut{}},
+		},
+		{
+			name: "replicated put command with leader index",
+			args: args{entry: sm.Entry{Index: 200, Cmd: mustMarshallProto(&regattapb.Command{Type: regattapb.Command_PUT, Table: []byte("test"), Kv: &regattapb.KeyValue{Key: []byte("key")}, LeaderIndex: &two})}},
+			want: want{index: 200, revision: 2, cmd: commandPut{}},
 		},
 	}
 	for _, tt := range tests {
@@ -56,6 +62,7 @@ func TestUpdateContext_Parse(t *testing.T) {
 			r.NoError(err)
 			r.IsType(tt.want.cmd, cmd)
 			r.Equal(tt.want.index, uc.index)
+			r.Equal(tt.want.revision, uc.revision)
 		})
 	}
 }
@@ -83,6 +90,42 @@ func TestUpdateContext_EnsureIndexed(t *testing.T) {
 	r.Equal(true, uc.batch.Indexed())
 }
 
+func TestUpdateContext_Rollback(t *testing.T) {
+	r := require.New(t)
+	db, err := rp.OpenDB("/", rp.WithFS(vfs.NewMem()))
+	r.NoError(err)
+	uc := updateContext{
+		db:    db,
+		batch: db.NewBatch(),
+	}
+
+	t.Log("rollback without writes keeps the batch")
+	sp := uc.Savepoint()
+	batch := uc.batch
+	r.NoError(uc.Rollback(sp))
+	r.Same(batch, uc.batch)
+
+	t.Log("rollback discards the writes made since the savepoint")
+	r.NoError(uc.batch.Set([]byte("kept"), []byte("value"), nil))
+	r.NoError(uc.EnsureIndexed())
+	sp = uc.Savepoint()
+	uc.nestedTxns = append(uc.nestedTxns, true)
+	r.NoError(uc.batch.Set([]byte("discarded"), []byte("value"), nil))
+	r.NoError(uc.batch.DeleteRange([]byte("a"), []byte("z"), nil))
+	r.NoError(uc.Rollback(sp))
+	r.True(uc.batch.Indexed())
+	r.Empty(uc.nestedTxns)
+	r.Equal(uint32(1), uc.batch.Count())
+	_, closer, err := uc.batch.Get([]byte("kept"))
+	r.NoError(err)
+	r.NoError(closer.Close())
+	_, _, err = uc.batch.Get([]byte("discarded"))
+	r.ErrorIs(err, pebble.ErrNotFound)
+
+	r.NoError(uc.Close())
+	r.NoError(db.Close())
+}
+
 func TestUpdateContext_Commit(t *testing.T) {
 	r := require.New(t)
 	db, err := rp.OpenDB("/", rp.WithFS(vfs.NewMem()))
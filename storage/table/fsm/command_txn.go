@@ -8,6 +8,7 @@ import (
 
 	"github.com/cockroachdb/pebble"
 	"github.com/jamf/regatta/regattapb"
+	serrors "github.com/jamf/regatta/storage/errors"
 )
 
 type commandTxn struct {
@@ -17,6 +18,14 @@ type commandTxn struct {
 func (c commandTxn) handle(ctx *updateContext) (UpdateResult, *regattapb.CommandResult, error) {
 	succ, rop, err := handleTxn(ctx, c.Txn.Compare, c.Txn.Success, c.Txn.Failure)
 	if err != nil {
+		switch {
+		case errors.Is(err, serrors.ErrLeaseNotFound):
+			return ResultLeaseNotFound, &regattapb.CommandResult{Revision: ctx.index}, nil
+		case errors.Is(err, serrors.ErrValueNotInteger):
+			return ResultValueNotInteger, &regattapb.CommandResult{Revision: ctx.index}, nil
+		case errors.Is(err, serrors.ErrValueOutOfBounds):
+			return ResultValueOutOfBounds, &regattapb.CommandResult{Revision: ctx.index}, nil
+		}
 		return ResultFailure, nil, err
 	}
 	result := ResultSuccess
@@ -27,7 +36,26 @@ func (c commandTxn) handle(ctx *updateContext) (UpdateResult, *regattapb.Command
 }
 
 // handleTxn handle transaction operation, returns if the operation succeeded (if success, or fail was applied) list or respective results and error.
+// If any of the applied operations refers to a lease that does not exist serrors.ErrLeaseNotFound is returned and no operation is applied.
+// Likewise no operation is applied if any of the increments fails with serrors.ErrValueNotInteger or serrors.ErrValueOutOfBounds.
+// The operations of the nested transactions are applied recursively, a failure of a nested transaction fails the whole transaction.
 func handleTxn(ctx *updateContext, compare []*regattapb.Compare, success, fail []*regattapb.RequestOp) (bool, []*regattapb.ResponseOp, error) {
+	// The increments and the nested transactions could fail only once the preceding operations are applied,
+	// the operations applied before the failure are rolled back.
+	sp := ctx.Savepoint()
+	ok, res, err := applyTxn(ctx, compare, success, fail)
+	if err != nil {
+		if err := ctx.Rollback(sp); err != nil {
+			return false, nil, err
+		}
+		return false, nil, err
+	}
+	return ok, res, nil
+}
+
+// applyTxn applies the operations of the transaction (or of the nested transaction), the operations applied
+// before a failure are kept in the batch.
+func applyTxn(ctx *updateContext, compare []*regattapb.Compare, success, fail []*regattapb.RequestOp) (bool, []*regattapb.ResponseOp, error) {
 	if err := ctx.EnsureIndexed(); err != nil {
 		return false, nil, err
 	}
@@ -35,12 +63,21 @@ func handleTxn(ctx *updateContext, compare []*regattapb.Compare, success, fail [
 	if err != nil {
 		return false, nil, err
 	}
+	ops := fail
 	if ok {
-		res, err := handleTxnOps(ctx, success)
-		return true, res, err
+		ops = success
+	}
+	for _, op := range ops {
+		exists, err := leaseExists(ctx.batch, uint64(op.GetRequestPut().GetLease()))
+		if err != nil {
+			return false, nil, err
+		}
+		if !exists {
+			return false, nil, serrors.ErrLeaseNotFound
+		}
 	}
-	res, err := handleTxnOps(ctx, fail)
-	return false, res, err
+	res, err := handleTxnOps(ctx, ops)
+	return ok, res, err
 }
 
 func handleTxnOps(ctx *updateContext, req []*regattapb.RequestOp) ([]*regattapb.ResponseOp, error) {
@@ -69,6 +106,22 @@ func handleTxnOps(ctx *updateContext, req []*regattapb.RequestOp) ([]*regattapb.
 			}
 
 			results = append(results, wrapResponseOp(response))
+		case *regattapb.RequestOp_RequestIncrement:
+			response, err := handleIncrement(ctx, o.RequestIncrement)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, wrapResponseOp(response))
+		case *regattapb.RequestOp_RequestTxn:
+			// The outcome is recorded before the outcomes of the transactions nested in the applied branch.
+			pos := len(ctx.nestedTxns)
+			ctx.nestedTxns = append(ctx.nestedTxns, false)
+			succ, responses, err := applyTxn(ctx, o.RequestTxn.Compare, o.RequestTxn.Success, o.RequestTxn.Failure)
+			if err != nil {
+				return nil, err
+			}
+			ctx.nestedTxns[pos] = succ
+			results = append(results, wrapResponseOp(&regattapb.ResponseOp_Txn{Succeeded: succ, Responses: responses}))
 		}
 	}
 	return results, nil
@@ -93,10 +146,14 @@ func txnCompare(reader pebble.Reader, compare []*regattapb.Compare) (bool, error
 					_ = iter.Close()
 				}()
 				if !iter.First() {
-					return false, nil
+					return txnCompareMissing(cmp), nil
 				}
 				for iter.First(); iter.Valid(); iter.Next() {
-					if !txnCompareSingle(cmp, iter.Value()) {
+					value, err := decodeCompareValue(reader, cmp, iter.Value())
+					if err != nil {
+						return false, err
+					}
+					if !txnCompareSingle(cmp, value) {
 						return false, nil
 					}
 				}
@@ -104,29 +161,26 @@ func txnCompare(reader pebble.Reader, compare []*regattapb.Compare) (bool, error
 			}()
 		} else {
 			res, err = func() (bool, error) {
+				keyBuf.Reset()
 				if err := encodeUserKey(keyBuf, cmp.Key); err != nil {
 					return false, err
 				}
-				value, closer, err := reader.Get(keyBuf.Bytes())
-				defer func() {
-					if closer != nil {
-						_ = closer.Close()
-					}
-				}()
-
+				raw, closer, err := reader.Get(keyBuf.Bytes())
 				if err != nil {
 					if errors.Is(err, pebble.ErrNotFound) {
-						return false, nil
+						return txnCompareMissing(cmp), nil
 					}
 					return false, err
 				}
+				defer func() {
+					_ = closer.Close()
+				}()
 
-				if !txnCompareSingle(cmp, value) {
-					return false, nil
+				value, err := decodeCompareValue(reader, cmp, raw)
+				if err != nil {
+					return false, err
 				}
-
-				keyBuf.Reset()
-				return true, nil
+				return txnCompareSingle(cmp, value), nil
 			}()
 		}
 		if err != nil {
@@ -139,20 +193,64 @@ func txnCompare(reader pebble.Reader, compare []*regattapb.Compare) (bool, error
 	return true, nil
 }
 
-func txnCompareSingle(cmp *regattapb.Compare, value []byte) bool {
-	cmpValue := true
-	if cmp.Target == regattapb.Compare_VALUE && cmp.TargetUnion != nil {
-		switch cmp.Result {
-		case regattapb.Compare_EQUAL:
-			cmpValue = bytes.Equal(value, cmp.GetValue())
-		case regattapb.Compare_NOT_EQUAL:
-			cmpValue = !bytes.Equal(value, cmp.GetValue())
-		case regattapb.Compare_GREATER:
-			cmpValue = bytes.Compare(value, cmp.GetValue()) == 1
-		case regattapb.Compare_LESS:
-			cmpValue = bytes.Compare(value, cmp.GetValue()) == -1
-		}
+// decodeCompareValue decodes the value to be compared, the large value is reassembled only if its data is compared.
+func decodeCompareValue(reader pebble.Reader, cmp *regattapb.Compare, raw []byte) (storedValue, error) {
+	value, err := decodeValue(raw)
+	if err != nil || cmp.Target != regattapb.Compare_VALUE {
+		return value, err
+	}
+	return resolveValue(reader, value)
+}
+
+// txnCompareMissing evaluates the comparison of a key (or a range) that does not exist. Comparison of the value
+// (or the existence) is always false, the revisions and the version of a missing key are compared as 0.
+func txnCompareMissing(cmp *regattapb.Compare) bool {
+	if cmp.Target == regattapb.Compare_VALUE || cmp.TargetUnion == nil {
+		return false
 	}
+	return txnCompareSingle(cmp, storedValue{})
+}
 
-	return cmpValue
+func txnCompareSingle(cmp *regattapb.Compare, value storedValue) bool {
+	if cmp.TargetUnion == nil {
+		return true
+	}
+	switch cmp.Target {
+	case regattapb.Compare_VALUE:
+		return compareResult(cmp.Result, bytes.Compare(value.data, cmp.GetValue()))
+	case regattapb.Compare_VERSION:
+		return compareResult(cmp.Result, compareInt(int64(value.version), cmp.GetVersion()))
+	case regattapb.Compare_CREATE:
+		return compareResult(cmp.Result, compareInt(int64(value.createRevision), cmp.GetCreateRevision()))
+	case regattapb.Compare_MOD:
+		return compareResult(cmp.Result, compareInt(int64(value.modRevision), cmp.GetModRevision()))
+	case regattapb.Compare_LEASE:
+		return compareResult(cmp.Result, compareInt(int64(value.lease), cmp.GetLease()))
+	}
+	return true
+}
+
+// compareResult reports whether the result of a three-way comparison satisfies the requested operation.
+func compareResult(result regattapb.Compare_CompareResult, c int) bool {
+	switch result {
+	case regattapb.Compare_EQUAL:
+		return c == 0
+	case regattapb.Compare_NOT_EQUAL:
+		return c != 0
+	case regattapb.Compare_GREATER:
+		return c > 0
+	case regattapb.Compare_LESS:
+		return c < 0
+	}
+	return true
+}
+
+func compareInt(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/cockroachdb/pebble/vfs"
 	rp "github.com/jamf/regatta/pebble"
 	"github.com/jamf/regatta/regattapb"
+	serrors "github.com/jamf/regatta/storage/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -178,6 +179,78 @@ func Test_txnCompare(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "key does not exist create revision comparison",
+			args: args{
+				reader: loadedPebble,
+				compare: []*regattapb.Compare{
+					{
+						Key:         []byte("nonsense"),
+						Target:      regattapb.Compare_CREATE,
+						TargetUnion: &regattapb.Compare_CreateRevision{CreateRevision: 0},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "empty range version comparison",
+			args: args{
+				reader: loadedPebble,
+				compare: []*regattapb.Compare{
+					{
+						Key:         []byte("nonsense"),
+						RangeEnd:    []byte("nonsense2"),
+						Target:      regattapb.Compare_VERSION,
+						TargetUnion: &regattapb.Compare_Version{Version: 0},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "key does not exist value comparison",
+			args: args{
+				reader: loadedPebble,
+				compare: []*regattapb.Compare{
+					{
+						Key:         []byte("nonsense"),
+						TargetUnion: &regattapb.Compare_Value{Value: nil},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "mod revision comparison",
+			args: args{
+				reader: loadedPebble,
+				compare: []*regattapb.Compare{
+					{
+						Key:         []byte(fmt.Sprintf(testKeyFormat, 5)),
+						Target:      regattapb.Compare_MOD,
+						TargetUnion: &regattapb.Compare_ModRevision{ModRevision: 5},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "range create revision comparison",
+			args: args{
+				reader: loadedPebble,
+				compare: []*regattapb.Compare{
+					{
+						Key:         []byte(fmt.Sprintf(testKeyFormat, 1)),
+						RangeEnd:    []byte(fmt.Sprintf(testKeyFormat, 2)),
+						Result:      regattapb.Compare_LESS,
+						Target:      regattapb.Compare_CREATE,
+						TargetUnion: &regattapb.Compare_CreateRevision{CreateRevision: 100},
+					},
+				},
+			},
+			want: false,
+		},
 		{
 			name: "fail to get key",
 			args: args{
@@ -214,9 +287,10 @@ func Test_handleTxn(t *testing.T) {
 	}
 
 	c := &updateContext{
-		batch: db.NewBatch(),
-		db:    db,
-		index: 1,
+		batch:    db.NewBatch(),
+		db:       db,
+		index:    1,
+		revision: 1,
 	}
 	defer func() { _ = c.Close() }()
 
@@ -231,6 +305,7 @@ func Test_handleTxn(t *testing.T) {
 	r.NoError(c.Commit())
 
 	c.batch = db.NewBatch()
+	c.index, c.revision = 2, 2
 
 	// empty transaction
 	succ, res, err := handleTxn(c, []*regattapb.Compare{{Key: []byte("key_1")}}, nil, nil)
@@ -250,7 +325,7 @@ func Test_handleTxn(t *testing.T) {
 	r.True(succ)
 	r.NoError(err)
 	r.Equal(1, len(res))
-	r.Equal(wrapResponseOp(&regattapb.ResponseOp_Put{PrevKv: &regattapb.KeyValue{Key: []byte("key_5"), Value: nil}}), res[0])
+	r.Equal(wrapResponseOp(&regattapb.ResponseOp_Put{PrevKv: &regattapb.KeyValue{Key: []byte("key_5"), Value: nil, CreateRevision: 2, ModRevision: 2, Version: 1}}), res[0])
 
 	// compare key_5 value with "value" and delete keys up to key_4 (non-inclusive)
 	succ, res, err = handleTxn(c, []*regattapb.Compare{{Key: []byte("key_5"), TargetUnion: &regattapb.Compare_Value{Value: []byte("value")}}}, []*regattapb.RequestOp{{Request: &regattapb.RequestOp_RequestDeleteRange{RequestDeleteRange: &regattapb.RequestOp_DeleteRange{Key: []byte("key_1"), RangeEnd: []byte("key_4"), PrevKv: true}}}}, nil)
@@ -260,9 +335,9 @@ func Test_handleTxn(t *testing.T) {
 	r.Equal(wrapResponseOp(&regattapb.ResponseOp_DeleteRange{
 		Deleted: 3,
 		PrevKvs: []*regattapb.KeyValue{
-			{Key: []byte("key_1"), Value: []byte("value")},
-			{Key: []byte("key_2"), Value: []byte("value")},
-			{Key: []byte("key_3"), Value: []byte("value")},
+			{Key: []byte("key_1"), Value: []byte("value"), CreateRevision: 1, ModRevision: 1, Version: 1},
+			{Key: []byte("key_2"), Value: []byte("value"), CreateRevision: 1, ModRevision: 1, Version: 1},
+			{Key: []byte("key_3"), Value: []byte("value"), CreateRevision: 1, ModRevision: 1, Version: 1},
 		},
 	}), res[0])
 
@@ -272,7 +347,9 @@ func Test_handleTxn(t *testing.T) {
 	count := 0
 	for iter.First(); iter.Valid(); iter.Next() {
 		count++
-		r.Equal("value", string(iter.Value()))
+		val, err := decodeValue(iter.Value())
+		r.NoError(err)
+		r.Equal("value", string(val.data))
 	}
 	// just keys key_4 and key_5 should remain
 	r.Equal(2, count)
@@ -283,10 +360,64 @@ func Test_handleTxn(t *testing.T) {
 	r.Equal(c.index, index)
 }
 
+func Test_handleTxnNested(t *testing.T) {
+	r := require.New(t)
+	db, err := rp.OpenDB("/", rp.WithFS(vfs.NewMem()))
+	r.NoError(err)
+	defer db.Close()
+
+	ctx := &updateContext{batch: db.NewBatch(), db: db, index: 1, revision: 1}
+	defer func() { _ = ctx.Close() }()
+
+	put := func(key, value string) *regattapb.RequestOp {
+		return &regattapb.RequestOp{Request: &regattapb.RequestOp_RequestPut{RequestPut: &regattapb.RequestOp_Put{Key: []byte(key), Value: []byte(value)}}}
+	}
+	missing := func(key string) []*regattapb.Compare {
+		return []*regattapb.Compare{{Key: []byte(key), Target: regattapb.Compare_CREATE, TargetUnion: &regattapb.Compare_CreateRevision{CreateRevision: 0}}}
+	}
+	nested := func(compare []*regattapb.Compare, success, failure []*regattapb.RequestOp) *regattapb.RequestOp {
+		return &regattapb.RequestOp{Request: &regattapb.RequestOp_RequestTxn{RequestTxn: &regattapb.Txn{Compare: compare, Success: success, Failure: failure}}}
+	}
+
+	t.Log("nested compare sees the preceding operations")
+	succ, res, err := handleTxn(ctx, nil, []*regattapb.RequestOp{
+		put("key_1", "value"),
+		nested(missing("key_1"), []*regattapb.RequestOp{put("key_2", "created")}, []*regattapb.RequestOp{
+			put("key_2", "exists"),
+			nested(missing("key_3"), []*regattapb.RequestOp{put("key_3", "created")}, nil),
+		}),
+	}, nil)
+	r.NoError(err)
+	r.True(succ)
+	r.Equal([]bool{false, true}, ctx.nestedTxns)
+	r.Equal([]*regattapb.ResponseOp{
+		wrapResponseOp(&regattapb.ResponseOp_Put{}),
+		wrapResponseOp(&regattapb.ResponseOp_Txn{Responses: []*regattapb.ResponseOp{
+			wrapResponseOp(&regattapb.ResponseOp_Put{}),
+			wrapResponseOp(&regattapb.ResponseOp_Txn{Succeeded: true, Responses: []*regattapb.ResponseOp{wrapResponseOp(&regattapb.ResponseOp_Put{})}}),
+		}}),
+	}, res)
+	for key, value := range map[string]string{"key_2": "exists", "key_3": "created"} {
+		v, err := lookup(ctx.batch, &regattapb.RequestOp_Range{Key: []byte(key)})
+		r.NoError(err)
+		r.Equal([]byte(value), v.Kvs[0].Value)
+	}
+
+	t.Log("failed nested transaction discards the whole transaction")
+	_, _, err = handleTxn(ctx, nil, []*regattapb.RequestOp{
+		put("key_4", "value"),
+		nested(nil, []*regattapb.RequestOp{{Request: &regattapb.RequestOp_RequestPut{RequestPut: &regattapb.RequestOp_Put{Key: []byte("key_5"), Lease: 10}}}}, nil),
+	}, nil)
+	r.ErrorIs(err, serrors.ErrLeaseNotFound)
+	v, err := lookup(ctx.batch, &regattapb.RequestOp_Range{Key: []byte("key_4")})
+	r.NoError(err)
+	r.Empty(v.Kvs, "no operation of the transaction must be applied")
+}
+
 func Test_txnCompareSingle(t *testing.T) {
 	type args struct {
 		cmp   *regattapb.Compare
-		value []byte
+		value storedValue
 	}
 	tests := []struct {
 		name string
@@ -297,7 +428,7 @@ func Test_txnCompareSingle(t *testing.T) {
 			name: "empty compare",
 			args: args{
 				cmp:   &regattapb.Compare{},
-				value: nil,
+				value: storedValue{},
 			},
 			want: true,
 		},
@@ -308,7 +439,7 @@ func Test_txnCompareSingle(t *testing.T) {
 					Result:      regattapb.Compare_EQUAL,
 					TargetUnion: &regattapb.Compare_Value{Value: []byte("test")},
 				},
-				value: []byte("test"),
+				value: storedValue{data: []byte("test")},
 			},
 			want: true,
 		},
@@ -319,7 +450,7 @@ func Test_txnCompareSingle(t *testing.T) {
 					Result:      regattapb.Compare_EQUAL,
 					TargetUnion: &regattapb.Compare_Value{Value: []byte("test")},
 				},
-				value: []byte("testssadasd"),
+				value: storedValue{data: []byte("testssadasd")},
 			},
 			want: false,
 		},
@@ -330,7 +461,7 @@ func Test_txnCompareSingle(t *testing.T) {
 					Result:      regattapb.Compare_NOT_EQUAL,
 					TargetUnion: &regattapb.Compare_Value{Value: []byte("test")},
 				},
-				value: []byte("test"),
+				value: storedValue{data: []byte("test")},
 			},
 			want: false,
 		},
@@ -341,7 +472,7 @@ func Test_txnCompareSingle(t *testing.T) {
 					Result:      regattapb.Compare_NOT_EQUAL,
 					TargetUnion: &regattapb.Compare_Value{Value: []byte("test")},
 				},
-				value: []byte("testytest"),
+				value: storedValue{data: []byte("testytest")},
 			},
 			want: true,
 		},
@@ -352,7 +483,7 @@ func Test_txnCompareSingle(t *testing.T) {
 					Result:      regattapb.Compare_GREATER,
 					TargetUnion: &regattapb.Compare_Value{Value: []byte("testa")},
 				},
-				value: []byte("testaa"),
+				value: storedValue{data: []byte("testaa")},
 			},
 			want: true,
 		},
@@ -363,7 +494,7 @@ func Test_txnCompareSingle(t *testing.T) {
 					Result:      regattapb.Compare_GREATER,
 					TargetUnion: &regattapb.Compare_Value{Value: []byte("testa")},
 				},
-				value: []byte("test"),
+				value: storedValue{data: []byte("test")},
 			},
 			want: false,
 		},
@@ -374,7 +505,7 @@ func Test_txnCompareSingle(t *testing.T) {
 					Result:      regattapb.Compare_LESS,
 					TargetUnion: &regattapb.Compare_Value{Value: []byte("test")},
 				},
-				value: []byte("testa"),
+				value: storedValue{data: []byte("testa")},
 			},
 			want: false,
 		},
@@ -385,10 +516,106 @@ func Test_txnCompareSingle(t *testing.T) {
 					Result:      regattapb.Compare_LESS,
 					TargetUnion: &regattapb.Compare_Value{Value: []byte("testa")},
 				},
-				value: []byte("test"),
+				value: storedValue{data: []byte("test")},
+			},
+			want: true,
+		},
+		{
+			name: "VERSION EQUAL - equal version",
+			args: args{
+				cmp: &regattapb.Compare{
+					Result:      regattapb.Compare_EQUAL,
+					Target:      regattapb.Compare_VERSION,
+					TargetUnion: &regattapb.Compare_Version{Version: 2},
+				},
+				value: storedValue{version: 2},
 			},
 			want: true,
 		},
+		{
+			name: "VERSION GREATER - lesser version",
+			args: args{
+				cmp: &regattapb.Compare{
+					Result:      regattapb.Compare_GREATER,
+					Target:      regattapb.Compare_VERSION,
+					TargetUnion: &regattapb.Compare_Version{Version: 2},
+				},
+				value: storedValue{version: 1},
+			},
+			want: false,
+		},
+		{
+			name: "CREATE EQUAL - missing key",
+			args: args{
+				cmp: &regattapb.Compare{
+					Result:      regattapb.Compare_EQUAL,
+					Target:      regattapb.Compare_CREATE,
+					TargetUnion: &regattapb.Compare_CreateRevision{CreateRevision: 0},
+				},
+				value: storedValue{},
+			},
+			want: true,
+		},
+		{
+			name: "CREATE EQUAL - existing key",
+			args: args{
+				cmp: &regattapb.Compare{
+					Result:      regattapb.Compare_EQUAL,
+					Target:      regattapb.Compare_CREATE,
+					TargetUnion: &regattapb.Compare_CreateRevision{CreateRevision: 0},
+				},
+				value: storedValue{createRevision: 5, modRevision: 5, version: 1},
+			},
+			want: false,
+		},
+		{
+			name: "MOD LESS - lesser mod revision",
+			args: args{
+				cmp: &regattapb.Compare{
+					Result:      regattapb.Compare_LESS,
+					Target:      regattapb.Compare_MOD,
+					TargetUnion: &regattapb.Compare_ModRevision{ModRevision: 10},
+				},
+				value: storedValue{createRevision: 5, modRevision: 9, version: 3},
+			},
+			want: true,
+		},
+		{
+			name: "MOD NOT EQUAL - equal mod revision",
+			args: args{
+				cmp: &regattapb.Compare{
+					Result:      regattapb.Compare_NOT_EQUAL,
+					Target:      regattapb.Compare_MOD,
+					TargetUnion: &regattapb.Compare_ModRevision{ModRevision: 9},
+				},
+				value: storedValue{createRevision: 5, modRevision: 9, version: 3},
+			},
+			want: false,
+		},
+		{
+			name: "LEASE EQUAL - equal lease",
+			args: args{
+				cmp: &regattapb.Compare{
+					Result:      regattapb.Compare_EQUAL,
+					Target:      regattapb.Compare_LEASE,
+					TargetUnion: &regattapb.Compare_Lease{Lease: 7},
+				},
+				value: storedValue{createRevision: 5, modRevision: 9, version: 3, lease: 7},
+			},
+			want: true,
+		},
+		{
+			name: "LEASE EQUAL - no lease",
+			args: args{
+				cmp: &regattapb.Compare{
+					Result:      regattapb.Compare_EQUAL,
+					Target:      regattapb.Compare_LEASE,
+					TargetUnion: &regattapb.Compare_Lease{Lease: 7},
+				},
+				value: storedValue{createRevision: 5, modRevision: 9, version: 3},
+			},
+			want: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -4,6 +4,7 @@ package fsm
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"fmt"
 	"hash/fnv"
@@ -18,6 +19,7 @@ import (
 	"github.com/jamf/regatta/regattapb"
 	"github.com/jamf/regatta/storage/errors"
 	"github.com/jamf/regatta/storage/table/key"
+	"github.com/jamf/regatta/tracing"
 	sm "github.com/lni/dragonboat/v4/statemachine"
 	"github.com/oxtoacart/bpool"
 	"github.com/prometheus/client_golang/prometheus"
@@ -35,7 +37,13 @@ var (
 		KeyType: key.TypeSystem,
 		Key:     []byte("leader_index"),
 	})
-	maxUserKey = mustEncodeKey(key.Key{
+	// sysHeartbeat holds the timestamp of the last applied heartbeat.
+	sysHeartbeat = mustEncodeKey(key.Key{
+		KeyType: key.TypeSystem,
+		Key:     []byte("heartbeat"),
+	})
+	sysTxnResultPrefix = []byte("txn_result/")
+	maxUserKey         = mustEncodeKey(key.Key{
 		KeyType: key.TypeUser,
 		Key:     key.LatestMaxKey,
 	})
@@ -44,9 +52,20 @@ var (
 const (
 	// maxBatchSize maximum size of inmemory batch before commit.
 	maxBatchSize = 16 * 1024 * 1024
+	// txnResultRetention number of entries for which the results of conditional commands are kept,
+	// it should comfortably exceed the number of entries retained in the raft log.
+	txnResultRetention = 1_000_000
+	// txnResultPruneInterval how often (in entries) are the results older than txnResultRetention pruned.
+	txnResultPruneInterval = 10_000
+	// requestResultRetention number of revisions for which the results of the commands applied with the request ID are kept,
+	// a retry of the command is not applied again within this window.
+	requestResultRetention = 1_000_000
+	// maxRequestResultSize the max size of the stored result of a command applied with the request ID, the key-value pairs
+	// are dropped from the larger results.
+	maxRequestResultSize = 1024
 )
 
-// UpdateResult if operation succeeded or not, both values mean that operation finished, value just indicates with which result.
+// UpdateResult if operation succeeded or not, all values mean that operation finished, value just indicates with which result.
 // You should always check for err from proposals to detect unfinished or failed operations.
 type UpdateResult uint64
 
@@ -55,6 +74,17 @@ const (
 	ResultFailure UpdateResult = iota
 	// ResultSuccess applied update.
 	ResultSuccess
+	// ResultLeaseNotFound update was not applied as the lease it refers to does not exist.
+	ResultLeaseNotFound
+	// ResultValueNotInteger update was not applied as the value to increment is not an integer.
+	ResultValueNotInteger
+	// ResultValueOutOfBounds update was not applied as the incremented value is out of bounds.
+	ResultValueOutOfBounds
+	// ResultDuplicate update was not applied as a command with the same request ID was applied already. The result is only
+	// stored for the watchers (see TxnResultRequest), the proposal returns the result of the original command.
+	ResultDuplicate
+	// ResultRequestMismatch update was not applied as the request ID was already used by a different command.
+	ResultRequestMismatch
 )
 
 type SnapshotRecoveryType uint8
@@ -86,7 +116,7 @@ func (s *snapshotHeader) snapshotType() SnapshotRecoveryType {
 	return SnapshotRecoveryType(s[6])
 }
 
-func New(tableName, stateMachineDir string, fs vfs.FS, blockCache *pebble.Cache, tableCache *pebble.TableCache, srt SnapshotRecoveryType) sm.CreateOnDiskStateMachineFunc {
+func New(tableName, stateMachineDir string, fs vfs.FS, blockCache *pebble.Cache, tableCache *pebble.TableCache, srt SnapshotRecoveryType, compression pebble.Compression) sm.CreateOnDiskStateMachineFunc {
 	if fs == nil {
 		fs = vfs.Default
 	}
@@ -105,6 +135,7 @@ func New(tableName, stateMachineDir string, fs vfs.FS, blockCache *pebble.Cache,
 			log:          zap.S().Named("table").Named(tableName),
 			metrics:      newMetrics(tableName, clusterID),
 			recoveryType: srt,
+			compression:  compression,
 		}
 	}
 }
@@ -123,6 +154,7 @@ type FSM struct {
 	tableCache   *pebble.TableCache
 	metrics      *metrics
 	recoveryType SnapshotRecoveryType
+	compression  pebble.Compression
 }
 
 func (p *FSM) Open(_ <-chan struct{}) (uint64, error) {
@@ -167,6 +199,9 @@ func (p *FSM) Open(_ <-chan struct{}) (uint64, error) {
 	if err != nil {
 		return 0, err
 	}
+	if err := migrateValues(db); err != nil {
+		return 0, err
+	}
 	p.pebble.Store(db)
 
 	if err := prometheus.Register(p); err != nil {
@@ -187,6 +222,7 @@ func (p *FSM) openDB(dbdir string) (*pebble.DB, error) {
 		rp.WithFS(p.fs),
 		rp.WithCache(p.blockCache),
 		rp.WithTableCache(p.tableCache),
+		rp.WithCompression(p.compression),
 		rp.WithLogger(p.log),
 		rp.WithEventListener(makeLoggingEventListener(p.log)),
 	)
@@ -195,38 +231,45 @@ func (p *FSM) openDB(dbdir string) (*pebble.DB, error) {
 // Lookup locally looks up the data.
 func (p *FSM) Lookup(l interface{}) (interface{}, error) {
 	switch req := l.(type) {
+	case TracedRequest:
+		_, span := tracing.Start(req.Context, "fsm.Lookup", tracing.Attr("table", p.tableName))
+		defer span.End()
+		res, err := p.Lookup(req.Request)
+		span.SetError(err)
+		return res, err
 	case *regattapb.TxnRequest:
 		snapshot := p.pebble.Load().NewSnapshot()
 		defer snapshot.Close()
 
-		ok, err := txnCompare(snapshot, req.Compare)
+		rev, err := readRevision(snapshot)
 		if err != nil {
 			return nil, err
 		}
-
-		var ops []*regattapb.RequestOp_Range
-		if ok {
-			for _, op := range req.Success {
-				ops = append(ops, op.GetRequestRange())
-			}
-		} else {
-			for _, op := range req.Failure {
-				ops = append(ops, op.GetRequestRange())
-			}
+		ok, responses, err := readonlyTxn(snapshot, req.Compare, req.Success, req.Failure)
+		if err != nil {
+			return nil, err
 		}
+		return &regattapb.TxnResponse{Header: &regattapb.ResponseHeader{Revision: rev}, Succeeded: ok, Responses: responses}, nil
+	case *regattapb.RequestOp_Range:
+		// The large values are read from the snapshot so that their chunks could not be discarded in the meantime.
+		snapshot := p.pebble.Load().NewSnapshot()
+		defer snapshot.Close()
 
-		resp := &regattapb.TxnResponse{Succeeded: ok}
-		for _, op := range ops {
-			rr, err := lookup(snapshot, op)
-			if err != nil {
-				return nil, err
-			}
-			resp.Responses = append(resp.Responses, wrapResponseOp(rr))
+		return lookup(snapshot, req)
+	case RangeRequest:
+		// The revision is read from the same snapshot as the range so that it matches the returned state.
+		snapshot := p.pebble.Load().NewSnapshot()
+		defer snapshot.Close()
+
+		rev, err := readRevision(snapshot)
+		if err != nil {
+			return nil, err
 		}
-		return resp, nil
-	case *regattapb.RequestOp_Range:
-		db := p.pebble.Load()
-		return lookup(db, req)
+		res, err := lookup(snapshot, req.Range)
+		if err != nil {
+			return nil, err
+		}
+		return &RangeResponse{Range: res, Revision: rev}, nil
 	case SnapshotRequest:
 		snapshot := p.pebble.Load().NewSnapshot()
 		defer snapshot.Close()
@@ -236,6 +279,36 @@ func (p *FSM) Lookup(l interface{}) (interface{}, error) {
 			return nil, err
 		}
 		return &SnapshotResponse{Index: idx}, nil
+	case CursorRequest:
+		snapshot := p.pebble.Load().NewSnapshot()
+		defer snapshot.Close()
+
+		idx, err := readRevision(snapshot)
+		if err != nil {
+			return nil, err
+		}
+		err = cursor(snapshot, req.Range, req.BatchSize, func(response *regattapb.ResponseOp_Range) error {
+			return req.Send(response, idx)
+		}, req.Stopper)
+		if err != nil {
+			return nil, err
+		}
+		return &CursorResponse{Index: idx}, nil
+	case LargeValueRequest:
+		snapshot := p.pebble.Load().NewSnapshot()
+		defer snapshot.Close()
+
+		idx, err := readRevision(snapshot)
+		if err != nil {
+			return nil, err
+		}
+		found, err := largeValueLookup(snapshot, req, func(kv *regattapb.KeyValue, part []byte) error {
+			return req.Send(kv, part, idx)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &LargeValueResponse{Index: idx, Found: found}, nil
 	case LocalIndexRequest:
 		idx, err := readLocalIndex(p.pebble.Load(), sysLocalIndex)
 		if err != nil {
@@ -248,6 +321,55 @@ func (p *FSM) Lookup(l interface{}) (interface{}, error) {
 			return nil, err
 		}
 		return &IndexResponse{Index: idx}, nil
+	case HeartbeatRequest:
+		ts, err := readLocalIndex(p.pebble.Load(), sysHeartbeat)
+		if err != nil {
+			return nil, err
+		}
+		return &HeartbeatResponse{Timestamp: int64(ts)}, nil
+	case SizeRequest:
+		return &SizeResponse{Size: p.pebble.Load().Metrics().DiskSpaceUsage()}, nil
+	case RevisionRequest:
+		snapshot := p.pebble.Load().NewSnapshot()
+		defer snapshot.Close()
+
+		rev, err := readRevision(snapshot)
+		if err != nil {
+			return nil, err
+		}
+		compacted, err := readLocalIndex(snapshot, sysCompactRevision)
+		if err != nil {
+			return nil, err
+		}
+		return &RevisionResponse{Revision: rev, CompactRevision: compacted}, nil
+	case TxnResultRequest:
+		return readTxnResult(p.pebble.Load(), req.Index)
+	case LeaseRequest:
+		snapshot := p.pebble.Load().NewSnapshot()
+		defer snapshot.Close()
+
+		rev, err := readRevision(snapshot)
+		if err != nil {
+			return nil, err
+		}
+		lease, err := readLease(snapshot, uint64(req.ID))
+		if err != nil {
+			return nil, err
+		}
+		resp := &LeaseResponse{Lease: lease, Revision: rev}
+		if req.Keys {
+			resp.Keys, err = leaseKeys(snapshot, uint64(req.ID))
+			if err != nil {
+				return nil, err
+			}
+		}
+		return resp, nil
+	case ExpiredLeasesRequest:
+		ids, err := expiredLeases(p.pebble.Load(), req.Now)
+		if err != nil {
+			return nil, err
+		}
+		return &ExpiredLeasesResponse{IDs: ids}, nil
 	case PathRequest:
 		return &PathResponse{Path: p.dirname}, nil
 	default:
@@ -270,19 +392,48 @@ func (p *FSM) Update(updates []sm.Entry) ([]sm.Entry, error) {
 		_ = ctx.Close()
 	}()
 
+	// The spans of the traced commands are ended once the whole batch is committed.
+	var spans []*tracing.Span
+	defer func() {
+		for _, span := range spans {
+			span.End()
+		}
+	}()
+
 	var idx uint64
 	for i := 0; i < len(updates); i++ {
 		cmd, err := parseCommand(ctx, updates[i])
 		if err != nil {
 			return nil, err
 		}
+		if span := p.traceUpdate(ctx.traceparent, updates[i].Index, len(updates)); span != nil {
+			spans = append(spans, span)
+		}
 
 		updateResult, res, err := cmd.handle(ctx)
 		if err != nil {
 			return nil, err
 		}
 
-		if len(res.Responses) > 0 {
+		// Store the outcome of conditional commands so that watchers could tell which operations were applied.
+		if c, ok := cmd.(conditionalCommand); ok && c.conditional() {
+			result := updateResult
+			if ctx.duplicate {
+				result = ResultDuplicate
+			}
+			if err := ctx.SetTxnResult(result); err != nil {
+				return nil, err
+			}
+		}
+		if err := ctx.PruneTxnResults(); err != nil {
+			return nil, err
+		}
+		if err := ctx.PruneRequestResults(); err != nil {
+			return nil, err
+		}
+
+		// The result of the duplicate carries the revision of the original command.
+		if len(res.Responses) > 0 || res.Lease != nil || ctx.duplicate {
 			bts, err := res.MarshalVT()
 			if err != nil {
 				return nil, err
@@ -298,9 +449,30 @@ func (p *FSM) Update(updates []sm.Entry) ([]sm.Entry, error) {
 	}
 
 	p.metrics.applied.Store(idx)
+	p.metrics.discardedHistoryKeys.Add(float64(ctx.discardedHistoryKeys))
 	return updates, nil
 }
 
+// traceUpdate starts the span of applying the command at the index as a part of the trace of the traceparent, nil is returned
+// if the command was not proposed by a traced request.
+func (p *FSM) traceUpdate(traceparent string, index uint64, batchSize int) *tracing.Span {
+	if traceparent == "" {
+		return nil
+	}
+	sc, ok := tracing.ParseTraceparent(traceparent)
+	if !ok {
+		return nil
+	}
+	_, span := tracing.Start(
+		tracing.ContextWithRemoteSpanContext(context.Background(), sc),
+		"fsm.Update",
+		tracing.Attr("table", p.tableName),
+		tracing.Attr("index", index),
+		tracing.Attr("batch_size", batchSize),
+	)
+	return span
+}
+
 // Sync synchronizes all in-core state of the state machine to permanent
 // storage so the state machine can continue from its latest state after
 // reboot.
@@ -438,6 +610,17 @@ func mustEncodeKey(k key.Key) []byte {
 	return encoded
 }
 
+// txnResultKey encodes the system key of a conditional transaction result applied at the index.
+func txnResultKey(index uint64) []byte {
+	k := make([]byte, len(sysTxnResultPrefix)+8)
+	copy(k, sysTxnResultPrefix)
+	binary.BigEndian.PutUint64(k[len(sysTxnResultPrefix):], index)
+	return mustEncodeKey(key.Key{
+		KeyType: key.TypeSystem,
+		Key:     k,
+	})
+}
+
 func incrementRightmostByte(in []byte) []byte {
 	for i := len(in) - 1; i >= 0; i-- {
 		in[i] = in[i] + 1
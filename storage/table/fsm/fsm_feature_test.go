@@ -411,6 +411,69 @@ var input = map[int][]*regattapb.Command{
 			},
 		},
 	},
+	4: {
+		{
+			Table: []byte("test"),
+			Type:  regattapb.Command_PUT,
+			Kv:    &regattapb.KeyValue{Key: []byte("key_1"), Value: []byte("value_1")},
+		},
+		{
+			Table: []byte("test"),
+			Type:  regattapb.Command_PUT,
+			Kv:    &regattapb.KeyValue{Key: []byte("key_1"), Value: []byte("value_1_new")},
+		},
+		{
+			Table: []byte("test"),
+			Type:  regattapb.Command_PUT,
+			Kv:    &regattapb.KeyValue{Key: []byte("key_2"), Value: []byte("value_2")},
+		},
+		{
+			Table: []byte("test"),
+			Type:  regattapb.Command_DELETE,
+			Kv:    &regattapb.KeyValue{Key: []byte("key_2")},
+		},
+		{
+			Table: []byte("test"),
+			Type:  regattapb.Command_PUT,
+			Kv:    &regattapb.KeyValue{Key: []byte("key_1"), Value: []byte("value_1_newest")},
+		},
+		{
+			Table:           []byte("test"),
+			Type:            regattapb.Command_COMPACT,
+			CompactRevision: 3,
+		},
+	},
+	5: {
+		{
+			Table: []byte("test"),
+			Type:  regattapb.Command_TXN,
+			Txn: &regattapb.Txn{
+				Success: []*regattapb.RequestOp{
+					{Request: &regattapb.RequestOp_RequestIncrement{RequestIncrement: &regattapb.RequestOp_Increment{Key: []byte("key_1"), Delta: 1, Initial: 10}}},
+					{Request: &regattapb.RequestOp_RequestPut{RequestPut: &regattapb.RequestOp_Put{Key: []byte("key_2"), Value: []byte("value_2")}}},
+				},
+			},
+		},
+		{
+			Table: []byte("test"),
+			Type:  regattapb.Command_TXN,
+			Txn: &regattapb.Txn{
+				Success: []*regattapb.RequestOp{
+					{Request: &regattapb.RequestOp_RequestIncrement{RequestIncrement: &regattapb.RequestOp_Increment{Key: []byte("key_1"), Delta: -2}}},
+				},
+			},
+		},
+		{
+			Table: []byte("test"),
+			Type:  regattapb.Command_TXN,
+			Txn: &regattapb.Txn{
+				Success: []*regattapb.RequestOp{
+					{Request: &regattapb.RequestOp_RequestPut{RequestPut: &regattapb.RequestOp_Put{Key: []byte("key_3"), Value: []byte("value_3")}}},
+					{Request: &regattapb.RequestOp_RequestIncrement{RequestIncrement: &regattapb.RequestOp_Increment{Key: []byte("key_2"), Delta: 1}}},
+				},
+			},
+		},
+	},
 }
 
 // TestGenerateData is useful for generating test data for new features.
@@ -504,6 +567,9 @@ func createTestFSM() (*FSM, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := migrateValues(db); err != nil {
+		return nil, err
+	}
 	fsm.pebble.Store(db)
 	return fsm, err
 }
@@ -3,11 +3,14 @@
 package fsm
 
 import (
+	"context"
 	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/cockroachdb/pebble/vfs"
 	"github.com/jamf/regatta/regattapb"
+	"github.com/jamf/regatta/tracing"
 	"github.com/jamf/regatta/util"
 	sm "github.com/lni/dragonboat/v4/statemachine"
 	"github.com/stretchr/testify/require"
@@ -723,6 +726,114 @@ func TestSM_Update(t *testing.T) {
 	}
 }
 
+func TestSM_Heartbeat(t *testing.T) {
+	r := require.New(t)
+	p := emptySM()
+	defer func() {
+		r.NoError(p.Close())
+	}()
+
+	res, err := p.Lookup(HeartbeatRequest{})
+	r.NoError(err)
+	r.Equal(&HeartbeatResponse{}, res)
+
+	_, err = p.Update([]sm.Entry{
+		{
+			Index: 1,
+			Cmd: mustMarshallProto(&regattapb.Command{
+				Table:     []byte("test"),
+				Type:      regattapb.Command_DUMMY,
+				Timestamp: 1000,
+			}),
+		},
+	})
+	r.NoError(err)
+	res, err = p.Lookup(HeartbeatRequest{})
+	r.NoError(err)
+	r.Equal(&HeartbeatResponse{Timestamp: 1000}, res)
+
+	t.Log("heartbeat replicated from the leader cluster")
+	_, err = p.Update([]sm.Entry{
+		{
+			Index: 2,
+			Cmd: mustMarshallProto(&regattapb.Command{
+				Table:       []byte("test"),
+				Type:        regattapb.Command_SEQUENCE,
+				LeaderIndex: &two,
+				Sequence: []*regattapb.Command{
+					{Table: []byte("test"), Type: regattapb.Command_DUMMY, LeaderIndex: &one, Timestamp: 2000},
+					{Table: []byte("test"), Type: regattapb.Command_DUMMY, LeaderIndex: &two},
+				},
+			}),
+		},
+	})
+	r.NoError(err)
+	res, err = p.Lookup(HeartbeatRequest{})
+	r.NoError(err)
+	r.Equal(&HeartbeatResponse{Timestamp: 2000}, res)
+}
+
+type recordingExporter struct {
+	mu    sync.Mutex
+	spans []tracing.SpanData
+}
+
+func (e *recordingExporter) ExportSpan(span tracing.SpanData) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = append(e.spans, span)
+	return nil
+}
+
+func TestSM_Tracing(t *testing.T) {
+	r := require.New(t)
+	p := emptySM()
+	defer func() {
+		r.NoError(p.Close())
+	}()
+	e := &recordingExporter{}
+	tracer := tracing.NewTracer(e, 1)
+	tracing.SetTracer(tracer)
+	defer tracing.SetTracer(nil)
+
+	ctx, parent := tracing.Start(context.Background(), "parent")
+	_, err := p.Update([]sm.Entry{
+		{
+			Index: 1,
+			Cmd: mustMarshallProto(&regattapb.Command{
+				Table:       []byte(testTable),
+				Type:        regattapb.Command_PUT,
+				Kv:          &regattapb.KeyValue{Key: []byte("key"), Value: []byte("value")},
+				Traceparent: parent.Context().Traceparent(),
+			}),
+		},
+		{
+			Index: 2,
+			Cmd: mustMarshallProto(&regattapb.Command{
+				Table: []byte(testTable),
+				Type:  regattapb.Command_PUT,
+				Kv:    &regattapb.KeyValue{Key: []byte("untraced"), Value: []byte("value")},
+			}),
+		},
+	})
+	r.NoError(err)
+	res, err := p.Lookup(TracedRequest{Context: ctx, Request: RangeRequest{Range: &regattapb.RequestOp_Range{Key: []byte("key")}}})
+	r.NoError(err)
+	r.Equal([]byte("value"), res.(*RangeResponse).Range.Kvs[0].Value)
+	parent.End()
+	tracing.SetTracer(nil)
+	tracer.Close()
+
+	r.Len(e.spans, 3)
+	r.Equal("fsm.Update", e.spans[0].Name)
+	r.Equal(parent.Context().SpanID, e.spans[0].ParentID)
+	r.Equal(uint64(1), e.spans[0].Attributes["index"])
+	r.Equal(2, e.spans[0].Attributes["batch_size"])
+	r.Equal("fsm.Lookup", e.spans[1].Name)
+	r.Equal(parent.Context().SpanID, e.spans[1].ParentID)
+	r.Equal("parent", e.spans[2].Name)
+}
+
 func equalResult(t *testing.T, want sm.Result, got sm.Result) {
 	require.Equal(t, want.Value, got.Value, "value does not match")
 	w := &regattapb.CommandResult{}
@@ -0,0 +1,528 @@
+// Copyright JAMF Software, LLC
+
+package fsm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/jamf/regatta/regattapb"
+	serrors "github.com/jamf/regatta/storage/errors"
+	"github.com/jamf/regatta/storage/table/key"
+)
+
+var (
+	// sysHistoryPrefix prefix of the system keys holding the superseded versions of the user keys. The prefix is followed
+	// by the order preserving encoding of the user key and the big endian mod revision of the version, so the versions
+	// are sorted by the user key and then by the revision.
+	sysHistoryPrefix = []byte("hist/")
+	// sysHistoryIndexPrefix prefix of the system keys indexing the superseded versions by the revision they were superseded at,
+	// the prefix is followed by the big endian revision and the part of the history key following sysHistoryPrefix.
+	sysHistoryIndexPrefix = []byte("hist_idx/")
+	// sysCompactRevision holds the revision the history of the table is compacted to.
+	sysCompactRevision = mustEncodeKey(key.Key{
+		KeyType: key.TypeSystem,
+		Key:     []byte("compact_revision"),
+	})
+	historyLowerBound = mustEncodeKey(key.Key{KeyType: key.TypeSystem, Key: sysHistoryPrefix})
+	historyUpperBound = incrementRightmostByte(mustEncodeKey(key.Key{KeyType: key.TypeSystem, Key: sysHistoryPrefix}))
+
+	errMalformedHistoryKey = errors.New("malformed history key")
+)
+
+const (
+	escapeByte     = 0x00
+	escapedByte    = 0xff
+	terminatorByte = 0x01
+	// historyKeySuffixLen length of the terminator and the revision following the user key in the history key.
+	historyKeySuffixLen = 2 + 8
+	// maxDeleteHistoryKeys the maximum number of keys a range delete keeps the deleted versions of,
+	// the larger range deletes drop the history of the keys within the range instead.
+	maxDeleteHistoryKeys = 10_000
+)
+
+// appendEscaped appends the order preserving encoding of the user key, the 0x00 bytes are escaped as 0x00 0xff
+// and the key is terminated by 0x00 0x01 so that no encoded key is a prefix of another one.
+func appendEscaped(dst []byte, userKey []byte, terminate bool) []byte {
+	for _, b := range userKey {
+		dst = append(dst, b)
+		if b == escapeByte {
+			dst = append(dst, escapedByte)
+		}
+	}
+	if terminate {
+		dst = append(dst, escapeByte, terminatorByte)
+	}
+	return dst
+}
+
+// historyKey encodes the system key of the version of the user key modified at the revision.
+func historyKey(userKey []byte, revision uint64) []byte {
+	k := make([]byte, 0, len(sysHistoryPrefix)+len(userKey)+historyKeySuffixLen)
+	k = append(k, sysHistoryPrefix...)
+	k = appendEscaped(k, userKey, true)
+	k = binary.BigEndian.AppendUint64(k, revision)
+	return mustEncodeKey(key.Key{KeyType: key.TypeSystem, Key: k})
+}
+
+// historyBound encodes the lower bound of the history keys of the user keys greater or equal to the user key.
+func historyBound(userKey []byte) []byte {
+	k := make([]byte, 0, len(sysHistoryPrefix)+len(userKey)+historyKeySuffixLen)
+	k = append(k, sysHistoryPrefix...)
+	k = appendEscaped(k, userKey, false)
+	return mustEncodeKey(key.Key{KeyType: key.TypeSystem, Key: k})
+}
+
+// historyIndexKey encodes the system key indexing the history key by the revision the version was superseded at.
+func historyIndexKey(supersededAt uint64, historyKey []byte) []byte {
+	prefixLen := len(historyLowerBound)
+	k := make([]byte, 0, len(sysHistoryIndexPrefix)+8+len(historyKey)-prefixLen)
+	k = append(k, sysHistoryIndexPrefix...)
+	k = binary.BigEndian.AppendUint64(k, supersededAt)
+	if historyKey != nil {
+		k = append(k, historyKey[prefixLen:]...)
+	}
+	return mustEncodeKey(key.Key{KeyType: key.TypeSystem, Key: k})
+}
+
+// decodeHistoryKey decodes the user key and the revision from the history key, the user key is appended to dst.
+func decodeHistoryKey(dst []byte, raw []byte) ([]byte, uint64, error) {
+	if len(raw) < len(historyLowerBound)+historyKeySuffixLen {
+		return nil, 0, errMalformedHistoryKey
+	}
+	body := raw[len(historyLowerBound) : len(raw)-8]
+	revision := binary.BigEndian.Uint64(raw[len(raw)-8:])
+	for i := 0; i < len(body); i++ {
+		if body[i] != escapeByte {
+			dst = append(dst, body[i])
+			continue
+		}
+		if i+1 >= len(body) {
+			return nil, 0, errMalformedHistoryKey
+		}
+		i++
+		switch body[i] {
+		case escapedByte:
+			dst = append(dst, escapeByte)
+		case terminatorByte:
+			if i != len(body)-1 {
+				return nil, 0, errMalformedHistoryKey
+			}
+			return dst, revision, nil
+		default:
+			return nil, 0, errMalformedHistoryKey
+		}
+	}
+	return nil, 0, errMalformedHistoryKey
+}
+
+// appendHistoryValue encodes the superseded version of the key, the revision it was superseded at followed by the value.
+func appendHistoryValue(dst []byte, val storedValue, supersededAt uint64) []byte {
+	dst = binary.AppendUvarint(dst, supersededAt)
+	return appendValue(dst, val)
+}
+
+// decodeHistoryValue decodes the superseded version of the key and the revision it was superseded at.
+func decodeHistoryValue(raw []byte) (storedValue, uint64, error) {
+	supersededAt, n := binary.Uvarint(raw)
+	if n <= 0 {
+		return storedValue{}, 0, errMalformedValue
+	}
+	val, err := decodeValue(raw[n:])
+	return val, supersededAt, err
+}
+
+// recordHistory keeps the previous version of the user key superseded (overwritten or deleted) by the current command.
+// Versions modified by the current command are not kept as they are not visible at any revision,
+// the chunks of such a large value are discarded.
+func recordHistory(ctx *updateContext, userKey []byte, prev storedValue) error {
+	if prev.modRevision >= ctx.revision {
+		if prev.large != nil {
+			return discardChunks(ctx, prev.large.id)
+		}
+		return nil
+	}
+	hk := historyKey(userKey, prev.modRevision)
+	valBuf := bufferPool.Get()
+	defer bufferPool.Put(valBuf)
+	valBuf.Write(appendHistoryValue(valBuf.AvailableBuffer(), prev, ctx.revision))
+	if err := ctx.batch.Set(hk, valBuf.Bytes(), nil); err != nil {
+		return err
+	}
+	return ctx.batch.Set(historyIndexKey(ctx.revision, hk), nil, nil)
+}
+
+// recordDeleteHistory keeps the versions of the user keys deleted by the operation, the history of the keys within the range
+// is dropped if the operation deletes more than maxDeleteHistoryKeys keys.
+func recordDeleteHistory(ctx *updateContext, del *regattapb.RequestOp_DeleteRange) error {
+	if err := ctx.EnsureIndexed(); err != nil {
+		return err
+	}
+	if del.RangeEnd == nil {
+		keyBuf := bufferPool.Get()
+		defer bufferPool.Put(keyBuf)
+		if err := encodeUserKey(keyBuf, del.Key); err != nil {
+			return err
+		}
+		raw, closer, err := ctx.batch.Get(keyBuf.Bytes())
+		if err != nil {
+			if errors.Is(err, pebble.ErrNotFound) {
+				return nil
+			}
+			return err
+		}
+		defer func() {
+			_ = closer.Close()
+		}()
+		prev, err := decodeValue(raw)
+		if err != nil {
+			return err
+		}
+		return recordHistory(ctx, del.Key, prev)
+	}
+
+	opts, err := iterOptionsForBounds(del.Key, del.RangeEnd)
+	if err != nil {
+		return err
+	}
+	iter := userIter{Iterator: ctx.batch.NewIter(opts)}
+	defer func() {
+		_ = iter.Close()
+	}()
+	n := 0
+	for iter.First(); iter.Valid() && n <= maxDeleteHistoryKeys; iter.Next() {
+		n++
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	if n > maxDeleteHistoryKeys {
+		return discardDeleteHistory(ctx, iter, del)
+	}
+	for iter.First(); iter.Valid(); iter.Next() {
+		k, prev, err := iter.Entry()
+		if err != nil {
+			return err
+		}
+		if err := recordHistory(ctx, k, prev); err != nil {
+			return err
+		}
+	}
+	return iter.Error()
+}
+
+// discardDeleteHistory drops the history of the user keys within the range of the operation instead of keeping the versions
+// of the keys of the iterator, along with the chunks of the deleted large values. Keeping the versions of a large range would
+// grow the batch of the command by the size of all the deleted values. The keys of the range are missing from the reads
+// at the revisions preceding the delete, the history of the keys outside the range is kept.
+func discardDeleteHistory(ctx *updateContext, iter userIter, del *regattapb.RequestOp_DeleteRange) error {
+	for iter.First(); iter.Valid(); iter.Next() {
+		_, prev, err := iter.Entry()
+		if err != nil {
+			return err
+		}
+		if prev.large != nil {
+			if err := discardChunks(ctx, prev.large.id); err != nil {
+				return err
+			}
+		}
+		ctx.discardedHistoryKeys++
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+
+	lower, upper := historyBound(del.Key), historyUpperBound
+	if !bytes.Equal(del.RangeEnd, wildcard) {
+		upper = historyBound(del.RangeEnd)
+	}
+	hiter := ctx.batch.NewIter(&pebble.IterOptions{LowerBound: lower, UpperBound: upper})
+	for hiter.First(); hiter.Valid(); hiter.Next() {
+		val, _, err := decodeHistoryValue(hiter.Value())
+		if err != nil {
+			_ = hiter.Close()
+			return err
+		}
+		if val.large != nil {
+			if err := discardChunks(ctx, val.large.id); err != nil {
+				_ = hiter.Close()
+				return err
+			}
+		}
+	}
+	if err := hiter.Close(); err != nil {
+		return err
+	}
+	// The index keys of the dropped versions are left to the compaction, the versions are discarded already.
+	return ctx.batch.DeleteRange(lower, upper, nil)
+}
+
+// compactHistory discards the versions of the keys not visible at the revision or any later one,
+// i.e. the versions superseded at the revision or earlier, along with the chunks of the discarded large values.
+func compactHistory(ctx *updateContext, revision uint64) error {
+	if err := ctx.EnsureIndexed(); err != nil {
+		return err
+	}
+	lower := historyIndexKey(0, nil)
+	upper := historyIndexKey(revision+1, nil)
+	iter := ctx.batch.NewIter(&pebble.IterOptions{LowerBound: lower, UpperBound: upper})
+	prefixLen := len(lower)
+	hk := append([]byte(nil), historyLowerBound...)
+	for iter.First(); iter.Valid(); iter.Next() {
+		hk = append(hk[:len(historyLowerBound)], iter.Key()[prefixLen:]...)
+		if err := discardHistoryChunks(ctx, hk); err != nil {
+			_ = iter.Close()
+			return err
+		}
+		if err := ctx.batch.Delete(hk, nil); err != nil {
+			_ = iter.Close()
+			return err
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return err
+	}
+	if err := ctx.batch.DeleteRange(lower, upper, nil); err != nil {
+		return err
+	}
+	rev := make([]byte, 8)
+	binary.LittleEndian.PutUint64(rev, revision)
+	return ctx.batch.Set(sysCompactRevision, rev, nil)
+}
+
+// discardHistoryChunks discards the chunks of the version stored under the history key if it is a large value.
+func discardHistoryChunks(ctx *updateContext, hk []byte) error {
+	raw, closer, err := ctx.batch.Get(hk)
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	defer func() {
+		_ = closer.Close()
+	}()
+	val, _, err := decodeHistoryValue(raw)
+	if err != nil || val.large == nil {
+		return err
+	}
+	return discardChunks(ctx, val.large.id)
+}
+
+// readRevision reads the current revision of the table, it is the leader index for the replicated tables.
+func readRevision(reader pebble.Reader) (uint64, error) {
+	idx, err := readLocalIndex(reader, sysLeaderIndex)
+	if err != nil || idx != 0 {
+		return idx, err
+	}
+	return readLocalIndex(reader, sysLocalIndex)
+}
+
+// checkRevision checks that the table could be read at the revision, serrors.ErrCompacted is returned
+// for the compacted revisions and serrors.ErrFutureRevision for the revisions newer than the current revision.
+func checkRevision(reader pebble.Reader, revision uint64) error {
+	compacted, err := readLocalIndex(reader, sysCompactRevision)
+	if err != nil {
+		return err
+	}
+	if revision < compacted {
+		return serrors.ErrCompacted
+	}
+	current, err := readRevision(reader)
+	if err != nil {
+		return err
+	}
+	if revision > current {
+		return serrors.ErrFutureRevision
+	}
+	return nil
+}
+
+// revisionIter iterates the user key-value pairs of a range as they were at the revision. It merges the current
+// key-value pairs with the superseded versions of the keys, the newest version modified at the revision or earlier
+// is visible unless it was superseded at the revision or earlier. The keys are iterated in the descending order if reverse is set.
+type revisionIter struct {
+	current  *pebble.Iterator
+	history  *pebble.Iterator
+	revision uint64
+	reverse  bool
+
+	key     []byte
+	value   storedValue
+	data    []byte
+	histKey []byte
+	err     error
+	valid   bool
+}
+
+func newRevisionIter(reader pebble.Reader, low, high []byte, revision uint64, reverse bool) (*revisionIter, error) {
+	opts, err := iterOptionsForBounds(low, high)
+	if err != nil {
+		return nil, err
+	}
+	historyOpts := &pebble.IterOptions{LowerBound: historyBound(low), UpperBound: historyUpperBound}
+	if !bytes.Equal(high, wildcard) {
+		historyOpts.UpperBound = historyBound(high)
+	}
+	return &revisionIter{
+		current:  reader.NewIter(opts),
+		history:  reader.NewIter(historyOpts),
+		revision: revision,
+		reverse:  reverse,
+	}, nil
+}
+
+func (it *revisionIter) First() bool {
+	if it.reverse {
+		it.current.Last()
+		it.history.Last()
+	} else {
+		it.current.First()
+		it.history.First()
+	}
+	it.decodeHistory()
+	return it.advance()
+}
+
+func (it *revisionIter) Next() bool {
+	return it.advance()
+}
+
+func (it *revisionIter) Valid() bool {
+	return it.valid
+}
+
+// Entry returns the current key and value, they are valid only until the iterator is moved.
+func (it *revisionIter) Entry() ([]byte, storedValue, error) {
+	return it.key, it.value, nil
+}
+
+func (it *revisionIter) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+	if err := it.current.Error(); err != nil {
+		return err
+	}
+	return it.history.Error()
+}
+
+func (it *revisionIter) Close() error {
+	err := it.current.Close()
+	if herr := it.history.Close(); err == nil {
+		err = herr
+	}
+	return err
+}
+
+// decodeHistory decodes the user key of the history iterator position into histKey (nil if the iterator is exhausted).
+func (it *revisionIter) decodeHistory() {
+	if !it.history.Valid() {
+		it.histKey = nil
+		return
+	}
+	k, _, err := decodeHistoryKey(it.histKey[:0], it.history.Key())
+	if err != nil {
+		it.err = err
+	}
+	it.histKey = k
+}
+
+// step moves the underlying iterator in the iteration order.
+func (it *revisionIter) step(iter *pebble.Iterator) {
+	if it.reverse {
+		iter.Prev()
+	} else {
+		iter.Next()
+	}
+}
+
+// before reports whether the user key a is iterated before (or together with) the user key b.
+func (it *revisionIter) before(a, b []byte) bool {
+	if it.reverse {
+		return bytes.Compare(a, b) >= 0
+	}
+	return bytes.Compare(a, b) <= 0
+}
+
+// advance moves the iterator to the next user key visible at the revision.
+func (it *revisionIter) advance() bool {
+	for it.err == nil {
+		var currentKey []byte
+		if it.current.Valid() {
+			k, err := key.DecodeBytes(it.current.Key())
+			if err != nil {
+				it.err = err
+				break
+			}
+			currentKey = k.Key
+		}
+		if currentKey == nil && it.histKey == nil {
+			break
+		}
+		// Pick the key of the current and history iterators which comes first in the iteration order.
+		var userKey []byte
+		switch {
+		case currentKey == nil:
+			userKey = it.histKey
+		case it.histKey == nil:
+			userKey = currentKey
+		case it.before(currentKey, it.histKey):
+			userKey = currentKey
+		default:
+			userKey = it.histKey
+		}
+		it.key = append(it.key[:0], userKey...)
+
+		found := false
+		if currentKey != nil && bytes.Equal(currentKey, it.key) {
+			value, err := decodeValue(it.current.Value())
+			if err != nil {
+				it.err = err
+				break
+			}
+			if value.modRevision <= it.revision {
+				it.setValue(value)
+				found = true
+			}
+			it.step(it.current)
+		}
+
+		// The versions of the key do not overlap, at most one of them was modified at the revision or earlier
+		// and superseded after the revision.
+		var candidate bool
+		for it.histKey != nil && bytes.Equal(it.histKey, it.key) {
+			if !found && !candidate {
+				value, supersededAt, err := decodeHistoryValue(it.history.Value())
+				if err != nil {
+					it.err = err
+					break
+				}
+				if value.modRevision <= it.revision && supersededAt > it.revision {
+					it.setValue(value)
+					candidate = true
+				}
+			}
+			it.step(it.history)
+			it.decodeHistory()
+		}
+		if it.err != nil {
+			break
+		}
+		if found || candidate {
+			it.valid = true
+			return true
+		}
+	}
+	it.valid = false
+	return false
+}
+
+// setValue sets the value copying the data out of the iterator.
+func (it *revisionIter) setValue(value storedValue) {
+	it.data = append(it.data[:0], value.data...)
+	value.data = it.data
+	it.value = value
+}
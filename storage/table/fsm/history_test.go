@@ -0,0 +1,239 @@
+// Copyright JAMF Software, LLC
+
+package fsm
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/jamf/regatta/regattapb"
+	serrors "github.com/jamf/regatta/storage/errors"
+	sm "github.com/lni/dragonboat/v4/statemachine"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func historySM(t *testing.T) *FSM {
+	commands := []*regattapb.Command{
+		{Type: regattapb.Command_PUT, Kv: &regattapb.KeyValue{Key: []byte("a"), Value: []byte("v1")}},
+		{Type: regattapb.Command_PUT, Kv: &regattapb.KeyValue{Key: []byte("b"), Value: []byte("v1")}},
+		{Type: regattapb.Command_PUT, Kv: &regattapb.KeyValue{Key: []byte("a"), Value: []byte("v2")}},
+		{Type: regattapb.Command_DELETE, Kv: &regattapb.KeyValue{Key: []byte("b")}},
+		{Type: regattapb.Command_PUT_BATCH, Batch: []*regattapb.KeyValue{
+			{Key: []byte("a\x00"), Value: []byte("v1")},
+			{Key: []byte("c"), Value: []byte("v1")},
+		}},
+		{Type: regattapb.Command_DELETE, Kv: &regattapb.KeyValue{Key: []byte("a")}, RangeEnd: []byte("d")},
+		{Type: regattapb.Command_PUT, Kv: &regattapb.KeyValue{Key: []byte("a"), Value: []byte("v3")}},
+	}
+	fsm := emptySM()
+	for i, cmd := range commands {
+		cmd.Table = []byte(testTable)
+		_, err := fsm.Update([]sm.Entry{{Index: uint64(i + 1), Cmd: mustMarshallProto(cmd)}})
+		require.NoError(t, err)
+	}
+	return fsm
+}
+
+func TestFSM_Lookup_RangeRevision(t *testing.T) {
+	fsm := historySM(t)
+	defer fsm.Close()
+
+	kv := func(key, value string, create, mod, version int64) *regattapb.KeyValue {
+		return &regattapb.KeyValue{Key: []byte(key), Value: []byte(value), CreateRevision: create, ModRevision: mod, Version: version}
+	}
+	tests := []struct {
+		name    string
+		req     *regattapb.RequestOp_Range
+		want    []*regattapb.KeyValue
+		wantErr error
+	}{
+		{
+			name: "first revision",
+			req:  &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}, Revision: 1},
+			want: []*regattapb.KeyValue{kv("a", "v1", 1, 1, 1)},
+		},
+		{
+			name: "overwritten key",
+			req:  &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}, Revision: 3},
+			want: []*regattapb.KeyValue{kv("a", "v2", 1, 3, 2), kv("b", "v1", 2, 2, 1)},
+		},
+		{
+			name: "deleted key",
+			req:  &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}, Revision: 4},
+			want: []*regattapb.KeyValue{kv("a", "v2", 1, 3, 2)},
+		},
+		{
+			name: "keys sharing a prefix",
+			req:  &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}, Revision: 5},
+			want: []*regattapb.KeyValue{kv("a", "v2", 1, 3, 2), kv("a\x00", "v1", 5, 5, 1), kv("c", "v1", 5, 5, 1)},
+		},
+		{
+			name: "keys sharing a prefix in descending order",
+			req:  &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}, Revision: 5, SortOrder: regattapb.SortOrder_DESCEND},
+			want: []*regattapb.KeyValue{kv("c", "v1", 5, 5, 1), kv("a\x00", "v1", 5, 5, 1), kv("a", "v2", 1, 3, 2)},
+		},
+		{
+			name: "overwritten key in descending order",
+			req:  &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}, Revision: 3, SortOrder: regattapb.SortOrder_DESCEND},
+			want: []*regattapb.KeyValue{kv("b", "v1", 2, 2, 1), kv("a", "v2", 1, 3, 2)},
+		},
+		{
+			name: "single key",
+			req:  &regattapb.RequestOp_Range{Key: []byte("a"), Revision: 5},
+			want: []*regattapb.KeyValue{kv("a", "v2", 1, 3, 2)},
+		},
+		{
+			name: "range deleted keys",
+			req:  &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}, Revision: 6},
+		},
+		{
+			name: "current revision",
+			req:  &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}, Revision: 7},
+			want: []*regattapb.KeyValue{kv("a", "v3", 7, 7, 1)},
+		},
+		{
+			name: "newest key-value store",
+			req:  &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}},
+			want: []*regattapb.KeyValue{kv("a", "v3", 7, 7, 1)},
+		},
+		{
+			name: "count only",
+			req:  &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}, Revision: 5, CountOnly: true},
+		},
+		{
+			name:    "future revision",
+			req:     &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}, Revision: 8},
+			wantErr: serrors.ErrFutureRevision,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			res, err := fsm.Lookup(tt.req)
+			if tt.wantErr != nil {
+				r.ErrorIs(err, tt.wantErr)
+				return
+			}
+			r.NoError(err)
+			rng := res.(*regattapb.ResponseOp_Range)
+			if tt.req.CountOnly {
+				r.Equal(int64(3), rng.Count)
+				return
+			}
+			r.Equal(tt.want, rng.Kvs)
+			r.Equal(int64(len(tt.want)), rng.Count)
+		})
+	}
+}
+
+func TestFSM_Compact(t *testing.T) {
+	r := require.New(t)
+	fsm := historySM(t)
+	defer fsm.Close()
+
+	compact := func(index uint64, revision int64) UpdateResult {
+		res, err := fsm.Update([]sm.Entry{{Index: index, Cmd: mustMarshallProto(&regattapb.Command{
+			Table:           []byte(testTable),
+			Type:            regattapb.Command_COMPACT,
+			CompactRevision: revision,
+		})}})
+		r.NoError(err)
+		return UpdateResult(res[0].Result.Value)
+	}
+	all := func(revision int64) *regattapb.RequestOp_Range {
+		return &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}, Revision: revision}
+	}
+
+	r.Equal(ResultSuccess, compact(8, 4))
+	_, err := fsm.Lookup(all(3))
+	r.ErrorIs(err, serrors.ErrCompacted)
+	res, err := fsm.Lookup(all(4))
+	r.NoError(err)
+	r.Equal([]*regattapb.KeyValue{{Key: []byte("a"), Value: []byte("v2"), CreateRevision: 1, ModRevision: 3, Version: 2}}, res.(*regattapb.ResponseOp_Range).Kvs)
+
+	r.Equal(ResultFailure, compact(9, 4), "already compacted revision")
+	r.Equal(ResultFailure, compact(10, 100), "future revision")
+
+	rev, err := fsm.Lookup(RevisionRequest{})
+	r.NoError(err)
+	r.Equal(&RevisionResponse{Revision: 10, CompactRevision: 4}, rev)
+
+	r.Equal(ResultSuccess, compact(11, 11))
+	res, err = fsm.Lookup(all(11))
+	r.NoError(err)
+	r.Len(res.(*regattapb.ResponseOp_Range).Kvs, 1)
+
+	iter := fsm.pebble.Load().NewIter(&pebble.IterOptions{LowerBound: historyLowerBound, UpperBound: historyUpperBound})
+	r.False(iter.First(), "history must be compacted")
+	r.NoError(iter.Close())
+}
+
+func TestFSM_DeleteRangeHistoryBound(t *testing.T) {
+	r := require.New(t)
+	fsm := emptySM()
+	defer fsm.Close()
+
+	update := func(index uint64, cmd *regattapb.Command) {
+		cmd.Table = []byte(testTable)
+		res, err := fsm.Update([]sm.Entry{{Index: index, Cmd: mustMarshallProto(cmd)}})
+		r.NoError(err)
+		r.Equal(ResultSuccess, UpdateResult(res[0].Result.Value))
+	}
+	historyKeys := func() int {
+		iter := fsm.pebble.Load().NewIter(&pebble.IterOptions{LowerBound: historyLowerBound, UpperBound: historyUpperBound})
+		defer func() {
+			r.NoError(iter.Close())
+		}()
+		n := 0
+		for iter.First(); iter.Valid(); iter.Next() {
+			n++
+		}
+		return n
+	}
+
+	batch := make([]*regattapb.KeyValue, maxDeleteHistoryKeys)
+	for i := range batch {
+		batch[i] = &regattapb.KeyValue{Key: []byte(fmt.Sprintf("key/%05d", i)), Value: []byte("value")}
+	}
+	update(1, &regattapb.Command{Type: regattapb.Command_PUT_BATCH, Batch: batch})
+	update(2, &regattapb.Command{Type: regattapb.Command_PUT, Kv: &regattapb.KeyValue{Key: []byte("key/00000"), Value: []byte("v2")}})
+
+	t.Log("range within the bound keeps the history")
+	update(3, &regattapb.Command{Type: regattapb.Command_DELETE, Kv: &regattapb.KeyValue{Key: []byte("key/")}, RangeEnd: []byte("key/00010")})
+	r.Equal(11, historyKeys())
+	res, err := fsm.Lookup(&regattapb.RequestOp_Range{Key: []byte("key/"), RangeEnd: []byte("key0"), Revision: 2, CountOnly: true})
+	r.NoError(err)
+	r.Equal(int64(maxDeleteHistoryKeys), res.(*regattapb.ResponseOp_Range).Count)
+
+	t.Log("range over the bound drops the history of the keys within the range")
+	update(4, &regattapb.Command{Type: regattapb.Command_PUT_BATCH, Batch: batch[:10]})
+	value := bytes.Repeat([]byte("0123456789"), 1000)
+	entries := largeValueEntries(5, 42, []byte("key/large"), value, 3000, 0)
+	_, err = fsm.Update(entries)
+	r.NoError(err)
+	r.Equal(4, countChunks(t, fsm, 42))
+	update(10, &regattapb.Command{Type: regattapb.Command_PUT, Kv: &regattapb.KeyValue{Key: []byte("other"), Value: []byte("v1")}})
+	update(11, &regattapb.Command{Type: regattapb.Command_PUT, Kv: &regattapb.KeyValue{Key: []byte("other"), Value: []byte("v2")}})
+	update(12, &regattapb.Command{Type: regattapb.Command_DELETE, Kv: &regattapb.KeyValue{Key: []byte("key/")}, RangeEnd: []byte("key0")})
+	r.Equal(1, historyKeys())
+	r.Equal(0, countChunks(t, fsm, 42))
+	r.Equal(float64(maxDeleteHistoryKeys+1), testutil.ToFloat64(fsm.metrics.discardedHistoryKeys))
+	res, err = fsm.Lookup(&regattapb.RequestOp_Range{Key: []byte("key/"), RangeEnd: []byte("key0"), Revision: 11})
+	r.NoError(err)
+	r.Empty(res.(*regattapb.ResponseOp_Range).Kvs)
+
+	t.Log("history of the keys outside the range is kept")
+	res, err = fsm.Lookup(&regattapb.RequestOp_Range{Key: []byte("other"), Revision: 10})
+	r.NoError(err)
+	r.Equal([]byte("v1"), res.(*regattapb.ResponseOp_Range).Kvs[0].Value)
+	rev, err := fsm.Lookup(RevisionRequest{})
+	r.NoError(err)
+	r.Equal(&RevisionResponse{Revision: 12}, rev)
+
+	t.Log("compaction skips the dropped versions")
+	update(13, &regattapb.Command{Type: regattapb.Command_COMPACT, CompactRevision: 12})
+	r.Equal(0, historyKeys())
+}
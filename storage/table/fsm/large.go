@@ -0,0 +1,147 @@
+// Copyright JAMF Software, LLC
+
+package fsm
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/jamf/regatta/regattapb"
+	"github.com/jamf/regatta/storage/table/key"
+)
+
+var (
+	// sysChunkPrefix prefix of the system keys holding the chunks of the large values, the prefix is followed
+	// by the big endian ID of the large value and the big endian index of the chunk.
+	sysChunkPrefix = []byte("chunk/")
+
+	errMissingChunks = errors.New("chunks of the large value are missing")
+)
+
+// chunkKey encodes the system key of the chunk of the large value.
+func chunkKey(id uint64, index uint32) []byte {
+	k := make([]byte, 0, len(sysChunkPrefix)+8+4)
+	k = append(k, sysChunkPrefix...)
+	k = binary.BigEndian.AppendUint64(k, id)
+	k = binary.BigEndian.AppendUint32(k, index)
+	return mustEncodeKey(key.Key{KeyType: key.TypeSystem, Key: k})
+}
+
+// chunkBounds encodes the bounds of the system keys of all the chunks of the large value.
+func chunkBounds(id uint64) ([]byte, []byte) {
+	k := make([]byte, 0, len(sysChunkPrefix)+8)
+	k = append(k, sysChunkPrefix...)
+	k = binary.BigEndian.AppendUint64(k, id)
+	lower := mustEncodeKey(key.Key{KeyType: key.TypeSystem, Key: k})
+	return lower, incrementRightmostByte(append([]byte(nil), lower...))
+}
+
+// discardChunks deletes all the chunks of the large value.
+func discardChunks(ctx *updateContext, id uint64) error {
+	lower, upper := chunkBounds(id)
+	return ctx.batch.DeleteRange(lower, upper, nil)
+}
+
+// streamLargeValue calls the send function with every chunk of the large value in order, the chunk is valid only
+// until the function returns. errMissingChunks is returned if the stored chunks do not match the large value.
+func streamLargeValue(reader pebble.Reader, large *largeValue, send func(chunk []byte) error) error {
+	lower, upper := chunkBounds(large.id)
+	iter := reader.NewIter(&pebble.IterOptions{LowerBound: lower, UpperBound: upper})
+	defer func() {
+		_ = iter.Close()
+	}()
+	var chunks, size uint64
+	for iter.First(); iter.Valid(); iter.Next() {
+		if chunks == large.chunks || !iterAtChunk(iter, uint32(chunks)) {
+			return errMissingChunks
+		}
+		if err := send(iter.Value()); err != nil {
+			return err
+		}
+		chunks++
+		size += uint64(len(iter.Value()))
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	if chunks != large.chunks || size != large.size {
+		return errMissingChunks
+	}
+	return nil
+}
+
+// iterAtChunk reports whether the iterator is positioned at the chunk with the index.
+func iterAtChunk(iter *pebble.Iterator, index uint32) bool {
+	k := iter.Key()
+	return len(k) >= 4 && binary.BigEndian.Uint32(k[len(k)-4:]) == index
+}
+
+// readLargeValue reads the chunks of the large value into a single slice.
+func readLargeValue(reader pebble.Reader, large *largeValue) ([]byte, error) {
+	data := make([]byte, 0, large.size)
+	err := streamLargeValue(reader, large, func(chunk []byte) error {
+		data = append(data, chunk...)
+		return nil
+	})
+	return data, err
+}
+
+// resolveValue reads the data of the large value, other values are returned unchanged.
+func resolveValue(reader pebble.Reader, value storedValue) (storedValue, error) {
+	if value.large == nil {
+		return value, nil
+	}
+	data, err := readLargeValue(reader, value.large)
+	if err != nil {
+		return storedValue{}, err
+	}
+	value.data = data
+	value.large = nil
+	return value, nil
+}
+
+// largeValueLookup streams the value of the key as it was at the revision (the current value if the revision is not set)
+// into the send function, the key-value pair (without the value) is passed along with the first part of the value.
+// The value which is not large is sent as a single part. False is returned if the key does not exist.
+func largeValueLookup(reader pebble.Reader, req LargeValueRequest, send func(kv *regattapb.KeyValue, part []byte) error) (bool, error) {
+	if req.Revision > 0 {
+		if err := checkRevision(reader, uint64(req.Revision)); err != nil {
+			return false, err
+		}
+	}
+	// The range end makes the iterator bound to the single key.
+	end := append(append(make([]byte, 0, len(req.Key)+1), req.Key...), 0)
+	iter, err := newRangeIter(reader, &regattapb.RequestOp_Range{Key: req.Key, RangeEnd: end, Revision: req.Revision})
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = iter.Close()
+	}()
+	if !iter.First() {
+		return false, iter.Error()
+	}
+	k, value, err := iter.Entry()
+	if err != nil {
+		return false, err
+	}
+	kv := newKeyValue(k, storedValue{createRevision: value.createRevision, modRevision: value.modRevision, version: value.version, lease: value.lease})
+	if value.large == nil {
+		return true, send(kv, value.data)
+	}
+	if value.large.chunks == 0 {
+		return true, send(kv, nil)
+	}
+	err = streamLargeValue(reader, value.large, func(chunk []byte) error {
+		select {
+		case <-req.Stopper:
+			return ErrCursorStopped
+		default:
+		}
+		err := send(kv, chunk)
+		kv = nil
+		return err
+	})
+	return true, err
+}
@@ -0,0 +1,241 @@
+// Copyright JAMF Software, LLC
+
+package fsm
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/jamf/regatta/regattapb"
+	serrors "github.com/jamf/regatta/storage/errors"
+	"github.com/jamf/regatta/storage/table/key"
+	sm "github.com/lni/dragonboat/v4/statemachine"
+	"github.com/stretchr/testify/require"
+)
+
+// largeValueEntries returns the entries storing the value as the large value with the ID in chunks of the chunk size.
+func largeValueEntries(index uint64, id uint64, key, value []byte, chunkSize int, lease int64) []sm.Entry {
+	var entries []sm.Entry
+	var chunks uint32
+	for off := 0; off < len(value); off += chunkSize {
+		end := min(off+chunkSize, len(value))
+		entries = append(entries, sm.Entry{
+			Index: index,
+			Cmd: mustMarshallProto(&regattapb.Command{
+				Table: []byte(testTable),
+				Type:  regattapb.Command_PUT_CHUNK,
+				Chunk: &regattapb.Chunk{Id: id, Index: chunks, Data: value[off:end]},
+			}),
+		})
+		index++
+		chunks++
+	}
+	return append(entries, sm.Entry{
+		Index: index,
+		Cmd: mustMarshallProto(&regattapb.Command{
+			Table:      []byte(testTable),
+			Type:       regattapb.Command_PUT_LARGE,
+			Kv:         &regattapb.KeyValue{Key: key, Lease: lease},
+			LargeValue: &regattapb.LargeValue{Id: id, Chunks: chunks, Size: uint64(len(value))},
+		}),
+	})
+}
+
+func countChunks(t *testing.T, p *FSM, id uint64) int {
+	lower, upper := chunkBounds(id)
+	iter := p.pebble.Load().NewIter(&pebble.IterOptions{LowerBound: lower, UpperBound: upper})
+	defer func() {
+		require.NoError(t, iter.Close())
+	}()
+	n := 0
+	for iter.First(); iter.Valid(); iter.Next() {
+		n++
+	}
+	return n
+}
+
+func TestSM_PutLarge(t *testing.T) {
+	r := require.New(t)
+	p := emptySM()
+	defer func() {
+		r.NoError(p.Close())
+	}()
+
+	value := bytes.Repeat([]byte("0123456789"), 1000)
+	entries := largeValueEntries(1, 42, []byte("key"), value, 3000, 0)
+	_, err := p.Update(entries)
+	r.NoError(err)
+	r.Equal(4, countChunks(t, p, 42))
+	r.Equal(ResultSuccess, UpdateResult(entries[len(entries)-1].Result.Value))
+
+	t.Log("range reassembles the large value")
+	res, err := p.Lookup(&regattapb.RequestOp_Range{Key: []byte("key")})
+	r.NoError(err)
+	r.Equal([]*regattapb.KeyValue{{Key: []byte("key"), Value: value, CreateRevision: 5, ModRevision: 5, Version: 1}}, res.(*regattapb.ResponseOp_Range).Kvs)
+	res, err = p.Lookup(&regattapb.RequestOp_Range{Key: []byte("key"), RangeEnd: []byte{0}, KeysOnly: true})
+	r.NoError(err)
+	r.Equal([]*regattapb.KeyValue{{Key: []byte("key"), CreateRevision: 5, ModRevision: 5, Version: 1}}, res.(*regattapb.ResponseOp_Range).Kvs)
+
+	t.Log("txn compares the reassembled value")
+	res, err = p.Lookup(&regattapb.TxnRequest{Compare: []*regattapb.Compare{{
+		Key:         []byte("key"),
+		Target:      regattapb.Compare_VALUE,
+		Result:      regattapb.Compare_EQUAL,
+		TargetUnion: &regattapb.Compare_Value{Value: value},
+	}}})
+	r.NoError(err)
+	r.True(res.(*regattapb.TxnResponse).Succeeded)
+
+	t.Log("large value is streamed chunk by chunk")
+	var parts [][]byte
+	var kvs []*regattapb.KeyValue
+	res, err = p.Lookup(LargeValueRequest{Key: []byte("key"), Send: func(kv *regattapb.KeyValue, part []byte, _ uint64) error {
+		kvs = append(kvs, kv)
+		parts = append(parts, bytes.Clone(part))
+		return nil
+	}})
+	r.NoError(err)
+	r.Equal(&LargeValueResponse{Index: 5, Found: true}, res)
+	r.Len(parts, 4)
+	r.Equal(value, bytes.Join(parts, nil))
+	r.Equal(&regattapb.KeyValue{Key: []byte("key"), CreateRevision: 5, ModRevision: 5, Version: 1}, kvs[0])
+	r.Nil(kvs[1])
+
+	t.Log("overwritten large value is kept in the history until compacted")
+	_, err = p.Update([]sm.Entry{{Index: 6, Cmd: mustMarshallProto(&regattapb.Command{
+		Table: []byte(testTable),
+		Type:  regattapb.Command_PUT,
+		Kv:    &regattapb.KeyValue{Key: []byte("key"), Value: []byte("small")},
+	})}})
+	r.NoError(err)
+	res, err = p.Lookup(&regattapb.RequestOp_Range{Key: []byte("key"), Revision: 5})
+	r.NoError(err)
+	r.Equal(value, res.(*regattapb.ResponseOp_Range).Kvs[0].Value)
+	res, err = p.Lookup(LargeValueRequest{Key: []byte("key"), Send: func(kv *regattapb.KeyValue, part []byte, _ uint64) error {
+		r.Equal([]byte("small"), part)
+		return nil
+	}})
+	r.NoError(err)
+	r.Equal(&LargeValueResponse{Index: 6, Found: true}, res)
+
+	_, err = p.Update([]sm.Entry{{Index: 7, Cmd: mustMarshallProto(&regattapb.Command{
+		Table:           []byte(testTable),
+		Type:            regattapb.Command_COMPACT,
+		CompactRevision: 6,
+	})}})
+	r.NoError(err)
+	r.Equal(0, countChunks(t, p, 42))
+
+	res, err = p.Lookup(LargeValueRequest{Key: []byte("missing"), Send: func(*regattapb.KeyValue, []byte, uint64) error {
+		r.Fail("missing key must not be sent")
+		return nil
+	}})
+	r.NoError(err)
+	r.Equal(&LargeValueResponse{Index: 7}, res)
+}
+
+func TestSM_PutLargeLeaseNotFound(t *testing.T) {
+	r := require.New(t)
+	p := emptySM()
+	defer func() {
+		r.NoError(p.Close())
+	}()
+
+	entries := largeValueEntries(1, 42, []byte("key"), []byte("value"), 2, 10)
+	_, err := p.Update(entries)
+	r.NoError(err)
+	r.Equal(ResultLeaseNotFound, UpdateResult(entries[len(entries)-1].Result.Value))
+	r.Equal(0, countChunks(t, p, 42))
+	res, err := p.Lookup(&regattapb.RequestOp_Range{Key: []byte("key")})
+	r.NoError(err)
+	r.Empty(res.(*regattapb.ResponseOp_Range).Kvs)
+}
+
+func TestSM_RangeValueTooLarge(t *testing.T) {
+	r := require.New(t)
+	p := emptySM()
+	defer func() {
+		r.NoError(p.Close())
+	}()
+
+	value := bytes.Repeat([]byte{1}, int(maxRangeSize))
+	entries := largeValueEntries(1, 42, []byte("key"), value, 1024*1024, 0)
+	_, err := p.Update(entries)
+	r.NoError(err)
+
+	_, err = p.Lookup(&regattapb.RequestOp_Range{Key: []byte("key")})
+	r.ErrorIs(err, serrors.ErrValueTooLarge)
+	_, err = p.Lookup(&regattapb.RequestOp_Range{Key: []byte("key"), RangeEnd: []byte{0}})
+	r.ErrorIs(err, serrors.ErrValueTooLarge)
+	res, err := p.Lookup(&regattapb.RequestOp_Range{Key: []byte("key"), RangeEnd: []byte{0}, CountOnly: true})
+	r.NoError(err)
+	r.Equal(int64(1), res.(*regattapb.ResponseOp_Range).Count)
+}
+
+func TestSM_RangeMaxValueSize(t *testing.T) {
+	r := require.New(t)
+	p := emptySM()
+	defer func() {
+		r.NoError(p.Close())
+	}()
+
+	k := bytes.Repeat([]byte{'k'}, key.LatestVersionLen)
+	_, err := p.Update([]sm.Entry{{Index: 1, Cmd: mustMarshallProto(&regattapb.Command{
+		Table: []byte(testTable),
+		Type:  regattapb.Command_PUT,
+		Kv:    &regattapb.KeyValue{Key: k, Value: bytes.Repeat([]byte{1}, int(MaxValueSize))},
+	})}})
+	r.NoError(err)
+
+	res, err := p.Lookup(&regattapb.RequestOp_Range{Key: k})
+	r.NoError(err)
+	r.Len(res.(*regattapb.ResponseOp_Range).Kvs, 1)
+	r.Less((&regattapb.RangeResponse{Header: &regattapb.ResponseHeader{}, Kvs: res.(*regattapb.ResponseOp_Range).Kvs}).SizeVT(), 4*1024*1024)
+}
+
+// commandsWriter collects the commands written into the command snapshot, every command is written by a single call.
+type commandsWriter struct {
+	cmds []*regattapb.Command
+}
+
+func (w *commandsWriter) Write(p []byte) (int, error) {
+	cmd := &regattapb.Command{}
+	if err := cmd.UnmarshalVT(p); err != nil {
+		return 0, err
+	}
+	w.cmds = append(w.cmds, cmd)
+	return len(p), nil
+}
+
+func TestFSM_Lookup_SnapshotLarge(t *testing.T) {
+	r := require.New(t)
+	p := emptySM()
+	defer func() {
+		r.NoError(p.Close())
+	}()
+	value := []byte("large value")
+	_, err := p.Update(largeValueEntries(1, 42, []byte("key"), value, 4, 0))
+	r.NoError(err)
+
+	w := &commandsWriter{}
+	_, err = p.Lookup(SnapshotRequest{Writer: w, Stopper: make(chan struct{})})
+	r.NoError(err)
+	r.Len(w.cmds, 4)
+	r.Equal(regattapb.Command_PUT_CHUNK, w.cmds[0].Type)
+	r.Equal(regattapb.Command_PUT_LARGE, w.cmds[3].Type)
+
+	restored := emptySM()
+	defer func() {
+		r.NoError(restored.Close())
+	}()
+	var entries []sm.Entry
+	for i, cmd := range w.cmds {
+		entries = append(entries, sm.Entry{Index: uint64(10 + i), Cmd: mustMarshallProto(cmd)})
+	}
+	_, err = restored.Update(entries)
+	r.NoError(err)
+	res, err := restored.Lookup(&regattapb.RequestOp_Range{Key: []byte("key")})
+	r.NoError(err)
+	r.Equal([]*regattapb.KeyValue{{Key: []byte("key"), Value: value, CreateRevision: 4, ModRevision: 4, Version: 1}}, res.(*regattapb.ResponseOp_Range).Kvs)
+}
@@ -0,0 +1,177 @@
+// Copyright JAMF Software, LLC
+
+package fsm
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/jamf/regatta/regattapb"
+	serrors "github.com/jamf/regatta/storage/errors"
+	"github.com/jamf/regatta/storage/table/key"
+)
+
+var (
+	// sysLeasePrefix prefix of the system keys holding the leases (the key is followed by the big endian lease ID).
+	sysLeasePrefix = []byte("lease/")
+	// sysLeaseKeyPrefix prefix of the system keys attaching the user keys to the leases (the key is followed by
+	// the big endian lease ID and the user key). The attachments are not removed when the user key is deleted
+	// so the user key must be checked to be still attached to the lease.
+	sysLeaseKeyPrefix = []byte("lease_key/")
+	leasesLowerBound  = mustEncodeKey(key.Key{KeyType: key.TypeSystem, Key: sysLeasePrefix})
+	leasesUpperBound  = incrementRightmostByte(mustEncodeKey(key.Key{KeyType: key.TypeSystem, Key: sysLeasePrefix}))
+)
+
+// leaseKey encodes the system key of the lease.
+func leaseKey(id uint64) []byte {
+	k := make([]byte, len(sysLeasePrefix)+8)
+	copy(k, sysLeasePrefix)
+	binary.BigEndian.PutUint64(k[len(sysLeasePrefix):], id)
+	return mustEncodeKey(key.Key{
+		KeyType: key.TypeSystem,
+		Key:     k,
+	})
+}
+
+// leaseAttachmentKey encodes the system key attaching the user key to the lease, a nil user key encodes
+// the common prefix of all the attachments of the lease.
+func leaseAttachmentKey(id uint64, userKey []byte) []byte {
+	k := make([]byte, len(sysLeaseKeyPrefix)+8+len(userKey))
+	copy(k, sysLeaseKeyPrefix)
+	binary.BigEndian.PutUint64(k[len(sysLeaseKeyPrefix):], id)
+	copy(k[len(sysLeaseKeyPrefix)+8:], userKey)
+	return mustEncodeKey(key.Key{
+		KeyType: key.TypeSystem,
+		Key:     k,
+	})
+}
+
+// leaseExpiresAt computes the expiration (unix nanoseconds) of a lease kept alive at the timestamp.
+func leaseExpiresAt(timestamp int64, ttl int64) int64 {
+	return timestamp + ttl*int64(time.Second)
+}
+
+// readLease reads the lease, serrors.ErrLeaseNotFound is returned if the lease does not exist.
+func readLease(reader pebble.Reader, id uint64) (*regattapb.Lease, error) {
+	raw, closer, err := reader.Get(leaseKey(id))
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, serrors.ErrLeaseNotFound
+		}
+		return nil, err
+	}
+	defer func() {
+		_ = closer.Close()
+	}()
+	lease := &regattapb.Lease{}
+	if err := lease.UnmarshalVT(raw); err != nil {
+		return nil, err
+	}
+	return lease, nil
+}
+
+// writeLease stores the lease.
+func writeLease(batch *pebble.Batch, lease *regattapb.Lease) error {
+	bts, err := lease.MarshalVT()
+	if err != nil {
+		return err
+	}
+	return batch.Set(leaseKey(uint64(lease.ID)), bts, nil)
+}
+
+// leaseExists reports whether the lease exists, the lease 0 (no lease) always exists.
+func leaseExists(reader pebble.Reader, id uint64) (bool, error) {
+	if id == 0 {
+		return true, nil
+	}
+	_, err := readLease(reader, id)
+	switch {
+	case errors.Is(err, serrors.ErrLeaseNotFound):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+	return true, nil
+}
+
+// leaseKeys returns the user keys attached to the lease in the ascending order.
+func leaseKeys(reader pebble.Reader, id uint64) ([][]byte, error) {
+	prefix := leaseAttachmentKey(id, nil)
+	iter := reader.NewIter(&pebble.IterOptions{
+		LowerBound: prefix,
+		UpperBound: incrementRightmostByte(append([]byte(nil), prefix...)),
+	})
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	keyBuf := bufferPool.Get()
+	defer bufferPool.Put(keyBuf)
+	var keys [][]byte
+	for iter.First(); iter.Valid(); iter.Next() {
+		userKey := iter.Key()[len(prefix):]
+		keyBuf.Reset()
+		if err := encodeUserKey(keyBuf, userKey); err != nil {
+			return nil, err
+		}
+		attached, err := func() (bool, error) {
+			raw, closer, err := reader.Get(keyBuf.Bytes())
+			if err != nil {
+				if errors.Is(err, pebble.ErrNotFound) {
+					return false, nil
+				}
+				return false, err
+			}
+			defer func() {
+				_ = closer.Close()
+			}()
+			value, err := decodeValue(raw)
+			if err != nil {
+				return false, err
+			}
+			return value.lease == id, nil
+		}()
+		if err != nil {
+			return nil, err
+		}
+		if attached {
+			keys = append(keys, append([]byte(nil), userKey...))
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// readLeases calls the function for every lease stored in the table in the order of lease IDs.
+func readLeases(reader pebble.Reader, f func(lease *regattapb.Lease) error) error {
+	iter := reader.NewIter(&pebble.IterOptions{LowerBound: leasesLowerBound, UpperBound: leasesUpperBound})
+	defer func() {
+		_ = iter.Close()
+	}()
+	for iter.First(); iter.Valid(); iter.Next() {
+		lease := &regattapb.Lease{}
+		if err := lease.UnmarshalVT(iter.Value()); err != nil {
+			return err
+		}
+		if err := f(lease); err != nil {
+			return err
+		}
+	}
+	return iter.Error()
+}
+
+// expiredLeases returns the IDs of the leases expired at the time.
+func expiredLeases(reader pebble.Reader, now time.Time) ([]int64, error) {
+	var ids []int64
+	err := readLeases(reader, func(lease *regattapb.Lease) error {
+		if lease.ExpiresAt <= now.UnixNano() {
+			ids = append(ids, lease.ID)
+		}
+		return nil
+	})
+	return ids, err
+}
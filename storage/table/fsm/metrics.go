@@ -24,6 +24,7 @@ type metrics struct {
 	totalBytesIn            prometheus.Gauge
 	compactCount            *prometheus.GaugeVec
 	compactDebt             prometheus.Gauge
+	discardedHistoryKeys    prometheus.Counter
 	applied                 atomic.Uint64
 	collected               *pebble.Metrics
 }
@@ -160,6 +161,16 @@ func newMetrics(tableName string, clusterID uint64) *metrics {
 				},
 			},
 		),
+		discardedHistoryKeys: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "regatta_table_history_discarded_keys_total",
+				Help: "Regatta table keys the history was dropped of by the large range deletes",
+				ConstLabels: map[string]string{
+					"table":     tableName,
+					"clusterID": fmt.Sprintf("%d", clusterID),
+				},
+			},
+		),
 	}
 }
 
@@ -209,6 +220,8 @@ func (p *metrics) Collect(ch chan<- prometheus.Metric) {
 
 	p.compactDebt.Set(float64(compact.EstimatedDebt))
 	p.compactDebt.Collect(ch)
+
+	p.discardedHistoryKeys.Collect(ch)
 }
 
 func (p *metrics) Describe(ch chan<- *prometheus.Desc) {
@@ -225,4 +238,5 @@ func (p *metrics) Describe(ch chan<- *prometheus.Desc) {
 	p.totalBytesIn.Describe(ch)
 	p.compactCount.Describe(ch)
 	p.compactDebt.Describe(ch)
+	p.discardedHistoryKeys.Describe(ch)
 }
@@ -3,17 +3,31 @@
 package fsm
 
 import (
+	"bytes"
+	"context"
 	"encoding/binary"
 	"errors"
 	"io"
+	"regexp"
+	"time"
 
 	"github.com/cockroachdb/pebble"
 	"github.com/jamf/regatta/regattapb"
+	serrors "github.com/jamf/regatta/storage/errors"
 	"github.com/jamf/regatta/storage/table/key"
 	sm "github.com/lni/dragonboat/v4/statemachine"
 )
 
-const maxRangeSize uint64 = (4 * 1024 * 1024) - 1024 // 4MiB - 1KiB sentinel.
+const (
+	maxRangeSize  uint64 = (4 * 1024 * 1024) - 1024 // 4MiB - 1KiB sentinel.
+	maxCursorSize uint64 = 512 * 1024               // 512KiB target size of a single cursor response.
+	// MaxValueSize is the maximum size of a value returned by the Range along with the longest key within maxRangeSize,
+	// the larger values have to be stored and read as the large values.
+	MaxValueSize = maxRangeSize - key.LatestVersionLen - 1
+)
+
+// ErrCursorStopped returned when the cursor was stopped before the range was exhausted.
+var ErrCursorStopped = errors.New("cursor stopped")
 
 func commandSnapshot(reader pebble.Reader, tableName string, w io.Writer, stopc <-chan struct{}) (uint64, error) {
 	iter := reader.NewIter(nil)
@@ -25,6 +39,42 @@ func commandSnapshot(reader pebble.Reader, tableName string, w io.Writer, stopc
 	}
 
 	var buffer []byte
+	// The leases are written first so that the keys could be attached to them when the snapshot is applied.
+	err = readLeases(reader, func(lease *regattapb.Lease) error {
+		select {
+		case <-stopc:
+			return sm.ErrSnapshotStopped
+		default:
+		}
+		buffer, err = writeLeaseCommand(tableName, lease, buffer)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(buffer)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// The results of the commands applied with the request ID are kept so that the retries are deduplicated after the restore.
+	err = readRequestResults(reader, func(res *regattapb.RequestResult) error {
+		select {
+		case <-stopc:
+			return sm.ErrSnapshotStopped
+		default:
+		}
+		buffer, err = writeRequestResultCommand(tableName, res, buffer)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(buffer)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
 	for iter.First(); iter.Valid(); iter.Next() {
 		select {
 		case <-stopc:
@@ -35,7 +85,27 @@ func commandSnapshot(reader pebble.Reader, tableName string, w io.Writer, stopc
 				return 0, err
 			}
 			if k.KeyType == key.TypeUser {
-				buffer, err = writeCommand(tableName, k.Key, iter.Value(), buffer)
+				val, err := decodeValue(iter.Value())
+				if err != nil {
+					return 0, err
+				}
+				if val.large != nil {
+					// The chunks are written first so that the large value is complete once the PUT_LARGE command is applied.
+					var index uint32
+					err = streamLargeValue(reader, val.large, func(chunk []byte) error {
+						buffer, err = writeChunkCommand(tableName, &regattapb.Chunk{Id: val.large.id, Index: index, Data: chunk}, buffer)
+						if err != nil {
+							return err
+						}
+						index++
+						_, err = w.Write(buffer)
+						return err
+					})
+					if err != nil {
+						return 0, err
+					}
+				}
+				buffer, err = writeCommand(tableName, k.Key, val, buffer)
 				if err != nil {
 					return 0, err
 				}
@@ -48,16 +118,61 @@ func commandSnapshot(reader pebble.Reader, tableName string, w io.Writer, stopc
 	return idx, nil
 }
 
-// writeCommand writes KV pair as PUT proto.Command into (optionally provided) buffer.
-func writeCommand(tableName string, key []byte, val []byte, buffer []byte) ([]byte, error) {
+// writeCommand writes KV pair as PUT proto.Command into (optionally provided) buffer,
+// the large value is written as PUT_LARGE proto.Command referencing its chunks.
+func writeCommand(tableName string, key []byte, val storedValue, buffer []byte) ([]byte, error) {
 	cmd := regattapb.CommandFromVTPool()
 	defer cmd.ReturnToVTPool()
 	cmd.Table = []byte(tableName)
 	cmd.Type = regattapb.Command_PUT
 	cmd.Kv = &regattapb.KeyValue{
-		Key:   key,
-		Value: val,
+		Key:            key,
+		Value:          val.data,
+		CreateRevision: int64(val.createRevision),
+		ModRevision:    int64(val.modRevision),
+		Version:        int64(val.version),
+		Lease:          int64(val.lease),
+	}
+	if val.large != nil {
+		cmd.Type = regattapb.Command_PUT_LARGE
+		cmd.LargeValue = &regattapb.LargeValue{Id: val.large.id, Chunks: uint32(val.large.chunks), Size: val.large.size}
 	}
+	return marshalCommand(cmd, buffer)
+}
+
+// writeChunkCommand writes the chunk of the large value as PUT_CHUNK proto.Command into (optionally provided) buffer.
+func writeChunkCommand(tableName string, chunk *regattapb.Chunk, buffer []byte) ([]byte, error) {
+	cmd := regattapb.CommandFromVTPool()
+	defer cmd.ReturnToVTPool()
+	cmd.Table = []byte(tableName)
+	cmd.Type = regattapb.Command_PUT_CHUNK
+	cmd.Chunk = chunk
+	return marshalCommand(cmd, buffer)
+}
+
+// writeLeaseCommand writes the lease as LEASE_GRANT proto.Command into (optionally provided) buffer.
+func writeLeaseCommand(tableName string, lease *regattapb.Lease, buffer []byte) ([]byte, error) {
+	cmd := regattapb.CommandFromVTPool()
+	defer cmd.ReturnToVTPool()
+	cmd.Table = []byte(tableName)
+	cmd.Type = regattapb.Command_LEASE_GRANT
+	cmd.Lease = lease
+	return marshalCommand(cmd, buffer)
+}
+
+// writeRequestResultCommand writes the result of the command applied with the request ID as REQUEST_RESULT proto.Command
+// into (optionally provided) buffer.
+func writeRequestResultCommand(tableName string, res *regattapb.RequestResult, buffer []byte) ([]byte, error) {
+	cmd := regattapb.CommandFromVTPool()
+	defer cmd.ReturnToVTPool()
+	cmd.Table = []byte(tableName)
+	cmd.Type = regattapb.Command_REQUEST_RESULT
+	cmd.RequestResult = res
+	return marshalCommand(cmd, buffer)
+}
+
+// marshalCommand marshals the command into (optionally provided) buffer.
+func marshalCommand(cmd *regattapb.Command, buffer []byte) ([]byte, error) {
 	size := cmd.SizeVT()
 	if cap(buffer) < size {
 		buffer = make([]byte, size*2)
@@ -85,7 +200,60 @@ func readLocalIndex(db pebble.Reader, indexKey []byte) (idx uint64, err error) {
 	return binary.LittleEndian.Uint64(indexVal), nil
 }
 
+func readTxnResult(reader pebble.Reader, index uint64) (*TxnResultResponse, error) {
+	val, closer, err := reader.Get(txnResultKey(index))
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return &TxnResultResponse{}, nil
+		}
+		return nil, err
+	}
+	defer func() {
+		_ = closer.Close()
+	}()
+	res := &TxnResultResponse{Found: true, Result: UpdateResult(val[0])}
+	for _, b := range val[1:] {
+		res.NestedTxns = append(res.NestedTxns, b == 1)
+	}
+	return res, nil
+}
+
+// readonlyTxn evaluates the read-only transaction, the nested transactions are evaluated recursively.
+func readonlyTxn(reader pebble.Reader, compare []*regattapb.Compare, success, failure []*regattapb.RequestOp) (bool, []*regattapb.ResponseOp, error) {
+	ok, err := txnCompare(reader, compare)
+	if err != nil {
+		return false, nil, err
+	}
+	ops := failure
+	if ok {
+		ops = success
+	}
+	var responses []*regattapb.ResponseOp
+	for _, op := range ops {
+		if txn := op.GetRequestTxn(); txn != nil {
+			succ, res, err := readonlyTxn(reader, txn.Compare, txn.Success, txn.Failure)
+			if err != nil {
+				return false, nil, err
+			}
+			responses = append(responses, wrapResponseOp(&regattapb.ResponseOp_Txn{Succeeded: succ, Responses: res}))
+			continue
+		}
+		rr, err := lookup(reader, op.GetRequestRange())
+		if err != nil {
+			return false, nil, err
+		}
+		responses = append(responses, wrapResponseOp(rr))
+	}
+	return ok, responses, nil
+}
+
 func lookup(reader pebble.Reader, req *regattapb.RequestOp_Range) (*regattapb.ResponseOp_Range, error) {
+	if req.Revision > 0 {
+		if err := checkRevision(reader, uint64(req.Revision)); err != nil {
+			return nil, err
+		}
+		return rangeLookup(reader, req)
+	}
 	if req.RangeEnd != nil {
 		return rangeLookup(reader, req)
 	}
@@ -93,16 +261,77 @@ func lookup(reader pebble.Reader, req *regattapb.RequestOp_Range) (*regattapb.Re
 }
 
 func rangeLookup(reader pebble.Reader, req *regattapb.RequestOp_Range) (*regattapb.ResponseOp_Range, error) {
-	opts, err := iterOptionsForBounds(req.Key, req.RangeEnd)
+	filter, err := entryFilterFromReq(reader, req)
+	if err != nil {
+		return nil, err
+	}
+	iter, err := newRangeIter(reader, req)
 	if err != nil {
 		return nil, err
 	}
-	iter := reader.NewIter(opts)
 	defer func() {
 		_ = iter.Close()
 	}()
 	fill, sf := iterFuncsFromReq(req)
-	return iterate(iter, int(req.Limit), fill, sf)
+	return iterate(reader, iter, int(req.Limit), filter, fill, sf)
+}
+
+// entryIter iterates the user key-value pairs in the order of the keys, First positions the iterator at the beginning of the range in that order.
+type entryIter interface {
+	First() bool
+	Next() bool
+	Valid() bool
+	// Entry returns the current key and value, they are valid only until the iterator is moved.
+	Entry() ([]byte, storedValue, error)
+	Error() error
+	Close() error
+}
+
+// userIter iterates the current user key-value pairs, in the descending order of the keys if reverse is set.
+type userIter struct {
+	*pebble.Iterator
+	reverse bool
+}
+
+func (it userIter) First() bool {
+	if it.reverse {
+		return it.Iterator.Last()
+	}
+	return it.Iterator.First()
+}
+
+func (it userIter) Next() bool {
+	if it.reverse {
+		return it.Iterator.Prev()
+	}
+	return it.Iterator.Next()
+}
+
+func (it userIter) Entry() ([]byte, storedValue, error) {
+	k, err := key.DecodeBytes(it.Key())
+	if err != nil {
+		return nil, storedValue{}, err
+	}
+	value, err := decodeValue(it.Value())
+	return k.Key, value, err
+}
+
+// newRangeIter creates the iterator of the range in the sort order of the request, the range is read as it was at the revision
+// if the revision is set. The range of a request at the revision without the range end contains only the key.
+func newRangeIter(reader pebble.Reader, req *regattapb.RequestOp_Range) (entryIter, error) {
+	reverse := req.SortOrder == regattapb.SortOrder_DESCEND
+	if req.Revision > 0 {
+		end := req.RangeEnd
+		if end == nil {
+			end = append(append(make([]byte, 0, len(req.Key)+1), req.Key...), 0)
+		}
+		return newRevisionIter(reader, req.Key, end, uint64(req.Revision), reverse)
+	}
+	opts, err := iterOptionsForBounds(req.Key, req.RangeEnd)
+	if err != nil {
+		return nil, err
+	}
+	return userIter{Iterator: reader.NewIter(opts), reverse: reverse}, nil
 }
 
 func iterFuncsFromReq(req *regattapb.RequestOp_Range) (fillEntriesFunc, sizeEntriesFunc) {
@@ -117,10 +346,15 @@ func iterFuncsFromReq(req *regattapb.RequestOp_Range) (fillEntriesFunc, sizeEntr
 }
 
 func singleLookup(reader pebble.Reader, req *regattapb.RequestOp_Range) (*regattapb.ResponseOp_Range, error) {
+	filter, err := entryFilterFromReq(reader, req)
+	if err != nil {
+		return nil, err
+	}
+
 	keyBuf := bufferPool.Get()
 	defer bufferPool.Put(keyBuf)
 
-	err := encodeUserKey(keyBuf, req.Key)
+	err = encodeUserKey(keyBuf, req.Key)
 	if err != nil {
 		return nil, err
 	}
@@ -134,85 +368,278 @@ func singleLookup(reader pebble.Reader, req *regattapb.RequestOp_Range) (*regatt
 		return &regattapb.ResponseOp_Range{}, nil
 	}
 
-	kv := &regattapb.KeyValue{Key: req.Key}
-	value := iter.Value()
-	if !(req.KeysOnly || req.CountOnly) && len(value) > 0 {
-		kv.Value = make([]byte, len(value))
-		copy(kv.Value, value)
+	value, err := decodeValue(iter.Value())
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := filter.match(req.Key, value); err != nil || !ok {
+		return &regattapb.ResponseOp_Range{}, err
 	}
 
-	var kvs []*regattapb.KeyValue
-	if !req.CountOnly {
-		kvs = append(kvs, kv)
+	response := &regattapb.ResponseOp_Range{}
+	fill, size := iterFuncsFromReq(req)
+	if size(req.Key, value) >= maxRangeSize {
+		return nil, serrors.ErrValueTooLarge
+	}
+	if err := fill(reader, req.Key, value, response); err != nil {
+		return nil, err
 	}
+	return response, nil
+}
 
-	return &regattapb.ResponseOp_Range{
-		Kvs:   kvs,
-		Count: 1,
-	}, nil
+// revisionFilter filters the entries by the revision bounds, zero bound is not applied.
+type revisionFilter struct {
+	minMod, maxMod, minCreate, maxCreate uint64
 }
 
-// fillEntriesFunc fills proto.RangeResponse response.
-type fillEntriesFunc func(key, value []byte, response *regattapb.ResponseOp_Range)
+func revisionFilterFromReq(req *regattapb.RequestOp_Range) revisionFilter {
+	bound := func(b int64) uint64 {
+		if b < 0 {
+			return 0
+		}
+		return uint64(b)
+	}
+	return revisionFilter{
+		minMod:    bound(req.MinModRevision),
+		maxMod:    bound(req.MaxModRevision),
+		minCreate: bound(req.MinCreateRevision),
+		maxCreate: bound(req.MaxCreateRevision),
+	}
+}
+
+// match reports whether the value passes all the set bounds.
+func (f revisionFilter) match(value storedValue) bool {
+	return (f.minMod == 0 || value.modRevision >= f.minMod) &&
+		(f.maxMod == 0 || value.modRevision <= f.maxMod) &&
+		(f.minCreate == 0 || value.createRevision >= f.minCreate) &&
+		(f.maxCreate == 0 || value.createRevision <= f.maxCreate)
+}
+
+// entryFilter filters the entries by the revision bounds and by the filter expressions of the request, see regattapb.RangeFilter.
+type entryFilter struct {
+	revisionFilter
+	// reader is used to read the chunks of the large values filtered by their content.
+	reader                                  pebble.Reader
+	keyRegex                                *regexp.Regexp
+	valuePrefix, valueSuffix, valueContains []byte
+	minValueLen, maxValueLen                uint64
+}
+
+func entryFilterFromReq(reader pebble.Reader, req *regattapb.RequestOp_Range) (entryFilter, error) {
+	f := entryFilter{revisionFilter: revisionFilterFromReq(req), reader: reader}
+	rf := req.Filter
+	if rf == nil {
+		return f, nil
+	}
+	if rf.KeyRegex != "" {
+		re, err := regexp.Compile(rf.KeyRegex)
+		if err != nil {
+			return entryFilter{}, err
+		}
+		f.keyRegex = re
+	}
+	f.valuePrefix = rf.ValuePrefix
+	f.valueSuffix = rf.ValueSuffix
+	f.valueContains = rf.ValueContains
+	if rf.MinValueLength > 0 {
+		f.minValueLen = uint64(rf.MinValueLength)
+	}
+	if rf.MaxValueLength > 0 {
+		f.maxValueLen = uint64(rf.MaxValueLength)
+	}
+	return f, nil
+}
+
+// match reports whether the entry passes all the set bounds and expressions. The value is read only if it is filtered by its content.
+func (f entryFilter) match(key []byte, value storedValue) (bool, error) {
+	if !f.revisionFilter.match(value) {
+		return false, nil
+	}
+	if f.keyRegex != nil && !f.keyRegex.Match(key) {
+		return false, nil
+	}
+	if size := value.size(); size < f.minValueLen || (f.maxValueLen != 0 && size > f.maxValueLen) {
+		return false, nil
+	}
+	if len(f.valuePrefix) == 0 && len(f.valueSuffix) == 0 && len(f.valueContains) == 0 {
+		return true, nil
+	}
+	value, err := resolveValue(f.reader, value)
+	if err != nil {
+		return false, err
+	}
+	return bytes.HasPrefix(value.data, f.valuePrefix) &&
+		bytes.HasSuffix(value.data, f.valueSuffix) &&
+		bytes.Contains(value.data, f.valueContains), nil
+}
+
+// fillEntriesFunc fills proto.RangeResponse response, the reader is used to read the chunks of the large values.
+type fillEntriesFunc func(reader pebble.Reader, key []byte, value storedValue, response *regattapb.ResponseOp_Range) error
 
 // sizeEntriesFunc estimates entry size.
-type sizeEntriesFunc func(key, value []byte) uint64
+type sizeEntriesFunc func(key []byte, value storedValue) uint64
 
-// iterate until the provided pebble.Iterator is no longer valid or the limit is reached.
-// Apply a function on the key/value pair in every iteration filling proto.RangeResponse.
-func iterate(iter *pebble.Iterator, limit int, f fillEntriesFunc, s sizeEntriesFunc) (*regattapb.ResponseOp_Range, error) {
+// iterate until the provided iterator is no longer valid or the limit is reached.
+// Apply a function on the key/value pair matching the filter in every iteration filling proto.RangeResponse.
+// serrors.ErrValueTooLarge is returned if the first matching entry alone does not fit into the response.
+func iterate(reader pebble.Reader, iter entryIter, limit int, filter entryFilter, f fillEntriesFunc, s sizeEntriesFunc) (*regattapb.ResponseOp_Range, error) {
 	response := &regattapb.ResponseOp_Range{}
 	i := 0
 	for iter.First(); iter.Valid(); iter.Next() {
-		k, err := key.DecodeBytes(iter.Key())
+		k, value, err := iter.Entry()
 		if err != nil {
 			return nil, err
 		}
+		if ok, err := filter.match(k, value); err != nil {
+			return nil, err
+		} else if !ok {
+			continue
+		}
 
-		if i == limit && limit != 0 || (uint64(response.SizeVT())+s(k.Key, iter.Value())) >= maxRangeSize {
-			response.More = iter.Next()
+		if i == limit && limit != 0 || (uint64(response.SizeVT())+s(k, value)) >= maxRangeSize {
+			if i == 0 {
+				return nil, serrors.ErrValueTooLarge
+			}
+			// The current entry matches the filter but does not fit into the response.
+			response.More = true
 			break
 		}
 		i++
-		f(k.Key, iter.Value(), response)
+		if err := f(reader, k, value, response); err != nil {
+			return nil, err
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
 	}
 	return response, nil
 }
 
-// addKVPair adds a key/value pair from the provided iterator to the proto.RangeResponse.
-func addKVPair(key, value []byte, response *regattapb.ResponseOp_Range) {
-	kv := &regattapb.KeyValue{Key: make([]byte, len(key)), Value: make([]byte, len(value))}
+// cursor streams the range in batches of at most batchSize entries (0 means no limit) and approximately maxCursorSize bytes
+// until the range is exhausted or the request limit is reached. Every batch except the last one has the More flag set.
+// serrors.ErrValueTooLarge is returned if an entry alone does not fit into the range response.
+func cursor(reader pebble.Reader, req *regattapb.RequestOp_Range, batchSize int, send func(*regattapb.ResponseOp_Range) error, stopc <-chan struct{}) error {
+	if req.Revision > 0 {
+		if err := checkRevision(reader, uint64(req.Revision)); err != nil {
+			return err
+		}
+	} else if req.RangeEnd == nil {
+		response, err := singleLookup(reader, req)
+		if err != nil {
+			return err
+		}
+		return send(response)
+	}
+
+	iter, err := newRangeIter(reader, req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	filter, err := entryFilterFromReq(reader, req)
+	if err != nil {
+		return err
+	}
+	f, s := iterFuncsFromReq(req)
+	limit := int(req.Limit)
+	response := &regattapb.ResponseOp_Range{}
+	total, batch := 0, 0
+	for iter.First(); iter.Valid() && (limit == 0 || total < limit); iter.Next() {
+		select {
+		case <-stopc:
+			return ErrCursorStopped
+		default:
+		}
+		k, value, err := iter.Entry()
+		if err != nil {
+			return err
+		}
+		if ok, err := filter.match(k, value); err != nil {
+			return err
+		} else if !ok {
+			continue
+		}
+
+		if s(k, value) >= maxRangeSize {
+			return serrors.ErrValueTooLarge
+		}
+		if batch > 0 && (batch == batchSize || uint64(response.SizeVT())+s(k, value) >= maxCursorSize) {
+			// The current entry matches the filter but does not fit into the batch.
+			response.More = true
+			if err := send(response); err != nil {
+				return err
+			}
+			response = &regattapb.ResponseOp_Range{}
+			batch = 0
+		}
+		if err := f(reader, k, value, response); err != nil {
+			return err
+		}
+		batch++
+		total++
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	return send(response)
+}
+
+// newKeyValue creates a proto.KeyValue copying the provided key and value, the value is not set for the large value.
+func newKeyValue(key []byte, value storedValue) *regattapb.KeyValue {
+	kv := &regattapb.KeyValue{
+		Key:            make([]byte, len(key)),
+		CreateRevision: int64(value.createRevision),
+		ModRevision:    int64(value.modRevision),
+		Version:        int64(value.version),
+		Lease:          int64(value.lease),
+	}
 	copy(kv.Key, key)
-	copy(kv.Value, value)
-	response.Kvs = append(response.Kvs, kv)
+	if len(value.data) > 0 {
+		kv.Value = make([]byte, len(value.data))
+		copy(kv.Value, value.data)
+	}
+	return kv
+}
+
+// addKVPair adds a key/value pair from the provided iterator to the proto.RangeResponse, the large value is reassembled from its chunks.
+func addKVPair(reader pebble.Reader, key []byte, value storedValue, response *regattapb.ResponseOp_Range) error {
+	value, err := resolveValue(reader, value)
+	if err != nil {
+		return err
+	}
+	response.Kvs = append(response.Kvs, newKeyValue(key, value))
 	response.Count++
+	return nil
 }
 
 // sizeKVPair takes the full pair size into consideration.
-func sizeKVPair(key, value []byte) uint64 {
-	return uint64(len(key) + len(value))
+func sizeKVPair(key []byte, value storedValue) uint64 {
+	return uint64(len(key)) + value.size()
 }
 
 // addKeyOnly adds a key from the provided iterator to the proto.RangeResponse.
-func addKeyOnly(key, _ []byte, response *regattapb.ResponseOp_Range) {
-	kv := &regattapb.KeyValue{Key: make([]byte, len(key))}
-	copy(kv.Key, key)
-	response.Kvs = append(response.Kvs, kv)
+func addKeyOnly(_ pebble.Reader, key []byte, value storedValue, response *regattapb.ResponseOp_Range) error {
+	response.Kvs = append(response.Kvs, newKeyValue(key, storedValue{createRevision: value.createRevision, modRevision: value.modRevision, version: value.version, lease: value.lease}))
 	response.Count++
+	return nil
 }
 
 // sizeKeyOnly takes only the key into consideration.
-func sizeKeyOnly(key, _ []byte) uint64 {
+func sizeKeyOnly(key []byte, _ storedValue) uint64 {
 	return uint64(len(key))
 }
 
 // addCountOnly increments number of keys from the provided iterator to the proto.RangeResponse.
-func addCountOnly(_, _ []byte, response *regattapb.ResponseOp_Range) {
+func addCountOnly(_ pebble.Reader, _ []byte, _ storedValue, response *regattapb.ResponseOp_Range) error {
 	response.Count++
+	return nil
 }
 
 // sizeCountOnly for count the size remains constant.
-func sizeCountOnly(_, _ []byte) uint64 {
+func sizeCountOnly(_ []byte, _ storedValue) uint64 {
 	return uint64(0)
 }
 
@@ -238,6 +665,112 @@ type IndexResponse struct {
 	Index uint64
 }
 
+// TxnResultRequest to read the stored result of a conditional command applied at the index, see IsConditional.
+type TxnResultRequest struct {
+	Index uint64
+}
+
+// TxnResultResponse returns the result of a conditional command, Found is false if the result is not stored (anymore).
+// NestedTxns are the outcomes of the nested transactions applied by the command, a nested transaction precedes
+// the transactions nested in its applied branch.
+type TxnResultResponse struct {
+	Found      bool
+	Result     UpdateResult
+	NestedTxns []bool
+}
+
+// LeaseRequest to read the lease, Keys if the keys attached to the lease should be read as well.
+type LeaseRequest struct {
+	ID   int64
+	Keys bool
+}
+
+// LeaseResponse returns the lease and the keys attached to it (if requested) along with the revision of the table they were read at.
+type LeaseResponse struct {
+	Lease    *regattapb.Lease
+	Keys     [][]byte
+	Revision uint64
+}
+
+// ExpiredLeasesRequest to list the leases expired at the given time.
+type ExpiredLeasesRequest struct {
+	Now time.Time
+}
+
+// ExpiredLeasesResponse returns the IDs of expired leases.
+type ExpiredLeasesResponse struct {
+	IDs []int64
+}
+
+// SizeRequest to read the disk space used by the table.
+type SizeRequest struct{}
+
+// SizeResponse returns the disk space used by the table in bytes.
+type SizeResponse struct {
+	Size uint64
+}
+
+// HeartbeatRequest to read the timestamp of the last applied heartbeat. The replica has applied all the commands
+// proposed before the timestamp, the timestamp is 0 if no heartbeat has been applied.
+type HeartbeatRequest struct{}
+
+// HeartbeatResponse returns the timestamp (unix nanoseconds) of the last applied heartbeat.
+type HeartbeatResponse struct {
+	Timestamp int64
+}
+
+// RevisionRequest to read the current revision of the table and the revision its history is compacted to.
+type RevisionRequest struct{}
+
+// RevisionResponse returns the current and the compacted revision of the table.
+type RevisionResponse struct {
+	Revision        uint64
+	CompactRevision uint64
+}
+
+// RangeRequest to read the range along with the revision of the snapshot it was read from.
+type RangeRequest struct {
+	Range *regattapb.RequestOp_Range
+}
+
+// RangeResponse returns the range and the revision of the snapshot it was read from (see CursorRequest).
+type RangeResponse struct {
+	Range    *regattapb.ResponseOp_Range
+	Revision uint64
+}
+
+// CursorRequest to stream the range in batches of at most BatchSize entries (0 means no limit) into the Send function.
+// The whole range is read from a single snapshot, the revision passed to Send is the revision of the snapshot
+// (the applied leader index for the replicated tables, the applied index otherwise).
+type CursorRequest struct {
+	Range     *regattapb.RequestOp_Range
+	BatchSize int
+	Send      func(response *regattapb.ResponseOp_Range, revision uint64) error
+	Stopper   <-chan struct{}
+}
+
+// CursorResponse returns the revision of the snapshot the range was read from.
+type CursorResponse struct {
+	Index uint64
+}
+
+// LargeValueRequest to stream the value of the key as it was at the Revision (the current value if not set) into the Send function
+// in parts, the large values are sent chunk by chunk. The key-value pair (without the value) is passed only along with the first part,
+// the part is valid only until Send returns.
+// The whole value is read from a single snapshot, the revision passed to Send is the revision of the snapshot.
+type LargeValueRequest struct {
+	Key      []byte
+	Revision int64
+	Send     func(kv *regattapb.KeyValue, part []byte, revision uint64) error
+	Stopper  <-chan struct{}
+}
+
+// LargeValueResponse returns the revision of the snapshot the value was read from, Found is false if the key does not exist.
+type LargeValueResponse struct {
+	Index uint64
+	Found bool
+}
+
 // PathRequest request data disk paths.
 type PathRequest struct{}
 
@@ -245,3 +778,9 @@ type PathRequest struct{}
 type PathResponse struct {
 	Path string
 }
+
+// TracedRequest wraps the Request to record the span of its lookup as a part of the trace of the Context.
+type TracedRequest struct {
+	Context context.Context
+	Request interface{}
+}
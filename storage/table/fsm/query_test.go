@@ -54,8 +54,9 @@ func TestFSM_Lookup(t *testing.T) {
 			want: &regattapb.ResponseOp_Range{
 				Kvs: []*regattapb.KeyValue{
 					{
-						Key:   []byte(fmt.Sprintf(testKeyFormat, 0)),
-						Value: []byte(testValue),
+						Key:     []byte(fmt.Sprintf(testKeyFormat, 0)),
+						Value:   []byte(testValue),
+						Version: 1,
 					},
 				},
 				Count: 1,
@@ -73,8 +74,9 @@ func TestFSM_Lookup(t *testing.T) {
 			want: &regattapb.ResponseOp_Range{
 				Kvs: []*regattapb.KeyValue{
 					{
-						Key:   []byte(fmt.Sprintf(testLargeKeyFormat, 0)),
-						Value: []byte(largeValues[0]),
+						Key:     []byte(fmt.Sprintf(testLargeKeyFormat, 0)),
+						Value:   []byte(largeValues[0]),
+						Version: 1,
 					},
 				},
 				Count: 1,
@@ -92,7 +94,7 @@ func TestFSM_Lookup(t *testing.T) {
 
 			want: &regattapb.ResponseOp_Range{
 				Kvs: []*regattapb.KeyValue{
-					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 0))},
+					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 0)), Version: 1},
 				},
 				Count: 1,
 			},
@@ -152,6 +154,7 @@ func TestFSM_Lookup_Txn(t *testing.T) {
 				},
 			},
 			want: &regattapb.TxnResponse{
+				Header:    &regattapb.ResponseHeader{Revision: 0},
 				Succeeded: true,
 				Responses: []*regattapb.ResponseOp{
 					wrapResponseOp(&regattapb.ResponseOp_Range{}),
@@ -169,6 +172,7 @@ func TestFSM_Lookup_Txn(t *testing.T) {
 				},
 			},
 			want: &regattapb.TxnResponse{
+				Header:    &regattapb.ResponseHeader{Revision: 9},
 				Succeeded: true,
 				Responses: []*regattapb.ResponseOp{
 					wrapResponseOp(&regattapb.ResponseOp_Range{}),
@@ -186,13 +190,15 @@ func TestFSM_Lookup_Txn(t *testing.T) {
 				},
 			},
 			want: &regattapb.TxnResponse{
+				Header:    &regattapb.ResponseHeader{Revision: 9},
 				Succeeded: true,
 				Responses: []*regattapb.ResponseOp{
 					wrapResponseOp(&regattapb.ResponseOp_Range{
 						Kvs: []*regattapb.KeyValue{
 							{
-								Key:   []byte(fmt.Sprintf(testKeyFormat, 0)),
-								Value: []byte(testValue),
+								Key:     []byte(fmt.Sprintf(testKeyFormat, 0)),
+								Value:   []byte(testValue),
+								Version: 1,
 							},
 						},
 						Count: 1,
@@ -211,13 +217,15 @@ func TestFSM_Lookup_Txn(t *testing.T) {
 				},
 			},
 			want: &regattapb.TxnResponse{
+				Header:    &regattapb.ResponseHeader{Revision: 9},
 				Succeeded: true,
 				Responses: []*regattapb.ResponseOp{
 					wrapResponseOp(&regattapb.ResponseOp_Range{
 						Kvs: []*regattapb.KeyValue{
 							{
-								Key:   []byte(fmt.Sprintf(testLargeKeyFormat, 0)),
-								Value: []byte(largeValues[0]),
+								Key:     []byte(fmt.Sprintf(testLargeKeyFormat, 0)),
+								Value:   []byte(largeValues[0]),
+								Version: 1,
 							},
 						},
 						Count: 1,
@@ -236,11 +244,12 @@ func TestFSM_Lookup_Txn(t *testing.T) {
 				},
 			},
 			want: &regattapb.TxnResponse{
+				Header:    &regattapb.ResponseHeader{Revision: 9},
 				Succeeded: true,
 				Responses: []*regattapb.ResponseOp{
 					wrapResponseOp(&regattapb.ResponseOp_Range{
 						Kvs: []*regattapb.KeyValue{
-							{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 0))},
+							{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 0)), Version: 1},
 						},
 						Count: 1,
 					}),
@@ -258,6 +267,7 @@ func TestFSM_Lookup_Txn(t *testing.T) {
 				},
 			},
 			want: &regattapb.TxnResponse{
+				Header:    &regattapb.ResponseHeader{Revision: 9},
 				Succeeded: true,
 				Responses: []*regattapb.ResponseOp{
 					wrapResponseOp(&regattapb.ResponseOp_Range{Count: 1}),
@@ -280,6 +290,7 @@ func TestFSM_Lookup_Txn(t *testing.T) {
 				},
 			},
 			want: &regattapb.TxnResponse{
+				Header:    &regattapb.ResponseHeader{Revision: 9},
 				Succeeded: true,
 				Responses: []*regattapb.ResponseOp{
 					wrapResponseOp(&regattapb.ResponseOp_Range{Count: 1}),
@@ -302,6 +313,7 @@ func TestFSM_Lookup_Txn(t *testing.T) {
 				},
 			},
 			want: &regattapb.TxnResponse{
+				Header:    &regattapb.ResponseHeader{Revision: 9},
 				Succeeded: false,
 				Responses: []*regattapb.ResponseOp{
 					wrapResponseOp(&regattapb.ResponseOp_Range{Count: 1}),
@@ -321,6 +333,7 @@ func TestFSM_Lookup_Txn(t *testing.T) {
 				},
 			},
 			want: &regattapb.TxnResponse{
+				Header:    &regattapb.ResponseHeader{Revision: 9},
 				Succeeded: true,
 			},
 		},
@@ -337,6 +350,7 @@ func TestFSM_Lookup_Txn(t *testing.T) {
 				},
 			},
 			want: &regattapb.TxnResponse{
+				Header:    &regattapb.ResponseHeader{Revision: 9},
 				Succeeded: false,
 			},
 		},
@@ -380,6 +394,180 @@ func TestFSM_Lookup_Snapshot(t *testing.T) {
 	})
 }
 
+func TestFSM_Lookup_Cursor(t *testing.T) {
+	fsm := filledSM()
+	defer fsm.Close()
+	all := &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}}
+
+	collect := func(req CursorRequest) ([]*regattapb.ResponseOp_Range, uint64, error) {
+		var responses []*regattapb.ResponseOp_Range
+		var revision uint64
+		req.Send = func(response *regattapb.ResponseOp_Range, rev uint64) error {
+			responses = append(responses, response)
+			revision = rev
+			return nil
+		}
+		_, err := fsm.Lookup(req)
+		return responses, revision, err
+	}
+
+	t.Run("batches", func(t *testing.T) {
+		r := require.New(t)
+		responses, revision, err := collect(CursorRequest{Range: all, BatchSize: 1000})
+		r.NoError(err)
+		r.Len(responses, 11)
+		var keys [][]byte
+		for i, response := range responses {
+			r.Equal(i < len(responses)-1, response.More)
+			for _, kv := range response.Kvs {
+				keys = append(keys, kv.Key)
+			}
+		}
+		r.Len(keys, smallEntries+largeEntries)
+		r.IsIncreasing(keys)
+		idx, err := fsm.Lookup(LocalIndexRequest{})
+		r.NoError(err)
+		r.Equal(idx.(*IndexResponse).Index, revision)
+	})
+	t.Run("limit", func(t *testing.T) {
+		r := require.New(t)
+		responses, _, err := collect(CursorRequest{Range: &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}, Limit: 2500, KeysOnly: true}, BatchSize: 1000})
+		r.NoError(err)
+		r.Len(responses, 3)
+		r.Equal(int64(500), responses[2].Count)
+		r.False(responses[2].More)
+	})
+	t.Run("count only", func(t *testing.T) {
+		r := require.New(t)
+		responses, _, err := collect(CursorRequest{Range: &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}, CountOnly: true}})
+		r.NoError(err)
+		r.Len(responses, 1)
+		r.Equal(int64(smallEntries+largeEntries), responses[0].Count)
+	})
+	t.Run("size", func(t *testing.T) {
+		r := require.New(t)
+		large := filledLargeValuesSM()
+		defer large.Close()
+		var responses []*regattapb.ResponseOp_Range
+		_, err := large.Lookup(CursorRequest{Range: all, Send: func(response *regattapb.ResponseOp_Range, _ uint64) error {
+			responses = append(responses, response)
+			return nil
+		}})
+		r.NoError(err)
+		r.Greater(len(responses), 1)
+		for _, response := range responses {
+			r.Less(uint64(response.SizeVT()), maxCursorSize+maxRangeSize)
+		}
+	})
+	t.Run("filter", func(t *testing.T) {
+		r := require.New(t)
+		responses, _, err := collect(CursorRequest{Range: &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}, Filter: &regattapb.RangeFilter{KeyRegex: `^test9\d\d$`}}, BatchSize: 60})
+		r.NoError(err)
+		r.Len(responses, 2)
+		r.Equal(int64(60), responses[0].Count)
+		r.Equal(int64(40), responses[1].Count)
+	})
+	t.Run("descending order", func(t *testing.T) {
+		r := require.New(t)
+		responses, _, err := collect(CursorRequest{Range: &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}, KeysOnly: true, SortOrder: regattapb.SortOrder_DESCEND}, BatchSize: 1000})
+		r.NoError(err)
+		r.Len(responses, 11)
+		var keys [][]byte
+		for _, response := range responses {
+			for _, kv := range response.Kvs {
+				keys = append(keys, kv.Key)
+			}
+		}
+		r.Len(keys, smallEntries+largeEntries)
+		r.IsDecreasing(keys)
+	})
+	t.Run("single key", func(t *testing.T) {
+		r := require.New(t)
+		responses, _, err := collect(CursorRequest{Range: &regattapb.RequestOp_Range{Key: []byte(fmt.Sprintf(testKeyFormat, 1))}})
+		r.NoError(err)
+		r.Len(responses, 1)
+		r.Equal(int64(1), responses[0].Count)
+	})
+	t.Run("stop by chan", func(t *testing.T) {
+		stopper := make(chan struct{})
+		close(stopper)
+		_, _, err := collect(CursorRequest{Range: all, Stopper: stopper})
+		require.ErrorIs(t, err, ErrCursorStopped)
+	})
+}
+
+func TestFSM_Lookup_TxnResult(t *testing.T) {
+	r := require.New(t)
+	fsm := emptySM()
+	defer fsm.Close()
+
+	txn := func(cmp []*regattapb.Compare) []byte {
+		return mustMarshallProto(&regattapb.Command{
+			Table: []byte(testTable),
+			Type:  regattapb.Command_TXN,
+			Txn: &regattapb.Txn{
+				Compare: cmp,
+				Success: []*regattapb.RequestOp{{Request: &regattapb.RequestOp_RequestPut{RequestPut: &regattapb.RequestOp_Put{Key: []byte("key"), Value: []byte(testValue)}}}},
+			},
+		})
+	}
+	_, err := fsm.Update([]statemachine.Entry{
+		{Index: 1, Cmd: txn(nil)},
+		{Index: 2, Cmd: txn([]*regattapb.Compare{{Key: []byte("key")}})},
+		{Index: 3, Cmd: txn([]*regattapb.Compare{{Key: []byte("missing")}})},
+	})
+	r.NoError(err)
+
+	res, err := fsm.Lookup(TxnResultRequest{Index: 1})
+	r.NoError(err)
+	r.Equal(&TxnResultResponse{}, res)
+
+	res, err = fsm.Lookup(TxnResultRequest{Index: 2})
+	r.NoError(err)
+	r.Equal(&TxnResultResponse{Found: true, Result: ResultSuccess}, res)
+
+	res, err = fsm.Lookup(TxnResultRequest{Index: 3})
+	r.NoError(err)
+	r.Equal(&TxnResultResponse{Found: true, Result: ResultFailure}, res)
+}
+
+func TestFSM_Lookup_TxnNested(t *testing.T) {
+	r := require.New(t)
+	fsm := emptySM()
+	defer fsm.Close()
+
+	rng := func(key string) *regattapb.RequestOp {
+		return &regattapb.RequestOp{Request: &regattapb.RequestOp_RequestRange{RequestRange: &regattapb.RequestOp_Range{Key: []byte(key)}}}
+	}
+	exists := func(key string) []*regattapb.Compare {
+		return []*regattapb.Compare{{Key: []byte(key)}}
+	}
+	_, err := fsm.Update([]statemachine.Entry{{Index: 1, Cmd: mustMarshallProto(&regattapb.Command{
+		Table: []byte(testTable),
+		Type:  regattapb.Command_TXN,
+		Txn: &regattapb.Txn{Success: []*regattapb.RequestOp{
+			{Request: &regattapb.RequestOp_RequestPut{RequestPut: &regattapb.RequestOp_Put{Key: []byte("key"), Value: []byte(testValue)}}},
+			{Request: &regattapb.RequestOp_RequestTxn{RequestTxn: &regattapb.Txn{Compare: exists("missing")}}},
+			{Request: &regattapb.RequestOp_RequestTxn{RequestTxn: &regattapb.Txn{Compare: exists("key")}}},
+		}},
+	})}})
+	r.NoError(err)
+
+	res, err := fsm.Lookup(TxnResultRequest{Index: 1})
+	r.NoError(err)
+	r.Equal(&TxnResultResponse{Found: true, Result: ResultSuccess, NestedTxns: []bool{false, true}}, res)
+
+	res, err = fsm.Lookup(&regattapb.TxnRequest{Compare: exists("key"), Success: []*regattapb.RequestOp{
+		{Request: &regattapb.RequestOp_RequestTxn{RequestTxn: &regattapb.Txn{Compare: exists("missing"), Success: []*regattapb.RequestOp{rng("missing")}, Failure: []*regattapb.RequestOp{rng("key")}}}},
+	}})
+	r.NoError(err)
+	r.Equal(&regattapb.TxnResponse{Header: &regattapb.ResponseHeader{Revision: 1}, Succeeded: true, Responses: []*regattapb.ResponseOp{
+		wrapResponseOp(&regattapb.ResponseOp_Txn{Responses: []*regattapb.ResponseOp{
+			wrapResponseOp(&regattapb.ResponseOp_Range{Kvs: []*regattapb.KeyValue{{Key: []byte("key"), Value: []byte(testValue), CreateRevision: 1, ModRevision: 1, Version: 1}}, Count: 1}),
+		}}),
+	}}, res)
+}
+
 func TestFSM_Lookup_Range(t *testing.T) {
 	type fields struct {
 		smFactory func() *FSM
@@ -405,12 +593,16 @@ func TestFSM_Lookup_Range(t *testing.T) {
 			want: &regattapb.ResponseOp_Range{
 				Kvs: []*regattapb.KeyValue{
 					{
-						Key:   []byte(fmt.Sprintf(testLargeKeyFormat, 0)),
-						Value: []byte(largeValues[0]),
+						Key:     []byte(fmt.Sprintf(testLargeKeyFormat, 0)),
+						Value:   []byte(largeValues[0]),
+						Version: 1,
 					},
 					{
-						Key:   []byte(fmt.Sprintf(testLargeKeyFormat, 1)),
-						Value: []byte(largeValues[1]),
+						Key:            []byte(fmt.Sprintf(testLargeKeyFormat, 1)),
+						Value:          []byte(largeValues[1]),
+						CreateRevision: 1,
+						ModRevision:    1,
+						Version:        1,
 					},
 				},
 				Count: 2,
@@ -429,8 +621,9 @@ func TestFSM_Lookup_Range(t *testing.T) {
 			want: &regattapb.ResponseOp_Range{
 				Kvs: []*regattapb.KeyValue{
 					{
-						Key:   []byte(fmt.Sprintf(testLargeKeyFormat, 0)),
-						Value: []byte(largeValues[0]),
+						Key:     []byte(fmt.Sprintf(testLargeKeyFormat, 0)),
+						Value:   []byte(largeValues[0]),
+						Version: 1,
 					},
 				},
 				Count: 1,
@@ -450,16 +643,23 @@ func TestFSM_Lookup_Range(t *testing.T) {
 			want: &regattapb.ResponseOp_Range{
 				Kvs: []*regattapb.KeyValue{
 					{
-						Key:   []byte(fmt.Sprintf(testKeyFormat, 0)),
-						Value: []byte(testValue),
+						Key:     []byte(fmt.Sprintf(testKeyFormat, 0)),
+						Value:   []byte(testValue),
+						Version: 1,
 					},
 					{
-						Key:   []byte(fmt.Sprintf(testKeyFormat, 1)),
-						Value: []byte(testValue),
+						Key:            []byte(fmt.Sprintf(testKeyFormat, 1)),
+						Value:          []byte(testValue),
+						CreateRevision: 1,
+						ModRevision:    1,
+						Version:        1,
 					},
 					{
-						Key:   []byte(fmt.Sprintf(testKeyFormat, 10)),
-						Value: []byte(testValue),
+						Key:            []byte(fmt.Sprintf(testKeyFormat, 10)),
+						Value:          []byte(testValue),
+						CreateRevision: 10,
+						ModRevision:    10,
+						Version:        1,
 					},
 				},
 				Count: 3,
@@ -479,16 +679,23 @@ func TestFSM_Lookup_Range(t *testing.T) {
 			want: &regattapb.ResponseOp_Range{
 				Kvs: []*regattapb.KeyValue{
 					{
-						Key:   []byte(fmt.Sprintf(testLargeKeyFormat, 0)),
-						Value: []byte(largeValues[0]),
+						Key:     []byte(fmt.Sprintf(testLargeKeyFormat, 0)),
+						Value:   []byte(largeValues[0]),
+						Version: 1,
 					},
 					{
-						Key:   []byte(fmt.Sprintf(testLargeKeyFormat, 1)),
-						Value: []byte(largeValues[1]),
+						Key:            []byte(fmt.Sprintf(testLargeKeyFormat, 1)),
+						Value:          []byte(largeValues[1]),
+						CreateRevision: 1,
+						ModRevision:    1,
+						Version:        1,
 					},
 					{
-						Key:   []byte(fmt.Sprintf(testLargeKeyFormat, 2)),
-						Value: []byte(largeValues[2]),
+						Key:            []byte(fmt.Sprintf(testLargeKeyFormat, 2)),
+						Value:          []byte(largeValues[2]),
+						CreateRevision: 2,
+						ModRevision:    2,
+						Version:        1,
 					},
 				},
 				Count: 3,
@@ -522,9 +729,9 @@ func TestFSM_Lookup_Range(t *testing.T) {
 			},
 			want: &regattapb.ResponseOp_Range{
 				Kvs: []*regattapb.KeyValue{
-					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 0))},
-					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 1))},
-					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 2))},
+					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 0)), Version: 1},
+					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 1)), CreateRevision: 1, ModRevision: 1, Version: 1},
+					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 2)), CreateRevision: 2, ModRevision: 2, Version: 1},
 				},
 				Count: 3,
 				More:  true,
@@ -543,9 +750,9 @@ func TestFSM_Lookup_Range(t *testing.T) {
 			},
 			want: &regattapb.ResponseOp_Range{
 				Kvs: []*regattapb.KeyValue{
-					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 0))},
-					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 1))},
-					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 2))},
+					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 0)), Version: 1},
+					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 1)), CreateRevision: 1, ModRevision: 1, Version: 1},
+					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 2)), CreateRevision: 2, ModRevision: 2, Version: 1},
 				},
 				Count: 3,
 			},
@@ -621,6 +828,75 @@ func TestFSM_Lookup_Range(t *testing.T) {
 				Count: 10000,
 			},
 		},
+		{
+			name: "Range count only with min mod revision",
+			fields: fields{
+				smFactory: filledSM,
+			},
+			req: &regattapb.RequestOp_Range{
+				Key:            []byte{0},
+				RangeEnd:       []byte{0},
+				CountOnly:      true,
+				MinModRevision: smallEntries - 10,
+			},
+			want: &regattapb.ResponseOp_Range{
+				Count: 10,
+			},
+		},
+		{
+			name: "Range keys only with create revision bounds and limit",
+			fields: fields{
+				smFactory: filledSM,
+			},
+			req: &regattapb.RequestOp_Range{
+				Key:               []byte(fmt.Sprintf(testLargeKeyFormat, 0)),
+				RangeEnd:          []byte{0},
+				KeysOnly:          true,
+				Limit:             2,
+				MinCreateRevision: 3,
+				MaxCreateRevision: 5,
+			},
+			want: &regattapb.ResponseOp_Range{
+				Kvs: []*regattapb.KeyValue{
+					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 3)), CreateRevision: 3, ModRevision: 3, Version: 1},
+					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 4)), CreateRevision: 4, ModRevision: 4, Version: 1},
+				},
+				Count: 2,
+				More:  true,
+			},
+		},
+		{
+			name: "Range keys only with max mod revision (no more matching keys)",
+			fields: fields{
+				smFactory: filledSM,
+			},
+			req: &regattapb.RequestOp_Range{
+				Key:            []byte(fmt.Sprintf(testLargeKeyFormat, 0)),
+				RangeEnd:       []byte{0},
+				KeysOnly:       true,
+				Limit:          2,
+				MinModRevision: 8,
+				MaxModRevision: 9,
+			},
+			want: &regattapb.ResponseOp_Range{
+				Kvs: []*regattapb.KeyValue{
+					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 8)), CreateRevision: 8, ModRevision: 8, Version: 1},
+					{Key: []byte(fmt.Sprintf(testLargeKeyFormat, 9)), CreateRevision: 9, ModRevision: 9, Version: 1},
+				},
+				Count: 2,
+			},
+		},
+		{
+			name: "Single key filtered out by min mod revision",
+			fields: fields{
+				smFactory: filledSM,
+			},
+			req: &regattapb.RequestOp_Range{
+				Key:            []byte(fmt.Sprintf(testKeyFormat, 1)),
+				MinModRevision: 2,
+			},
+			want: &regattapb.ResponseOp_Range{},
+		},
 	}
 
 	for _, tt := range tests {
@@ -650,3 +926,206 @@ func TestFSM_Lookup_Range(t *testing.T) {
 		})
 	}
 }
+
+func TestFSM_Lookup_RangeFilter(t *testing.T) {
+	r := require.New(t)
+	fsm := emptySM()
+	defer fsm.Close()
+
+	var entries []statemachine.Entry
+	for i, kv := range [][2]string{
+		{"fruit/apple", "green apple"},
+		{"fruit/banana", "yellow banana"},
+		{"fruit/cherry", "red cherry"},
+		{"vegetable/carrot", "orange carrot"},
+	} {
+		entries = append(entries, statemachine.Entry{Index: uint64(i + 1), Cmd: mustMarshallProto(&regattapb.Command{
+			Table: []byte(testTable),
+			Type:  regattapb.Command_PUT,
+			Kv:    &regattapb.KeyValue{Key: []byte(kv[0]), Value: []byte(kv[1])},
+		})})
+	}
+	entries = append(entries, largeValueEntries(5, 42, []byte("fruit/grape"), []byte("red grape of the large value"), 4, 0)...)
+	_, err := fsm.Update(entries)
+	r.NoError(err)
+
+	tests := []struct {
+		name     string
+		req      *regattapb.RequestOp_Range
+		wantKeys []string
+		wantMore bool
+	}{
+		{
+			name:     "key regex",
+			req:      &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}, Filter: &regattapb.RangeFilter{KeyRegex: "^fruit/.*a"}},
+			wantKeys: []string{"fruit/apple", "fruit/banana", "fruit/grape"},
+		},
+		{
+			name:     "value prefix",
+			req:      &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}, Filter: &regattapb.RangeFilter{ValuePrefix: []byte("red")}},
+			wantKeys: []string{"fruit/cherry", "fruit/grape"},
+		},
+		{
+			name:     "value suffix",
+			req:      &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}, Filter: &regattapb.RangeFilter{ValueSuffix: []byte("carrot")}},
+			wantKeys: []string{"vegetable/carrot"},
+		},
+		{
+			name:     "value contains",
+			req:      &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}, Filter: &regattapb.RangeFilter{ValueContains: []byte("an")}},
+			wantKeys: []string{"fruit/banana", "vegetable/carrot"},
+		},
+		{
+			name:     "value length bounds",
+			req:      &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}, Filter: &regattapb.RangeFilter{MinValueLength: 11, MaxValueLength: 13}},
+			wantKeys: []string{"fruit/apple", "fruit/banana", "vegetable/carrot"},
+		},
+		{
+			name:     "combined expressions",
+			req:      &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}, Filter: &regattapb.RangeFilter{KeyRegex: "^fruit/", ValuePrefix: []byte("red"), MaxValueLength: 20}},
+			wantKeys: []string{"fruit/cherry"},
+		},
+		{
+			name:     "limit counts only matching entries",
+			req:      &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}, Limit: 1, Filter: &regattapb.RangeFilter{ValuePrefix: []byte("red")}},
+			wantKeys: []string{"fruit/cherry"},
+			wantMore: true,
+		},
+		{
+			name:     "single key not matching",
+			req:      &regattapb.RequestOp_Range{Key: []byte("fruit/apple"), Filter: &regattapb.RangeFilter{ValuePrefix: []byte("red")}},
+			wantKeys: nil,
+		},
+		{
+			name:     "single key matching",
+			req:      &regattapb.RequestOp_Range{Key: []byte("fruit/apple"), Filter: &regattapb.RangeFilter{KeyRegex: "apple$"}},
+			wantKeys: []string{"fruit/apple"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			res, err := fsm.Lookup(tt.req)
+			r.NoError(err)
+			response := res.(*regattapb.ResponseOp_Range)
+			var keys []string
+			for _, kv := range response.Kvs {
+				keys = append(keys, string(kv.Key))
+			}
+			r.Equal(tt.wantKeys, keys)
+			r.Equal(int64(len(tt.wantKeys)), response.Count)
+			r.Equal(tt.wantMore, response.More)
+
+			tt.req.CountOnly = true
+			res, err = fsm.Lookup(tt.req)
+			r.NoError(err)
+			r.Equal(int64(len(tt.wantKeys)), res.(*regattapb.ResponseOp_Range).Count)
+		})
+	}
+
+	t.Run("invalid key regex", func(t *testing.T) {
+		_, err := fsm.Lookup(&regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}, Filter: &regattapb.RangeFilter{KeyRegex: "("}})
+		require.Error(t, err)
+	})
+}
+
+func TestFSM_Lookup_RangeSortOrder(t *testing.T) {
+	fsm := filledSM()
+	defer fsm.Close()
+
+	tests := []struct {
+		name     string
+		req      *regattapb.RequestOp_Range
+		wantKeys []string
+		wantMore bool
+	}{
+		{
+			name:     "ascending order with limit",
+			req:      &regattapb.RequestOp_Range{Key: []byte("testlarge"), RangeEnd: incrementRightmostByte([]byte("testlarge")), Limit: 3, KeysOnly: true, SortOrder: regattapb.SortOrder_ASCEND},
+			wantKeys: []string{"testlarge0", "testlarge1", "testlarge2"},
+			wantMore: true,
+		},
+		{
+			name:     "descending order with limit",
+			req:      &regattapb.RequestOp_Range{Key: []byte("testlarge"), RangeEnd: incrementRightmostByte([]byte("testlarge")), Limit: 3, KeysOnly: true, SortOrder: regattapb.SortOrder_DESCEND},
+			wantKeys: []string{"testlarge9", "testlarge8", "testlarge7"},
+			wantMore: true,
+		},
+		{
+			name:     "descending order stops on range start",
+			req:      &regattapb.RequestOp_Range{Key: []byte("testlarge7"), RangeEnd: incrementRightmostByte([]byte("testlarge")), Limit: 5, KeysOnly: true, SortOrder: regattapb.SortOrder_DESCEND},
+			wantKeys: []string{"testlarge9", "testlarge8", "testlarge7"},
+		},
+		{
+			name:     "descending order with filter",
+			req:      &regattapb.RequestOp_Range{Key: []byte("testlarge"), RangeEnd: incrementRightmostByte([]byte("testlarge")), Limit: 2, KeysOnly: true, SortOrder: regattapb.SortOrder_DESCEND, Filter: &regattapb.RangeFilter{KeyRegex: "[0-4]$"}},
+			wantKeys: []string{"testlarge4", "testlarge3"},
+			wantMore: true,
+		},
+		{
+			name:     "descending order of the whole table",
+			req:      &regattapb.RequestOp_Range{Key: []byte{0}, RangeEnd: []byte{0}, Limit: 2, KeysOnly: true, SortOrder: regattapb.SortOrder_DESCEND},
+			wantKeys: []string{"testlarge9", "testlarge8"},
+			wantMore: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			res, err := fsm.Lookup(tt.req)
+			r.NoError(err)
+			response := res.(*regattapb.ResponseOp_Range)
+			var keys []string
+			for _, kv := range response.Kvs {
+				keys = append(keys, string(kv.Key))
+			}
+			r.Equal(tt.wantKeys, keys)
+			r.Equal(tt.wantMore, response.More)
+		})
+	}
+
+	t.Run("descending order stops at maxRangeSize", func(t *testing.T) {
+		r := require.New(t)
+		large := filledLargeValuesSM()
+		defer large.Close()
+		res, err := large.Lookup(&regattapb.RequestOp_Range{Key: []byte("test"), RangeEnd: incrementRightmostByte([]byte("test")), SortOrder: regattapb.SortOrder_DESCEND})
+		r.NoError(err)
+		response := res.(*regattapb.ResponseOp_Range)
+		r.Equal(int64(399), response.Count)
+		r.True(response.More)
+		r.Greater(string(response.Kvs[0].Key), string(response.Kvs[1].Key))
+	})
+}
+
+func TestFSM_Lookup_RangeRequestRevision(t *testing.T) {
+	r := require.New(t)
+	p := emptySM()
+	defer func() {
+		r.NoError(p.Close())
+	}()
+	put := func(index uint64, leaderIndex *uint64) {
+		_, err := p.Update([]statemachine.Entry{{Index: index, Cmd: mustMarshallProto(&regattapb.Command{
+			Table:       []byte(testTable),
+			Type:        regattapb.Command_PUT,
+			Kv:          &regattapb.KeyValue{Key: []byte("key"), Value: []byte(testValue)},
+			LeaderIndex: leaderIndex,
+		})}})
+		r.NoError(err)
+	}
+
+	t.Log("revision is the applied index")
+	put(1, nil)
+	put(2, nil)
+	res, err := p.Lookup(RangeRequest{Range: &regattapb.RequestOp_Range{Key: []byte("key")}})
+	r.NoError(err)
+	r.Equal(uint64(2), res.(*RangeResponse).Revision)
+	r.Equal(int64(2), res.(*RangeResponse).Range.Kvs[0].ModRevision)
+
+	t.Log("revision of the replicated table is the leader index")
+	leaderIndex := uint64(10)
+	put(3, &leaderIndex)
+	res, err = p.Lookup(RangeRequest{Range: &regattapb.RequestOp_Range{Key: []byte("key")}})
+	r.NoError(err)
+	r.Equal(leaderIndex, res.(*RangeResponse).Revision)
+	r.Equal(int64(leaderIndex), res.(*RangeResponse).Range.Kvs[0].ModRevision)
+}
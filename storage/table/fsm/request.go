@@ -0,0 +1,127 @@
+// Copyright JAMF Software, LLC
+
+package fsm
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/jamf/regatta/regattapb"
+	"github.com/jamf/regatta/storage/table/key"
+)
+
+var (
+	// sysRequestPrefix prefix of the system keys holding the results of the commands applied with the request ID
+	// (the key is followed by the request ID).
+	sysRequestPrefix = []byte("request/")
+	// sysRequestRevisionPrefix prefix of the system keys indexing the request IDs by the revision the command was applied at
+	// (the key is followed by the big endian revision and the request ID).
+	sysRequestRevisionPrefix = []byte("request_rev/")
+	requestsLowerBound       = mustEncodeKey(key.Key{KeyType: key.TypeSystem, Key: sysRequestPrefix})
+	requestsUpperBound       = incrementRightmostByte(mustEncodeKey(key.Key{KeyType: key.TypeSystem, Key: sysRequestPrefix}))
+)
+
+// requestKey encodes the system key of the result of the command applied with the request ID.
+func requestKey(id []byte) []byte {
+	k := make([]byte, 0, len(sysRequestPrefix)+len(id))
+	k = append(k, sysRequestPrefix...)
+	k = append(k, id...)
+	return mustEncodeKey(key.Key{KeyType: key.TypeSystem, Key: k})
+}
+
+// requestRevisionKey encodes the system key indexing the request ID by the revision, a nil request ID encodes
+// the lower bound of the index keys of the revision.
+func requestRevisionKey(revision uint64, id []byte) []byte {
+	k := make([]byte, 0, len(sysRequestRevisionPrefix)+8+len(id))
+	k = append(k, sysRequestRevisionPrefix...)
+	k = binary.BigEndian.AppendUint64(k, revision)
+	k = append(k, id...)
+	return mustEncodeKey(key.Key{KeyType: key.TypeSystem, Key: k})
+}
+
+// readRequestResult reads the stored result of the command applied with the request ID, nil is returned if there is none.
+func readRequestResult(reader pebble.Reader, id []byte) (*regattapb.RequestResult, error) {
+	raw, closer, err := reader.Get(requestKey(id))
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer func() {
+		_ = closer.Close()
+	}()
+	res := &regattapb.RequestResult{}
+	if err := res.UnmarshalVT(raw); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// storeRequestResult stores the result of the command applied with the request ID along with its revision index.
+func storeRequestResult(ctx *updateContext, res *regattapb.RequestResult) error {
+	bts, err := res.MarshalVT()
+	if err != nil {
+		return err
+	}
+	if err := ctx.batch.Set(requestKey(res.RequestId), bts, nil); err != nil {
+		return err
+	}
+	return ctx.batch.Set(requestRevisionKey(res.Revision, res.RequestId), nil, nil)
+}
+
+// readRequestResults calls the function with every stored result of the commands applied with the request ID.
+func readRequestResults(reader pebble.Reader, f func(res *regattapb.RequestResult) error) error {
+	iter := reader.NewIter(&pebble.IterOptions{LowerBound: requestsLowerBound, UpperBound: requestsUpperBound})
+	defer func() {
+		_ = iter.Close()
+	}()
+	for iter.First(); iter.Valid(); iter.Next() {
+		res := &regattapb.RequestResult{}
+		if err := res.UnmarshalVT(iter.Value()); err != nil {
+			return err
+		}
+		if err := f(res); err != nil {
+			return err
+		}
+	}
+	return iter.Error()
+}
+
+// pruneRequestResults drops the stored results of the commands applied before the revision. The result is kept
+// if the request ID was reused by a command applied at or after the revision.
+func pruneRequestResults(ctx *updateContext, revision uint64) error {
+	if err := ctx.EnsureIndexed(); err != nil {
+		return err
+	}
+	lower, upper := requestRevisionKey(0, nil), requestRevisionKey(revision, nil)
+	iter := ctx.batch.NewIter(&pebble.IterOptions{LowerBound: lower, UpperBound: upper})
+	defer func() {
+		_ = iter.Close()
+	}()
+	prefixLen := len(sysRequestRevisionPrefix) + 8
+	for iter.First(); iter.Valid(); iter.Next() {
+		k, err := key.DecodeBytes(iter.Key())
+		if err != nil {
+			return err
+		}
+		if len(k.Key) < prefixLen {
+			continue
+		}
+		id := k.Key[prefixLen:]
+		res, err := readRequestResult(ctx.batch, id)
+		if err != nil {
+			return err
+		}
+		if res != nil && res.Revision < revision {
+			if err := ctx.batch.Delete(requestKey(id), nil); err != nil {
+				return err
+			}
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	return ctx.batch.DeleteRange(lower, upper, nil)
+}
@@ -165,6 +165,9 @@ func (c *checkpoint) recover(r io.Reader, stopc <-chan struct{}) error {
 	if err != nil {
 		return err
 	}
+	if err := migrateValues(db); err != nil {
+		return err
+	}
 	idx, err := readLocalIndex(db, sysLocalIndex)
 	if err != nil {
 		return err
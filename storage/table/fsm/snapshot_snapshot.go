@@ -157,6 +157,9 @@ read:
 	if err := db.Ingest(files); err != nil {
 		return err
 	}
+	if err := migrateValues(db); err != nil {
+		return err
+	}
 	idx, err := readLocalIndex(db, sysLocalIndex)
 	if err != nil {
 		return err
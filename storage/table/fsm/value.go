@@ -0,0 +1,220 @@
+// Copyright JAMF Software, LLC
+
+package fsm
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/jamf/regatta/storage/table/key"
+)
+
+// valueFormatV1 stored user value layout:
+// 0 value format
+// 1-n uvarint create revision
+// n-m uvarint mod revision
+// m-o uvarint version
+// o-p uvarint lease
+// p-  user value.
+const valueFormatV1 byte = 1
+
+// valueFormatLargeV1 stored large user value layout, the metadata are the same as of valueFormatV1
+// but the user value is replaced by the reference of its chunks:
+// 0 value format
+// 1-o uvarint create revision, mod revision, version and lease
+// o-p uvarint chunks ID
+// p-q uvarint number of chunks
+// q-r uvarint size of the user value.
+const valueFormatLargeV1 byte = 2
+
+// legacyRevision revision of the values stored before the revisions were tracked, no user write is ever applied at it
+// as the first entry of every raft log is a membership change.
+const legacyRevision uint64 = 1
+
+var (
+	// errMalformedValue stored value could not be decoded.
+	errMalformedValue = errors.New("malformed value")
+	// errUnknownValueFormat stored value format is not implemented in this build.
+	errUnknownValueFormat = errors.New("unknown value format")
+)
+
+var (
+	// sysValueFormat marks the format of the stored user values, tables without the mark store raw user values.
+	sysValueFormat = mustEncodeKey(key.Key{
+		KeyType: key.TypeSystem,
+		Key:     []byte("value_format"),
+	})
+	// sysValueMigration holds the last user key migrated into the latest value format (if the migration is in progress).
+	sysValueMigration = mustEncodeKey(key.Key{
+		KeyType: key.TypeSystem,
+		Key:     []byte("value_migration"),
+	})
+	userKeysLowerBound = mustEncodeKey(key.Key{KeyType: key.TypeUser})
+	userKeysUpperBound = mustEncodeKey(key.Key{KeyType: key.TypeSystem})
+)
+
+// storedValue user value stored in the table together with its metadata.
+type storedValue struct {
+	createRevision uint64
+	modRevision    uint64
+	version        uint64
+	lease          uint64
+	// large references the chunks of the large user value, the data is empty if set, see readLargeValue.
+	large *largeValue
+	data  []byte
+}
+
+// largeValue references the chunks of the user value stored by the PUT_LARGE command.
+type largeValue struct {
+	id     uint64
+	chunks uint64
+	size   uint64
+}
+
+// size returns the size of the user value.
+func (v storedValue) size() uint64 {
+	if v.large != nil {
+		return v.large.size
+	}
+	return uint64(len(v.data))
+}
+
+// appendValue appends the encoded value to the dst and returns the extended buffer.
+func appendValue(dst []byte, v storedValue) []byte {
+	if v.large != nil {
+		dst = append(dst, valueFormatLargeV1)
+	} else {
+		dst = append(dst, valueFormatV1)
+	}
+	dst = binary.AppendUvarint(dst, v.createRevision)
+	dst = binary.AppendUvarint(dst, v.modRevision)
+	dst = binary.AppendUvarint(dst, v.version)
+	dst = binary.AppendUvarint(dst, v.lease)
+	if v.large != nil {
+		dst = binary.AppendUvarint(dst, v.large.id)
+		dst = binary.AppendUvarint(dst, v.large.chunks)
+		return binary.AppendUvarint(dst, v.large.size)
+	}
+	return append(dst, v.data...)
+}
+
+// decodeValue transforms raw bytes into a storedValue, input bytes are not copied.
+func decodeValue(raw []byte) (storedValue, error) {
+	if len(raw) == 0 {
+		return storedValue{}, errMalformedValue
+	}
+	format := raw[0]
+	if format != valueFormatV1 && format != valueFormatLargeV1 {
+		return storedValue{}, errUnknownValueFormat
+	}
+	raw = raw[1:]
+	create, n := binary.Uvarint(raw)
+	if n <= 0 {
+		return storedValue{}, errMalformedValue
+	}
+	raw = raw[n:]
+	mod, n := binary.Uvarint(raw)
+	if n <= 0 {
+		return storedValue{}, errMalformedValue
+	}
+	raw = raw[n:]
+	version, n := binary.Uvarint(raw)
+	if n <= 0 {
+		return storedValue{}, errMalformedValue
+	}
+	raw = raw[n:]
+	lease, n := binary.Uvarint(raw)
+	if n <= 0 {
+		return storedValue{}, errMalformedValue
+	}
+	if format == valueFormatLargeV1 {
+		large, err := decodeLargeValue(raw[n:])
+		if err != nil {
+			return storedValue{}, err
+		}
+		return storedValue{createRevision: create, modRevision: mod, version: version, lease: lease, large: large}, nil
+	}
+	return storedValue{createRevision: create, modRevision: mod, version: version, lease: lease, data: raw[n:]}, nil
+}
+
+func decodeLargeValue(raw []byte) (*largeValue, error) {
+	var fields [3]uint64
+	for i := range fields {
+		v, n := binary.Uvarint(raw)
+		if n <= 0 {
+			return nil, errMalformedValue
+		}
+		fields[i] = v
+		raw = raw[n:]
+	}
+	return &largeValue{id: fields[0], chunks: fields[1], size: fields[2]}, nil
+}
+
+// migrateValues rewrites the raw user values stored by the previous versions into the latest value format.
+// The revisions of migrated values are unknown and so are set to legacyRevision, the version is set to 1.
+// The migration is resumable, the progress is committed together with every batch so the values are never encoded twice.
+func migrateValues(db *pebble.DB) error {
+	format, closer, err := db.Get(sysValueFormat)
+	switch {
+	case err == nil:
+		defer func() {
+			_ = closer.Close()
+		}()
+		if len(format) != 1 || format[0] != valueFormatV1 {
+			return errUnknownValueFormat
+		}
+		return nil
+	case !errors.Is(err, pebble.ErrNotFound):
+		return err
+	}
+
+	opts := &pebble.IterOptions{LowerBound: userKeysLowerBound, UpperBound: userKeysUpperBound}
+	last, closer, err := db.Get(sysValueMigration)
+	switch {
+	case err == nil:
+		opts.LowerBound = append(append([]byte(nil), last...), 0)
+		_ = closer.Close()
+	case !errors.Is(err, pebble.ErrNotFound):
+		return err
+	}
+
+	iter := db.NewIter(opts)
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	batch := db.NewBatch()
+	defer func() {
+		_ = batch.Close()
+	}()
+	var buf []byte
+	for iter.First(); iter.Valid(); iter.Next() {
+		buf = appendValue(buf[:0], storedValue{createRevision: legacyRevision, modRevision: legacyRevision, version: 1, data: iter.Value()})
+		if err := batch.Set(iter.Key(), buf, nil); err != nil {
+			return err
+		}
+		if batch.Len() >= maxBatchSize {
+			if err := batch.Set(sysValueMigration, iter.Key(), nil); err != nil {
+				return err
+			}
+			if err := batch.Commit(pebble.NoSync); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	if err := batch.Set(sysValueFormat, []byte{valueFormatV1}, nil); err != nil {
+		return err
+	}
+	if err := batch.Delete(sysValueMigration, nil); err != nil {
+		return err
+	}
+	if err := batch.Commit(pebble.NoSync); err != nil {
+		return err
+	}
+	return db.Flush()
+}
@@ -0,0 +1,128 @@
+// Copyright JAMF Software, LLC
+
+package fsm
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/vfs"
+	rp "github.com/jamf/regatta/pebble"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_decodeValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     []byte
+		want    storedValue
+		wantErr error
+	}{
+		{
+			name: "value with revisions",
+			raw:  appendValue(nil, storedValue{createRevision: 1, modRevision: 1000, version: 3, data: []byte("value")}),
+			want: storedValue{createRevision: 1, modRevision: 1000, version: 3, data: []byte("value")},
+		},
+		{
+			name: "value with lease",
+			raw:  appendValue(nil, storedValue{createRevision: 2, modRevision: 2, version: 1, lease: 1 << 40, data: []byte("value")}),
+			want: storedValue{createRevision: 2, modRevision: 2, version: 1, lease: 1 << 40, data: []byte("value")},
+		},
+		{
+			name: "empty value",
+			raw:  appendValue(nil, storedValue{createRevision: 5, modRevision: 5, version: 1}),
+			want: storedValue{createRevision: 5, modRevision: 5, version: 1, data: []byte{}},
+		},
+		{
+			name: "large value",
+			raw:  appendValue(nil, storedValue{createRevision: 2, modRevision: 3, version: 2, lease: 1, large: &largeValue{id: 1 << 60, chunks: 3, size: 3 << 20}}),
+			want: storedValue{createRevision: 2, modRevision: 3, version: 2, lease: 1, large: &largeValue{id: 1 << 60, chunks: 3, size: 3 << 20}},
+		},
+		{
+			name:    "missing format",
+			raw:     []byte{},
+			wantErr: errMalformedValue,
+		},
+		{
+			name:    "unknown format",
+			raw:     []byte{0xff, 1, 1},
+			wantErr: errUnknownValueFormat,
+		},
+		{
+			name:    "missing mod revision",
+			raw:     []byte{valueFormatV1, 1},
+			wantErr: errMalformedValue,
+		},
+		{
+			name:    "missing version",
+			raw:     []byte{valueFormatV1, 1, 1},
+			wantErr: errMalformedValue,
+		},
+		{
+			name:    "missing lease",
+			raw:     []byte{valueFormatV1, 1, 1, 1},
+			wantErr: errMalformedValue,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			got, err := decodeValue(tt.raw)
+			if tt.wantErr != nil {
+				r.ErrorIs(err, tt.wantErr)
+				return
+			}
+			r.NoError(err)
+			r.Equal(tt.want, got)
+		})
+	}
+}
+
+func Test_migrateValues(t *testing.T) {
+	r := require.New(t)
+	db, err := rp.OpenDB("/", rp.WithFS(vfs.NewMem()))
+	r.NoError(err)
+	defer db.Close()
+
+	keys := []string{"key_1", "key_2", "key_3"}
+	for _, k := range keys {
+		r.NoError(db.Set(mustEncodeUserKey(k), []byte("value"), pebble.NoSync))
+	}
+	// Simulate migration interrupted after the first key.
+	r.NoError(db.Set(mustEncodeUserKey(keys[0]), appendValue(nil, storedValue{createRevision: legacyRevision, modRevision: legacyRevision, version: 1, data: []byte("value")}), pebble.NoSync))
+	r.NoError(db.Set(sysValueMigration, mustEncodeUserKey(keys[0]), pebble.NoSync))
+
+	r.NoError(migrateValues(db))
+	// Repeated migration is a no-op.
+	r.NoError(migrateValues(db))
+
+	for _, k := range keys {
+		raw, closer, err := db.Get(mustEncodeUserKey(k))
+		r.NoError(err)
+		val, err := decodeValue(raw)
+		r.NoError(err)
+		r.Equal(storedValue{createRevision: legacyRevision, modRevision: legacyRevision, version: 1, data: []byte("value")}, val)
+		r.NoError(closer.Close())
+	}
+	_, _, err = db.Get(sysValueMigration)
+	r.ErrorIs(err, pebble.ErrNotFound)
+}
+
+func Test_migrateValuesUnknownFormat(t *testing.T) {
+	r := require.New(t)
+	db, err := rp.OpenDB("/", rp.WithFS(vfs.NewMem()))
+	r.NoError(err)
+	defer db.Close()
+
+	r.NoError(db.Set(sysValueFormat, []byte{0xff}, pebble.NoSync))
+	r.ErrorIs(migrateValues(db), errUnknownValueFormat)
+}
+
+func mustEncodeUserKey(k string) []byte {
+	buf := bufferPool.Get()
+	defer bufferPool.Put(buf)
+	if err := encodeUserKey(buf, []byte(k)); err != nil {
+		panic(err)
+	}
+	return append([]byte(nil), buf.Bytes()...)
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/jamf/regatta/storage/kv"
 	"github.com/jamf/regatta/storage/table/fsm"
 	"github.com/lni/dragonboat/v4"
+	"github.com/lni/dragonboat/v4/client"
 	"github.com/lni/dragonboat/v4/config"
 	"go.uber.org/zap"
 )
@@ -49,6 +50,11 @@ func NewManager(nh *dragonboat.NodeHost, members map[uint64]string, cfg Config)
 		cleanupInterval:    30 * time.Second,
 		cleanupGracePeriod: 5 * time.Minute,
 		cleanupTimeout:     5 * time.Minute,
+		leaseInterval:      time.Second,
+		leaseTimeout:       30 * time.Second,
+		compactionInterval: time.Minute,
+		compactionTimeout:  5 * time.Minute,
+		heartbeatTimeout:   5 * time.Second,
 		readyChan:          make(chan struct{}),
 		members:            members,
 		cfg:                cfg,
@@ -85,9 +91,16 @@ type Manager struct {
 	cleanupInterval    time.Duration
 	cleanupGracePeriod time.Duration
 	cleanupTimeout     time.Duration
+	leaseInterval      time.Duration
+	leaseTimeout       time.Duration
+	compactionInterval time.Duration
+	compactionTimeout  time.Duration
+	heartbeatTimeout   time.Duration
 	log                *zap.SugaredLogger
 	blockCache         *pebble.Cache
 	tableCache         *pebble.TableCache
+	// dedicatedCaches are the caches dedicated to the tables by the cluster ID.
+	dedicatedCaches sync.Map
 }
 
 type Lease struct {
@@ -161,17 +174,48 @@ func (m *Manager) ReturnTable(name string) (bool, error) {
 }
 
 func (m *Manager) CreateTable(name string) error {
+	return m.CreateTableWithOptions(name, Options{})
+}
+
+// CreateTableWithOptions creates the table with the given options stored in the table metadata.
+func (m *Manager) CreateTableWithOptions(name string, opts Options) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
-	created, err := m.createTable(name)
+	created, err := m.createTable(name, opts)
 	if err != nil {
 		return err
 	}
 
-	return m.startTable(created.Name, created.ClusterID)
+	return m.startTable(created, created.ClusterID)
+}
+
+// UpdateTableOptions replaces the options of the table. The maximum value size is applied once the table metadata
+// is reloaded, other options are applied the next time the table is started.
+func (m *Manager) UpdateTableOptions(name string, opts Options) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	tbl, err := func() (Table, error) {
+		m.mtx.Lock()
+		defer m.mtx.Unlock()
+		tbl, version, err := m.getTableVersion(name)
+		if err != nil {
+			return Table{}, err
+		}
+		tbl.Options = opts
+		return tbl, m.setTableVersion(tbl, version)
+	}()
+	if err != nil {
+		return err
+	}
+	m.cacheTable(tbl)
+	return nil
 }
 
-func (m *Manager) createTable(name string) (Table, error) {
+func (m *Manager) createTable(name string, opts Options) (Table, error) {
 	storeName := storedTableName(name)
 	exists, err := m.store.Exists(storeName)
 	if err != nil {
@@ -187,6 +231,7 @@ func (m *Manager) createTable(name string) (Table, error) {
 	tab := Table{
 		Name:      name,
 		ClusterID: seq,
+		Options:   opts,
 	}
 	err = m.setTableVersion(tab, 0)
 	if err != nil {
@@ -204,6 +249,9 @@ func (m *Manager) DeleteTable(name string) error {
 	storeName := storedTableName(name)
 	tab, err := m.store.Get(storeName)
 	if err != nil {
+		if errors.Is(err, kv.ErrNotExist) {
+			return serrors.ErrTableNotFound
+		}
 		return err
 	}
 
@@ -273,6 +321,15 @@ func (m *Manager) Start() error {
 				if ok {
 					go m.reconcileLoop()
 					go m.cleanupLoop()
+					if m.cfg.ExpireLeases {
+						go m.leaseExpiryLoop()
+					}
+					if m.cfg.HistoryRetention > 0 {
+						go m.compactionLoop()
+					}
+					if m.cfg.HeartbeatInterval > 0 {
+						go m.heartbeatLoop()
+					}
 					close(m.readyChan)
 					return
 				}
@@ -343,7 +400,7 @@ func (m *Manager) reconcile() error {
 
 	start, stop := diffTables(tabs, nhi.ShardInfoList)
 	for id, tbl := range start {
-		err = m.startTable(tbl.Name, id)
+		err = m.startTable(tbl, id)
 		if err != nil {
 			return err
 		}
@@ -412,6 +469,112 @@ func (m *Manager) cleanup() error {
 	return nil
 }
 
+func (m *Manager) leaseExpiryLoop() {
+	t := time.NewTicker(m.leaseInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-m.closed:
+			return
+		case <-t.C:
+			m.expireLeases()
+		}
+	}
+}
+
+// expireLeases revokes the expired key leases in all the tables led by this node.
+func (m *Manager) expireLeases() {
+	for _, t := range m.ledTables() {
+		func() {
+			ctx, cancel := context.WithTimeout(context.Background(), m.leaseTimeout)
+			defer cancel()
+			if err := t.ExpireLeases(ctx, time.Now()); err != nil {
+				m.log.Errorf("[%d:%d] lease expiry failed: %v", t.ClusterID, m.cfg.NodeID, err)
+			}
+		}()
+	}
+}
+
+func (m *Manager) compactionLoop() {
+	t := time.NewTicker(m.compactionInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-m.closed:
+			return
+		case <-t.C:
+			m.compactHistory()
+		}
+	}
+}
+
+// compactHistory discards the history older than the configured retention in all the tables led by this node.
+func (m *Manager) compactHistory() {
+	for _, t := range m.ledTables() {
+		func() {
+			ctx, cancel := context.WithTimeout(context.Background(), m.compactionTimeout)
+			defer cancel()
+			rev, err := t.Revision(ctx, true)
+			if err != nil {
+				m.log.Errorf("[%d:%d] history compaction failed: %v", t.ClusterID, m.cfg.NodeID, err)
+				return
+			}
+			if rev.Revision <= m.cfg.HistoryRetention || rev.Revision-m.cfg.HistoryRetention <= rev.CompactRevision {
+				return
+			}
+			if err := t.Compact(ctx, rev.Revision-m.cfg.HistoryRetention); err != nil && !errors.Is(err, serrors.ErrCompacted) {
+				m.log.Errorf("[%d:%d] history compaction failed: %v", t.ClusterID, m.cfg.NodeID, err)
+			}
+		}()
+	}
+}
+
+func (m *Manager) heartbeatLoop() {
+	t := time.NewTicker(m.cfg.HeartbeatInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-m.closed:
+			return
+		case <-t.C:
+			m.heartbeat()
+		}
+	}
+}
+
+// heartbeat proposes the heartbeat into all the tables led by this node.
+func (m *Manager) heartbeat() {
+	for _, t := range m.ledTables() {
+		func() {
+			ctx, cancel := context.WithTimeout(context.Background(), m.heartbeatTimeout)
+			defer cancel()
+			if err := t.Heartbeat(ctx); err != nil {
+				m.log.Errorf("[%d:%d] heartbeat failed: %v", t.ClusterID, m.cfg.NodeID, err)
+			}
+		}()
+	}
+}
+
+// ledTables returns the cached tables led by this node.
+func (m *Manager) ledTables() []ActiveTable {
+	m.cache.mu.RLock()
+	tables := make([]ActiveTable, 0, len(m.cache.tables))
+	for _, t := range m.cache.tables {
+		tables = append(tables, t)
+	}
+	m.cache.mu.RUnlock()
+
+	led := tables[:0]
+	for _, t := range tables {
+		leaderID, _, ok, err := m.nh.GetLeaderID(t.ClusterID)
+		if err != nil || !ok || leaderID != m.cfg.NodeID {
+			continue
+		}
+		led = append(led, t)
+	}
+	return led
+}
+
 func (m *Manager) incAndGetIDSeq() (uint64, error) {
 	seq, err := m.store.Get(sequenceKey)
 	if err != nil {
@@ -487,21 +650,40 @@ func diffTables(tables map[string]Table, raftInfo []dragonboat.ShardInfo) (toSta
 	return
 }
 
-func (m *Manager) startTable(name string, id uint64) error {
+// startTable starts the table replica with the given cluster ID honouring the table options.
+func (m *Manager) startTable(tbl Table, id uint64) error {
+	caches := tableCaches{block: m.blockCache, table: m.tableCache}
+	if tbl.Options.BlockCacheSize > 0 {
+		// The table cache is bound to the block cache, a dedicated block cache requires a dedicated table cache.
+		caches.block = pebble.NewCache(tbl.Options.BlockCacheSize)
+		caches.table = pebble.NewTableCache(caches.block, runtime.GOMAXPROCS(-1), m.cfg.Table.TableCacheSize)
+	}
+	createFSM := fsm.New(tbl.Name, m.cfg.Table.DataDir, m.cfg.Table.FS, caches.block, caches.table, tbl.Options.recoveryType(m.cfg.Table), tbl.Options.compression())
+	members := m.members
 	if m.nh.HasNodeInfo(id, m.cfg.NodeID) {
-		return m.nh.StartOnDiskReplica(
-			map[uint64]dragonboat.Target{},
-			false,
-			fsm.New(name, m.cfg.Table.DataDir, m.cfg.Table.FS, m.blockCache, m.tableCache, fsm.SnapshotRecoveryType(m.cfg.Table.RecoveryType)),
-			tableRaftConfig(m.cfg.NodeID, id, m.cfg.Table),
-		)
+		members = map[uint64]dragonboat.Target{}
+	}
+	if err := m.nh.StartOnDiskReplica(members, false, createFSM, tableRaftConfig(m.cfg.NodeID, id, tbl.Options.tableConfig(m.cfg.Table))); err != nil {
+		if caches.block != m.blockCache {
+			caches.release()
+		}
+		return err
 	}
-	return m.nh.StartOnDiskReplica(
-		m.members,
-		false,
-		fsm.New(name, m.cfg.Table.DataDir, m.cfg.Table.FS, m.blockCache, m.tableCache, fsm.SnapshotRecoveryType(m.cfg.Table.RecoveryType)),
-		tableRaftConfig(m.cfg.NodeID, id, m.cfg.Table),
-	)
+	if caches.block != m.blockCache {
+		m.dedicatedCaches.Store(id, caches)
+	}
+	return nil
+}
+
+// tableCaches are the block and table caches used by a table.
+type tableCaches struct {
+	block *pebble.Cache
+	table *pebble.TableCache
+}
+
+func (c tableCaches) release() {
+	_ = c.table.Unref()
+	c.block.Unref()
 }
 
 func (m *Manager) cacheTable(tbl Table) {
@@ -552,6 +734,9 @@ func (m *Manager) stopTable(clusterID uint64) error {
 	if err := m.nh.StopShard(clusterID); err != nil {
 		return err
 	}
+	if c, ok := m.dedicatedCaches.LoadAndDelete(clusterID); ok {
+		c.(tableCaches).release()
+	}
 
 	// Unregister metrics, check dragonboat/v4/event.go for metric names
 	if m.nh.NodeHostConfig().EnableMetrics {
@@ -580,7 +765,7 @@ func (m *Manager) Restore(name string, reader io.Reader) error {
 	tbl.Name = name
 	tbl.RecoverID = recoveryID
 
-	err = m.startTable(tbl.Name, tbl.RecoverID)
+	err = m.startTable(tbl, tbl.RecoverID)
 	if err != nil {
 		return err
 	}
@@ -642,8 +827,6 @@ func (m *Manager) setTableVersion(tbl Table, version uint64) error {
 }
 
 func (m *Manager) readIntoTable(id uint64, reader io.Reader) error {
-	backOff := backoff.NewExponentialBackOff()
-	backOff.MaxElapsedTime = 0
 	session := m.nh.GetNoOPSession(id)
 	msg := make([]byte, 1024*1024*4)
 
@@ -675,35 +858,25 @@ func (m *Manager) readIntoTable(id uint64, reader io.Reader) error {
 			batchCmd.Table = cmd.Table
 			batchCmd.LeaderIndex = cmd.LeaderIndex
 
-			if uint64(estimatedSize) < m.cfg.Table.MaxInMemLogSize/2 {
-				batchCmd.Batch = append(batchCmd.Batch, cmd.Kv)
+			if cmd.Type != regattapb.Command_PUT {
+				// Commands other than PUTs (e.g. lease grants) are proposed as they are, after the batch collected so far.
+				if err := m.proposeBatch(session, batchCmd); err != nil {
+					return err
+				}
+				if err := m.proposeRetry(session, cmd); err != nil {
+					return err
+				}
+				estimatedSize = 0
 				continue
 			}
-		}
 
-		bb, err := batchCmd.MarshalVT()
-		if err != nil {
-			return err
-		}
-		batchCmd.LeaderIndex = nil
-		batchCmd.Batch = batchCmd.Batch[:0]
-
-		err = backoff.Retry(func() error {
-			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-			defer cancel()
-			_, err := m.nh.SyncPropose(ctx, session, bb)
-			if err != nil {
-				if errors.Is(err, dragonboat.ErrShardNotFound) {
-					m.log.Warn("cluster not found recovery probably started on a different node")
-					return backoff.Permanent(err)
-				}
-				m.log.Warnf("error proposing batch %v", err)
-				return err
+			batchCmd.Batch = append(batchCmd.Batch, cmd.Kv)
+			if uint64(estimatedSize) < m.cfg.Table.MaxInMemLogSize/2 {
+				continue
 			}
-			return nil
-		}, backOff)
+		}
 
-		if err != nil {
+		if err := m.proposeBatch(session, batchCmd); err != nil {
 			return err
 		}
 
@@ -716,6 +889,43 @@ func (m *Manager) readIntoTable(id uint64, reader io.Reader) error {
 	return nil
 }
 
+// proposeBatch proposes the batch command (if it is not empty) and resets the batch.
+func (m *Manager) proposeBatch(session *client.Session, batchCmd *regattapb.Command) error {
+	if len(batchCmd.Batch) == 0 {
+		return nil
+	}
+	if err := m.proposeRetry(session, batchCmd); err != nil {
+		return err
+	}
+	batchCmd.LeaderIndex = nil
+	batchCmd.Batch = batchCmd.Batch[:0]
+	return nil
+}
+
+// proposeRetry proposes the command, retrying with an exponential backoff until it succeeds.
+func (m *Manager) proposeRetry(session *client.Session, cmd *regattapb.Command) error {
+	bb, err := cmd.MarshalVT()
+	if err != nil {
+		return err
+	}
+	backOff := backoff.NewExponentialBackOff()
+	backOff.MaxElapsedTime = 0
+	return backoff.Retry(func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		_, err := m.nh.SyncPropose(ctx, session, bb)
+		if err != nil {
+			if errors.Is(err, dragonboat.ErrShardNotFound) {
+				m.log.Warn("cluster not found recovery probably started on a different node")
+				return backoff.Permanent(err)
+			}
+			m.log.Warnf("error proposing batch %v", err)
+			return err
+		}
+		return nil
+	}, backOff)
+}
+
 func (m *Manager) waitForLeader(clusterID uint64) error {
 	t := time.NewTicker(500 * time.Millisecond)
 	defer t.Stop()
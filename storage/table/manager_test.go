@@ -3,6 +3,7 @@
 package table
 
 import (
+	"context"
 	"net"
 	"testing"
 	"time"
@@ -52,6 +53,49 @@ func TestManager_CreateTable(t *testing.T) {
 	r.Equal(1, len(ts))
 }
 
+func TestManager_TableOptions(t *testing.T) {
+	const testTableName = "test"
+	r := require.New(t)
+	node, m := startRaftNode(t)
+	defer node.Close()
+
+	tm := NewManager(node, m, minimalTestConfig())
+	r.NoError(tm.Start())
+	defer tm.Close()
+	r.NoError(tm.WaitUntilReady())
+
+	t.Log("create table with invalid options")
+	r.ErrorIs(tm.CreateTableWithOptions(testTableName, Options{Compression: "lz4"}), serrors.ErrInvalidTableOptions)
+
+	t.Log("create table with options")
+	opts := Options{MaxValueSize: 1024, SnapshotEntries: 10, Compression: CompressionZstd, BlockCacheSize: 1024 * 1024}
+	r.NoError(tm.CreateTableWithOptions(testTableName, opts))
+	tab, err := tm.GetTable(testTableName)
+	r.NoError(err)
+	r.Equal(opts, tab.Options)
+	r.Eventually(func() bool {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, err := tab.LocalIndex(ctx, true)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond, "table with options must start")
+	_, ok := tm.dedicatedCaches.Load(tab.ClusterID)
+	r.True(ok, "dedicated block cache must be created")
+
+	t.Log("update table options")
+	opts.MaxValueSize = 2048
+	r.NoError(tm.UpdateTableOptions(testTableName, opts))
+	tab, err = tm.GetTable(testTableName)
+	r.NoError(err)
+	r.Equal(uint64(2048), tab.Options.MaxValueSize)
+	tabs, err := tm.GetTables()
+	r.NoError(err)
+	r.Equal(opts, tabs[0].Options)
+
+	r.ErrorIs(tm.UpdateTableOptions("missing", opts), serrors.ErrTableNotFound)
+	r.ErrorIs(tm.UpdateTableOptions(testTableName, Options{BlockCacheSize: -1}), serrors.ErrInvalidTableOptions)
+}
+
 func TestManager_DeleteTable(t *testing.T) {
 	const testTableName = "test"
 	r := require.New(t)
@@ -84,6 +128,7 @@ func TestManager_DeleteTable(t *testing.T) {
 	t.Log("delete non-existent table")
 	_, err = tm.GetTable("foo")
 	r.ErrorIs(err, serrors.ErrTableNotFound)
+	r.ErrorIs(tm.DeleteTable("foo"), serrors.ErrTableNotFound)
 	r.NoError(tm.cleanup())
 
 	// LogDB cleaned
@@ -270,7 +315,7 @@ func TestManager_reconcile(t *testing.T) {
 	r.NoError(tm.Start())
 	defer tm.Close()
 	r.NoError(tm.WaitUntilReady())
-	_, err := tm.createTable(testTableName)
+	_, err := tm.createTable(testTableName, Options{})
 	r.NoError(err)
 	time.Sleep(reconcileInterval * 3)
 
@@ -0,0 +1,159 @@
+// Copyright JAMF Software, LLC
+
+package table
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/jamf/regatta/regattapb"
+	serrors "github.com/jamf/regatta/storage/errors"
+	"github.com/jamf/regatta/storage/table/fsm"
+)
+
+// Compression of the table data blocks.
+type Compression string
+
+const (
+	// CompressionDefault uses the default Snappy compression.
+	CompressionDefault Compression = ""
+	CompressionNone    Compression = "none"
+	CompressionSnappy  Compression = "snappy"
+	CompressionZstd    Compression = "zstd"
+)
+
+// Options are the per-table settings stored in the table metadata. Zero values fall back
+// to the global TableConfig and defaults.
+type Options struct {
+	// MaxValueSize is the maximum size of a single value in bytes, MaxValueLen is used if zero.
+	MaxValueSize uint64 `json:"max_value_size,omitempty"`
+	// SnapshotEntries defines how often the table should be snapshotted in terms of the number of applied
+	// Raft log entries, TableConfig.SnapshotEntries is used if zero.
+	SnapshotEntries uint64 `json:"snapshot_entries,omitempty"`
+	// CompactionOverhead defines the number of most recent entries to keep after each Raft log compaction,
+	// TableConfig.CompactionOverhead is used if zero.
+	CompactionOverhead uint64 `json:"compaction_overhead,omitempty"`
+	// Compression of the table data blocks, Snappy is used if empty.
+	Compression Compression `json:"compression,omitempty"`
+	// BlockCacheSize is the size of the block cache dedicated to the table in bytes,
+	// the block cache shared by all the tables is used if zero.
+	BlockCacheSize int64 `json:"block_cache_size,omitempty"`
+	// RecoveryType is the in-cluster snapshot recovery type, TableConfig.RecoveryType is used if nil.
+	RecoveryType *SnapshotRecoveryType `json:"recovery_type,omitempty"`
+}
+
+// Validate checks that the options are valid.
+func (o Options) Validate() error {
+	switch o.Compression {
+	case CompressionDefault, CompressionNone, CompressionSnappy, CompressionZstd:
+	default:
+		return fmt.Errorf("%w: unknown compression %q", serrors.ErrInvalidTableOptions, o.Compression)
+	}
+	if o.MaxValueSize > fsm.MaxValueSize {
+		return fmt.Errorf("%w: max value size exceeds %d", serrors.ErrInvalidTableOptions, fsm.MaxValueSize)
+	}
+	if o.BlockCacheSize < 0 {
+		return fmt.Errorf("%w: negative block cache size", serrors.ErrInvalidTableOptions)
+	}
+	if o.RecoveryType != nil && *o.RecoveryType != RecoveryTypeSnapshot && *o.RecoveryType != RecoveryTypeCheckpoint {
+		return fmt.Errorf("%w: unknown recovery type %d", serrors.ErrInvalidTableOptions, *o.RecoveryType)
+	}
+	return nil
+}
+
+func (o Options) maxValueSize() int {
+	if o.MaxValueSize == 0 {
+		return MaxValueLen
+	}
+	return int(o.MaxValueSize)
+}
+
+func (o Options) compression() pebble.Compression {
+	switch o.Compression {
+	case CompressionNone:
+		return pebble.NoCompression
+	case CompressionZstd:
+		return pebble.ZstdCompression
+	default:
+		return pebble.SnappyCompression
+	}
+}
+
+func (o Options) recoveryType(cfg TableConfig) fsm.SnapshotRecoveryType {
+	if o.RecoveryType == nil {
+		return fsm.SnapshotRecoveryType(cfg.RecoveryType)
+	}
+	return fsm.SnapshotRecoveryType(*o.RecoveryType)
+}
+
+// tableConfig returns the global table configuration overridden by the options.
+func (o Options) tableConfig(cfg TableConfig) TableConfig {
+	if o.SnapshotEntries != 0 {
+		cfg.SnapshotEntries = o.SnapshotEntries
+	}
+	if o.CompactionOverhead != 0 {
+		cfg.CompactionOverhead = o.CompactionOverhead
+	}
+	return cfg
+}
+
+// OptionsFromProto returns the options of the protobuf message, the unknown values are kept to fail the validation.
+func OptionsFromProto(opts *regattapb.TableOptions) Options {
+	res := Options{
+		MaxValueSize:       opts.GetMaxValueSize(),
+		SnapshotEntries:    opts.GetSnapshotEntries(),
+		CompactionOverhead: opts.GetCompactionOverhead(),
+		BlockCacheSize:     opts.GetBlockCacheSize(),
+	}
+	switch opts.GetCompression() {
+	case regattapb.TableOptions_DEFAULT_COMPRESSION:
+	case regattapb.TableOptions_NONE:
+		res.Compression = CompressionNone
+	case regattapb.TableOptions_SNAPPY:
+		res.Compression = CompressionSnappy
+	case regattapb.TableOptions_ZSTD:
+		res.Compression = CompressionZstd
+	default:
+		res.Compression = Compression(opts.GetCompression().String())
+	}
+	switch opts.GetRecoveryType() {
+	case regattapb.TableOptions_DEFAULT_RECOVERY:
+	case regattapb.TableOptions_SNAPSHOT:
+		rt := RecoveryTypeSnapshot
+		res.RecoveryType = &rt
+	case regattapb.TableOptions_CHECKPOINT:
+		rt := RecoveryTypeCheckpoint
+		res.RecoveryType = &rt
+	default:
+		rt := SnapshotRecoveryType(opts.GetRecoveryType())
+		res.RecoveryType = &rt
+	}
+	return res
+}
+
+// OptionsToProto returns the protobuf message of the options.
+func OptionsToProto(opts Options) *regattapb.TableOptions {
+	res := &regattapb.TableOptions{
+		MaxValueSize:       opts.MaxValueSize,
+		SnapshotEntries:    opts.SnapshotEntries,
+		CompactionOverhead: opts.CompactionOverhead,
+		BlockCacheSize:     opts.BlockCacheSize,
+	}
+	switch opts.Compression {
+	case CompressionNone:
+		res.Compression = regattapb.TableOptions_NONE
+	case CompressionSnappy:
+		res.Compression = regattapb.TableOptions_SNAPPY
+	case CompressionZstd:
+		res.Compression = regattapb.TableOptions_ZSTD
+	}
+	if opts.RecoveryType != nil {
+		switch *opts.RecoveryType {
+		case RecoveryTypeSnapshot:
+			res.RecoveryType = regattapb.TableOptions_SNAPSHOT
+		case RecoveryTypeCheckpoint:
+			res.RecoveryType = regattapb.TableOptions_CHECKPOINT
+		}
+	}
+	return res
+}
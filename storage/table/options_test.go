@@ -0,0 +1,82 @@
+// Copyright JAMF Software, LLC
+
+package table
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/jamf/regatta/regattapb"
+	serrors "github.com/jamf/regatta/storage/errors"
+	"github.com/jamf/regatta/storage/table/fsm"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptions_Validate(t *testing.T) {
+	invalidRecovery := SnapshotRecoveryType(10)
+	checkpoint := RecoveryTypeCheckpoint
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr bool
+	}{
+		{name: "defaults", opts: Options{}},
+		{name: "all set", opts: Options{MaxValueSize: 1024, SnapshotEntries: 10, CompactionOverhead: 5, Compression: CompressionZstd, BlockCacheSize: 1024, RecoveryType: &checkpoint}},
+		{name: "unknown compression", opts: Options{Compression: "lz4"}, wantErr: true},
+		{name: "max value size limit", opts: Options{MaxValueSize: fsm.MaxValueSize}},
+		{name: "max value size over limit", opts: Options{MaxValueSize: fsm.MaxValueSize + 1}, wantErr: true},
+		{name: "negative block cache size", opts: Options{BlockCacheSize: -1}, wantErr: true},
+		{name: "unknown recovery type", opts: Options{RecoveryType: &invalidRecovery}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Validate()
+			if tt.wantErr {
+				require.ErrorIs(t, err, serrors.ErrInvalidTableOptions)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestOptions_Defaults(t *testing.T) {
+	r := require.New(t)
+	cfg := TableConfig{SnapshotEntries: 1000, CompactionOverhead: 100, RecoveryType: RecoveryTypeCheckpoint}
+
+	r.Equal(cfg, Options{}.tableConfig(cfg))
+	r.Equal(MaxValueLen, Options{}.maxValueSize())
+	r.Equal(pebble.SnappyCompression, Options{}.compression())
+	r.Equal(fsm.RecoveryTypeCheckpoint, Options{}.recoveryType(cfg))
+
+	snapshot := RecoveryTypeSnapshot
+	opts := Options{MaxValueSize: 10, SnapshotEntries: 50, Compression: CompressionNone, RecoveryType: &snapshot}
+	r.Equal(TableConfig{SnapshotEntries: 50, CompactionOverhead: 100, RecoveryType: RecoveryTypeCheckpoint}, opts.tableConfig(cfg))
+	r.Equal(10, opts.maxValueSize())
+	r.Equal(pebble.NoCompression, opts.compression())
+	r.Equal(fsm.RecoveryTypeSnapshot, opts.recoveryType(cfg))
+}
+
+func TestActiveTable_PutMaxValueSize(t *testing.T) {
+	at := &ActiveTable{Table: Table{Options: Options{MaxValueSize: 2}}, nh: &mockRaftHandler{}}
+	_, err := at.Put(context.TODO(), &regattapb.PutRequest{Key: []byte("foo"), Value: []byte("bar")})
+	require.ErrorIs(t, err, serrors.ErrValueLengthExceeded)
+}
+
+func TestOptionsFromProto(t *testing.T) {
+	r := require.New(t)
+	checkpoint := RecoveryTypeCheckpoint
+	opts := Options{
+		MaxValueSize:       1024,
+		SnapshotEntries:    10,
+		CompactionOverhead: 5,
+		Compression:        CompressionZstd,
+		BlockCacheSize:     2048,
+		RecoveryType:       &checkpoint,
+	}
+	r.Equal(opts, OptionsFromProto(OptionsToProto(opts)))
+	r.Equal(Options{}, OptionsFromProto(nil))
+	r.Equal(&regattapb.TableOptions{}, OptionsToProto(Options{}))
+	r.Error(OptionsFromProto(&regattapb.TableOptions{Compression: 10}).Validate())
+}
@@ -4,12 +4,17 @@ package table
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
 	"io"
+	"time"
 
 	"github.com/jamf/regatta/regattapb"
 	serrors "github.com/jamf/regatta/storage/errors"
 	"github.com/jamf/regatta/storage/table/fsm"
 	"github.com/jamf/regatta/storage/table/key"
+	"github.com/jamf/regatta/tracing"
 	"github.com/lni/dragonboat/v4/client"
 	sm "github.com/lni/dragonboat/v4/statemachine"
 )
@@ -19,16 +24,34 @@ type raftHandler interface {
 	StaleRead(id uint64, req interface{}) (interface{}, error)
 	SyncPropose(ctx context.Context, session *client.Session, bytes []byte) (sm.Result, error)
 	GetNoOPSession(id uint64) *client.Session
+	GetLeaderID(id uint64) (uint64, uint64, bool, error)
 }
 
-// MaxValueLen 2MB max value.
-const MaxValueLen = 2 * 1024 * 1024
+const (
+	// MaxValueLen 2MB max value.
+	MaxValueLen = 2 * 1024 * 1024
+	// cursorReadTimeout timeout of the linearizable read preceding the cursor if the context has no deadline set.
+	cursorReadTimeout = 5 * time.Second
+	// stalenessWaitTimeout how long does the bounded-staleness read wait for the replica to catch up.
+	stalenessWaitTimeout = 500 * time.Millisecond
+	// stalenessPollInterval how often is the heartbeat of the replica checked while waiting for it to catch up.
+	stalenessPollInterval = 10 * time.Millisecond
+	// revisionPollInterval how often is the revision of the replica checked while waiting for the min revision of the read.
+	revisionPollInterval = 10 * time.Millisecond
+	// MaxLargeValueLen 1GB max large value, see ActiveTable.PutLarge.
+	MaxLargeValueLen = 1024 * 1024 * 1024
+	// largeValueChunkSize size of the chunks the large value is split into, each chunk is proposed separately.
+	largeValueChunkSize = 1024 * 1024
+	// largeProposeTimeout timeout of every proposal of the large value if the context has no deadline set.
+	largeProposeTimeout = 5 * time.Second
+)
 
 // Table stored representation of a table.
 type Table struct {
-	Name      string `json:"name"`
-	ClusterID uint64 `json:"cluster_id"`
-	RecoverID uint64 `json:"recover_id"`
+	Name      string  `json:"name"`
+	ClusterID uint64  `json:"cluster_id"`
+	RecoverID uint64  `json:"recover_id"`
+	Options   Options `json:"options"`
 }
 
 // AsActive returns ActiveTable wrapper of this table.
@@ -45,31 +68,70 @@ type ActiveTable struct {
 
 func readTable[S any](t *ActiveTable, ctx context.Context, linearizable bool, req any) (S, error) {
 	var (
-		err error
-		val interface{}
+		err  error
+		val  interface{}
+		span *tracing.Span
 	)
+	if linearizable {
+		ctx, span = tracing.Start(ctx, "raft.SyncRead", tracing.Attr("table", t.Name))
+	} else {
+		ctx, span = tracing.Start(ctx, "raft.StaleRead", tracing.Attr("table", t.Name))
+	}
+	defer span.End()
+	if span.IsRecording() {
+		// The lookup of the state machine is traced as a part of the read.
+		req = fsm.TracedRequest{Context: ctx, Request: req}
+	}
 	if linearizable {
 		val, err = t.nh.SyncRead(ctx, t.ClusterID, req)
 	} else {
 		val, err = t.nh.StaleRead(t.ClusterID, req)
 	}
 	if err != nil {
+		span.SetError(err)
 		return *new(S), err
 	}
 	return val.(S), nil
 }
 
-func proposeTable[S any](t *ActiveTable, ctx context.Context, cmd *regattapb.Command) (S, uint64, error) {
+// propose proposes the command into the Raft and returns the result, serrors.ErrLeaseNotFound is returned
+// if the command was not applied because of a missing lease, serrors.ErrValueNotInteger or serrors.ErrValueOutOfBounds
+// if the command was not applied because of a failed increment, serrors.ErrRequestIDReused if the request ID of the command
+// was already used by a different command.
+func propose(t *ActiveTable, ctx context.Context, cmd *regattapb.Command) (fsm.UpdateResult, *regattapb.CommandResult, error) {
+	ctx, span := tracing.Start(ctx, "raft.SyncPropose", tracing.Attr("table", t.Name), tracing.Attr("command", cmd.Type.String()))
+	defer span.End()
+	// The state machine records the span of applying the command as a part of the trace.
+	cmd.Traceparent = span.Context().Traceparent()
 	bytes, err := cmd.MarshalVT()
 	if err != nil {
-		return *new(S), 0, err
+		return fsm.ResultFailure, nil, err
 	}
 	res, err := t.nh.SyncPropose(ctx, t.session, bytes)
 	if err != nil {
-		return *new(S), 0, err
+		span.SetError(err)
+		return fsm.ResultFailure, nil, err
+	}
+	switch result := fsm.UpdateResult(res.Value); result {
+	case fsm.ResultLeaseNotFound:
+		return result, nil, serrors.ErrLeaseNotFound
+	case fsm.ResultValueNotInteger:
+		return result, nil, serrors.ErrValueNotInteger
+	case fsm.ResultValueOutOfBounds:
+		return result, nil, serrors.ErrValueOutOfBounds
+	case fsm.ResultRequestMismatch:
+		return result, nil, serrors.ErrRequestIDReused
 	}
 	pr := &regattapb.CommandResult{}
 	if err := pr.UnmarshalVT(res.Data); err != nil {
+		return fsm.ResultFailure, nil, err
+	}
+	return fsm.UpdateResult(res.Value), pr, nil
+}
+
+func proposeTable[S any](t *ActiveTable, ctx context.Context, cmd *regattapb.Command) (S, uint64, error) {
+	_, pr, err := propose(t, ctx, cmd)
+	if err != nil {
 		return *new(S), 0, err
 	}
 	if len(pr.Responses) == 0 {
@@ -85,39 +147,180 @@ func proposeTable[S any](t *ActiveTable, ctx context.Context, cmd *regattapb.Com
 
 // Range performs a Range query in the Raft data, supplied context must have a deadline set.
 func (t *ActiveTable) Range(ctx context.Context, req *regattapb.RangeRequest) (*regattapb.RangeResponse, error) {
+	ctx, span := tracing.Start(ctx, "ActiveTable.Range", tracing.Attr("table", t.Name))
+	defer span.End()
 	if len(req.Key) > key.LatestVersionLen {
 		return nil, serrors.ErrKeyLengthExceeded
 	}
 	if len(req.RangeEnd) > key.LatestVersionLen {
 		return nil, serrors.ErrKeyLengthExceeded
 	}
+	linearizable := req.Linearizable
+	if req.MinRevision > 0 {
+		if err := t.waitRevision(ctx, uint64(req.MinRevision)); err != nil {
+			return nil, err
+		}
+		// The replica has applied the requested revision, the read is served locally.
+		linearizable = false
+	}
+	if !linearizable && req.MaxStalenessMs > 0 {
+		if err := t.waitStaleness(ctx, time.Duration(req.MaxStalenessMs)*time.Millisecond); err != nil {
+			return nil, err
+		}
+	}
 
-	response, err := readTable[*regattapb.ResponseOp_Range](t, ctx, req.Linearizable, &regattapb.RequestOp_Range{
-		Key:       req.Key,
-		RangeEnd:  req.RangeEnd,
-		Limit:     req.Limit,
-		KeysOnly:  req.KeysOnly,
-		CountOnly: req.CountOnly,
+	response, err := readTable[*fsm.RangeResponse](t, ctx, linearizable, fsm.RangeRequest{
+		Range: &regattapb.RequestOp_Range{
+			Key:               req.Key,
+			RangeEnd:          req.RangeEnd,
+			Limit:             req.Limit,
+			KeysOnly:          req.KeysOnly,
+			CountOnly:         req.CountOnly,
+			MinModRevision:    req.MinModRevision,
+			MaxModRevision:    req.MaxModRevision,
+			MinCreateRevision: req.MinCreateRevision,
+			MaxCreateRevision: req.MaxCreateRevision,
+			Revision:          req.Revision,
+			Filter:            req.Filter,
+			SortOrder:         req.SortOrder,
+		},
 	})
 	if err != nil {
 		return nil, err
 	}
 	return &regattapb.RangeResponse{
-		Kvs:   response.Kvs,
-		Count: response.Count,
-		More:  response.More,
+		Header: &regattapb.ResponseHeader{Revision: response.Revision},
+		Kvs:    response.Range.Kvs,
+		Count:  response.Range.Count,
+		More:   response.Range.More,
 	}, nil
 }
 
+// Cursor streams the range read from a single snapshot of the table in batches into the send function.
+// The stream is not bound by the context deadline, it is stopped only once the context is done.
+func (t *ActiveTable) Cursor(ctx context.Context, req *regattapb.RangeRequest, send func(*regattapb.RangeResponse) error) error {
+	ctx, span := tracing.Start(ctx, "ActiveTable.Cursor", tracing.Attr("table", t.Name))
+	defer span.End()
+	if len(req.Key) > key.LatestVersionLen {
+		return serrors.ErrKeyLengthExceeded
+	}
+	if len(req.RangeEnd) > key.LatestVersionLen {
+		return serrors.ErrKeyLengthExceeded
+	}
+
+	if req.MinRevision > 0 || req.Linearizable {
+		// Wait for the table to catch up with the cluster (or the requested revision), the snapshot is then read locally.
+		rctx := ctx
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			rctx, cancel = context.WithTimeout(ctx, cursorReadTimeout)
+			defer cancel()
+		}
+		if req.MinRevision > 0 {
+			if err := t.waitRevision(rctx, uint64(req.MinRevision)); err != nil {
+				return err
+			}
+		} else if _, err := t.LocalIndex(rctx, true); err != nil {
+			return err
+		}
+	}
+	if !req.Linearizable && req.MaxStalenessMs > 0 {
+		if err := t.waitStaleness(ctx, time.Duration(req.MaxStalenessMs)*time.Millisecond); err != nil {
+			return err
+		}
+	}
+
+	_, err := readTable[*fsm.CursorResponse](t, ctx, false, fsm.CursorRequest{
+		Range: &regattapb.RequestOp_Range{
+			Key:               req.Key,
+			RangeEnd:          req.RangeEnd,
+			Limit:             req.Limit,
+			KeysOnly:          req.KeysOnly,
+			CountOnly:         req.CountOnly,
+			MinModRevision:    req.MinModRevision,
+			MaxModRevision:    req.MaxModRevision,
+			MinCreateRevision: req.MinCreateRevision,
+			MaxCreateRevision: req.MaxCreateRevision,
+			Revision:          req.Revision,
+			Filter:            req.Filter,
+			SortOrder:         req.SortOrder,
+		},
+		BatchSize: int(req.BatchSize),
+		Send: func(response *regattapb.ResponseOp_Range, revision uint64) error {
+			return send(&regattapb.RangeResponse{
+				Header: &regattapb.ResponseHeader{Revision: revision},
+				Kvs:    response.Kvs,
+				Count:  response.Count,
+				More:   response.More,
+			})
+		},
+		Stopper: ctx.Done(),
+	})
+	return err
+}
+
+// waitRevision waits until the replica applies the revision (the leader index for the replicated tables),
+// serrors.ErrRevisionNotApplied is returned if the context is done before.
+func (t *ActiveTable) waitRevision(ctx context.Context, revision uint64) error {
+	ticker := time.NewTicker(revisionPollInterval)
+	defer ticker.Stop()
+	for {
+		res, err := t.Revision(ctx, false)
+		if err != nil {
+			return err
+		}
+		if res.Revision >= revision {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return serrors.ErrRevisionNotApplied
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitStaleness waits until the replica has applied a heartbeat at most maxStaleness old,
+// serrors.ErrReplicaStale is returned if the replica does not catch up within stalenessWaitTimeout.
+func (t *ActiveTable) waitStaleness(ctx context.Context, maxStaleness time.Duration) error {
+	timeout := time.NewTimer(stalenessWaitTimeout)
+	defer timeout.Stop()
+	ticker := time.NewTicker(stalenessPollInterval)
+	defer ticker.Stop()
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		hb, err := t.LastHeartbeat(ctx)
+		if err != nil {
+			return err
+		}
+		if hb.Timestamp != 0 && time.Since(time.Unix(0, hb.Timestamp)) <= maxStaleness {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timeout.C:
+			return serrors.ErrReplicaStale
+		case <-ticker.C:
+		}
+	}
+}
+
 // Put performs a Put proposal into the Raft, supplied context must have a deadline set.
 func (t *ActiveTable) Put(ctx context.Context, req *regattapb.PutRequest) (*regattapb.PutResponse, error) {
+	ctx, span := tracing.Start(ctx, "ActiveTable.Put", tracing.Attr("table", t.Name))
+	defer span.End()
 	if len(req.Key) == 0 {
 		return nil, serrors.ErrEmptyKey
 	}
 	if len(req.Key) > key.LatestVersionLen {
 		return nil, serrors.ErrKeyLengthExceeded
 	}
-	if len(req.Value) > MaxValueLen {
+	if len(req.Value) > t.Options.maxValueSize() {
 		return nil, serrors.ErrValueLengthExceeded
 	}
 	cmd := &regattapb.Command{
@@ -126,8 +329,10 @@ func (t *ActiveTable) Put(ctx context.Context, req *regattapb.PutRequest) (*rega
 		Kv: &regattapb.KeyValue{
 			Key:   req.Key,
 			Value: req.Value,
+			Lease: req.Lease,
 		},
-		PrevKvs: req.PrevKv,
+		PrevKvs:   req.PrevKv,
+		RequestId: req.RequestId,
 	}
 	r, rev, err := proposeTable[*regattapb.ResponseOp_ResponsePut](t, ctx, cmd)
 	if err != nil {
@@ -136,8 +341,188 @@ func (t *ActiveTable) Put(ctx context.Context, req *regattapb.PutRequest) (*rega
 	return &regattapb.PutResponse{PrevKv: r.ResponsePut.PrevKv, Header: &regattapb.ResponseHeader{Revision: rev}}, nil
 }
 
+// PutLarge stores the value read from the reader until io.EOF as the large value. The value is split into chunks proposed
+// one by one and the key is set once all the chunks are applied, so the value becomes visible atomically. The chunks of an
+// unfinished large value are discarded (on the best effort basis) if the value could not be read or proposed.
+func (t *ActiveTable) PutLarge(ctx context.Context, req *regattapb.PutLargeRequest, value io.Reader) (*regattapb.PutLargeResponse, error) {
+	ctx, span := tracing.Start(ctx, "ActiveTable.PutLarge", tracing.Attr("table", t.Name))
+	defer span.End()
+	if len(req.Key) == 0 {
+		return nil, serrors.ErrEmptyKey
+	}
+	if len(req.Key) > key.LatestVersionLen {
+		return nil, serrors.ErrKeyLengthExceeded
+	}
+	id, err := largeValueID()
+	if err != nil {
+		return nil, err
+	}
+
+	large := &regattapb.LargeValue{Id: id}
+	buf := make([]byte, largeValueChunkSize)
+	for {
+		n, err := io.ReadFull(value, buf)
+		if n > 0 {
+			if large.Size+uint64(n) > MaxLargeValueLen {
+				t.discardChunks(ctx, id)
+				return nil, serrors.ErrValueLengthExceeded
+			}
+			cmd := &regattapb.Command{
+				Type:  regattapb.Command_PUT_CHUNK,
+				Table: req.Table,
+				Chunk: &regattapb.Chunk{Id: id, Index: large.Chunks, Data: buf[:n]},
+			}
+			if _, _, err := proposeLarge(t, ctx, cmd); err != nil {
+				t.discardChunks(ctx, id)
+				return nil, err
+			}
+			large.Chunks++
+			large.Size += uint64(n)
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			t.discardChunks(ctx, id)
+			return nil, err
+		}
+	}
+
+	cmd := &regattapb.Command{
+		Type:  regattapb.Command_PUT_LARGE,
+		Table: req.Table,
+		Kv: &regattapb.KeyValue{
+			Key:   req.Key,
+			Lease: req.Lease,
+		},
+		LargeValue: large,
+	}
+	// The chunks are not discarded if the outcome of the proposal is not known, the key might reference them.
+	// The FSM discards the chunks itself if the lease does not exist.
+	_, pr, err := proposeLarge(t, ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	return &regattapb.PutLargeResponse{Size: large.Size, Header: &regattapb.ResponseHeader{Revision: pr.Revision}}, nil
+}
+
+// proposeLarge proposes the command of the large value, the default timeout is applied if the context has no deadline set.
+func proposeLarge(t *ActiveTable, ctx context.Context, cmd *regattapb.Command) (fsm.UpdateResult, *regattapb.CommandResult, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, largeProposeTimeout)
+		defer cancel()
+	}
+	return propose(t, ctx, cmd)
+}
+
+// discardChunks discards the chunks of the unfinished large value, the discarding is not bound to the context.
+func (t *ActiveTable) discardChunks(ctx context.Context, id uint64) {
+	dctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), largeProposeTimeout)
+	defer cancel()
+	_, _, _ = propose(t, dctx, &regattapb.Command{
+		Type:       regattapb.Command_DISCARD_CHUNKS,
+		Table:      []byte(t.Name),
+		LargeValue: &regattapb.LargeValue{Id: id},
+	})
+}
+
+// largeValueID generates a random non-zero ID of the large value.
+func largeValueID() (uint64, error) {
+	var b [8]byte
+	for {
+		if _, err := rand.Read(b[:]); err != nil {
+			return 0, err
+		}
+		if id := binary.BigEndian.Uint64(b[:]); id != 0 {
+			return id, nil
+		}
+	}
+}
+
+// GetLarge streams the value of the key read from a single snapshot of the table in parts into the send function,
+// the header and the key-value pair (without the value) are set only in the first response. serrors.ErrKeyNotFound
+// is returned if the key does not exist. The stream is not bound by the context deadline, it is stopped only once the context is done.
+func (t *ActiveTable) GetLarge(ctx context.Context, req *regattapb.GetLargeRequest, send func(*regattapb.GetLargeResponse) error) error {
+	ctx, span := tracing.Start(ctx, "ActiveTable.GetLarge", tracing.Attr("table", t.Name))
+	defer span.End()
+	if len(req.Key) == 0 {
+		return serrors.ErrEmptyKey
+	}
+	if len(req.Key) > key.LatestVersionLen {
+		return serrors.ErrKeyLengthExceeded
+	}
+
+	if req.Linearizable {
+		// Wait for the table to catch up with the cluster, the snapshot is then read locally.
+		rctx := ctx
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			rctx, cancel = context.WithTimeout(ctx, cursorReadTimeout)
+			defer cancel()
+		}
+		if _, err := t.LocalIndex(rctx, true); err != nil {
+			return err
+		}
+	}
+
+	res, err := readTable[*fsm.LargeValueResponse](t, ctx, false, fsm.LargeValueRequest{
+		Key:      req.Key,
+		Revision: req.Revision,
+		Send: func(kv *regattapb.KeyValue, part []byte, revision uint64) error {
+			response := &regattapb.GetLargeResponse{Value: part}
+			if kv != nil {
+				response.Header = &regattapb.ResponseHeader{Revision: revision}
+				response.Kv = kv
+			}
+			return send(response)
+		},
+		Stopper: ctx.Done(),
+	})
+	if err != nil {
+		return err
+	}
+	if !res.Found {
+		return serrors.ErrKeyNotFound
+	}
+	return nil
+}
+
+// Increment performs an Increment proposal into the Raft, supplied context must have a deadline set.
+func (t *ActiveTable) Increment(ctx context.Context, req *regattapb.IncrementRequest) (*regattapb.IncrementResponse, error) {
+	ctx, span := tracing.Start(ctx, "ActiveTable.Increment", tracing.Attr("table", t.Name))
+	defer span.End()
+	if len(req.Key) == 0 {
+		return nil, serrors.ErrEmptyKey
+	}
+	if len(req.Key) > key.LatestVersionLen {
+		return nil, serrors.ErrKeyLengthExceeded
+	}
+	cmd := &regattapb.Command{
+		Type:  regattapb.Command_TXN,
+		Table: req.Table,
+		Txn: &regattapb.Txn{
+			Success: []*regattapb.RequestOp{{Request: &regattapb.RequestOp_RequestIncrement{RequestIncrement: &regattapb.RequestOp_Increment{
+				Key:     req.Key,
+				Delta:   req.Delta,
+				Initial: req.Initial,
+				Min:     req.Min,
+				Max:     req.Max,
+			}}}},
+		},
+		RequestId: req.RequestId,
+	}
+	r, rev, err := proposeTable[*regattapb.ResponseOp_ResponseIncrement](t, ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	return &regattapb.IncrementResponse{Value: r.ResponseIncrement.Value, Header: &regattapb.ResponseHeader{Revision: rev}}, nil
+}
+
 // Delete performs a DeleteRange proposal into the Raft, supplied context must have a deadline set.
 func (t *ActiveTable) Delete(ctx context.Context, req *regattapb.DeleteRangeRequest) (*regattapb.DeleteRangeResponse, error) {
+	ctx, span := tracing.Start(ctx, "ActiveTable.Delete", tracing.Attr("table", t.Name))
+	defer span.End()
 	if len(req.Key) == 0 {
 		return nil, serrors.ErrEmptyKey
 	}
@@ -150,9 +535,10 @@ func (t *ActiveTable) Delete(ctx context.Context, req *regattapb.DeleteRangeRequ
 		Kv: &regattapb.KeyValue{
 			Key: req.Key,
 		},
-		PrevKvs:  req.PrevKv,
-		RangeEnd: req.RangeEnd,
-		Count:    req.Count,
+		PrevKvs:   req.PrevKv,
+		RangeEnd:  req.RangeEnd,
+		Count:     req.Count,
+		RequestId: req.RequestId,
 	}
 	r, rev, err := proposeTable[*regattapb.ResponseOp_ResponseDeleteRange](t, ctx, cmd)
 	if err != nil {
@@ -162,10 +548,21 @@ func (t *ActiveTable) Delete(ctx context.Context, req *regattapb.DeleteRangeRequ
 }
 
 func (t *ActiveTable) Txn(ctx context.Context, req *regattapb.TxnRequest) (*regattapb.TxnResponse, error) {
+	ctx, span := tracing.Start(ctx, "ActiveTable.Txn", tracing.Attr("table", t.Name))
+	defer span.End()
 	// Do not propose read-only transactions through the log
 	if isReadonlyTransaction(req) {
+		if req.MinRevision > 0 {
+			if err := t.waitRevision(ctx, uint64(req.MinRevision)); err != nil {
+				return nil, err
+			}
+			return readTable[*regattapb.TxnResponse](t, ctx, false, req)
+		}
 		return readTable[*regattapb.TxnResponse](t, ctx, true, req)
 	}
+	if !valuesFit(req.Success, t.Options.maxValueSize()) || !valuesFit(req.Failure, t.Options.maxValueSize()) {
+		return nil, serrors.ErrValueLengthExceeded
+	}
 
 	cmd := &regattapb.Command{
 		Type:  regattapb.Command_TXN,
@@ -175,47 +572,216 @@ func (t *ActiveTable) Txn(ctx context.Context, req *regattapb.TxnRequest) (*rega
 			Success: req.Success,
 			Failure: req.Failure,
 		},
+		RequestId: req.RequestId,
 	}
 
-	bytes, err := cmd.MarshalVT()
+	result, txr, err := propose(t, ctx, cmd)
 	if err != nil {
 		return nil, err
 	}
-	res, err := t.nh.SyncPropose(ctx, t.session, bytes)
-	if err != nil {
-		return nil, err
-	}
-	txr := &regattapb.CommandResult{}
-	if err := txr.UnmarshalVT(res.Data); err != nil {
-		return nil, err
-	}
 	return &regattapb.TxnResponse{
-		Succeeded: fsm.UpdateResult(res.Value) == fsm.ResultSuccess,
+		Succeeded: result == fsm.ResultSuccess,
 		Responses: txr.Responses,
 		Header:    &regattapb.ResponseHeader{Revision: txr.Revision},
 	}, nil
 }
 
 func isReadonlyTransaction(req *regattapb.TxnRequest) bool {
-	for _, op := range req.Success {
-		if _, ok := op.Request.(*regattapb.RequestOp_RequestRange); !ok {
+	return isReadonlyOps(req.Success) && isReadonlyOps(req.Failure)
+}
+
+// isReadonlyOps reports whether all the operations are ranges, the nested transactions are read-only if their operations are.
+func isReadonlyOps(ops []*regattapb.RequestOp) bool {
+	for _, op := range ops {
+		switch o := op.Request.(type) {
+		case *regattapb.RequestOp_RequestRange:
+		case *regattapb.RequestOp_RequestTxn:
+			if !isReadonlyOps(o.RequestTxn.Success) || !isReadonlyOps(o.RequestTxn.Failure) {
+				return false
+			}
+		default:
 			return false
 		}
 	}
+	return true
+}
 
-	for _, op := range req.Failure {
-		if _, ok := op.Request.(*regattapb.RequestOp_RequestRange); !ok {
-			return false
+// valuesFit reports whether all the values put by the operations, including the nested transactions, are at most maxSize long.
+func valuesFit(ops []*regattapb.RequestOp, maxSize int) bool {
+	for _, op := range ops {
+		switch o := op.Request.(type) {
+		case *regattapb.RequestOp_RequestPut:
+			if len(o.RequestPut.Value) > maxSize {
+				return false
+			}
+		case *regattapb.RequestOp_RequestTxn:
+			if !valuesFit(o.RequestTxn.Success, maxSize) || !valuesFit(o.RequestTxn.Failure, maxSize) {
+				return false
+			}
 		}
 	}
 	return true
 }
 
+// LeaseGrant performs a LeaseGrant proposal into the Raft, supplied context must have a deadline set.
+func (t *ActiveTable) LeaseGrant(ctx context.Context, req *regattapb.LeaseGrantRequest) (*regattapb.LeaseGrantResponse, error) {
+	ctx, span := tracing.Start(ctx, "ActiveTable.LeaseGrant", tracing.Attr("table", t.Name))
+	defer span.End()
+	cmd := &regattapb.Command{
+		Type:      regattapb.Command_LEASE_GRANT,
+		Table:     req.Table,
+		Lease:     &regattapb.Lease{ID: req.ID, TTL: req.TTL},
+		Timestamp: time.Now().UnixNano(),
+	}
+	result, res, err := propose(t, ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	if result != fsm.ResultSuccess {
+		return nil, serrors.ErrLeaseExists
+	}
+	return &regattapb.LeaseGrantResponse{ID: res.Lease.ID, TTL: res.Lease.TTL, Header: &regattapb.ResponseHeader{Revision: res.Revision}}, nil
+}
+
+// LeaseRevoke performs a LeaseRevoke proposal into the Raft, supplied context must have a deadline set.
+// The keys attached to the lease are read first and proposed together with the revocation so that the deletion of every key
+// is recorded in the log, the revocation is retried until the context is done if the attached keys changed in the meantime.
+func (t *ActiveTable) LeaseRevoke(ctx context.Context, req *regattapb.LeaseRevokeRequest) (*regattapb.LeaseRevokeResponse, error) {
+	ctx, span := tracing.Start(ctx, "ActiveTable.LeaseRevoke", tracing.Attr("table", t.Name))
+	defer span.End()
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		lease, err := readTable[*fsm.LeaseResponse](t, ctx, true, fsm.LeaseRequest{ID: req.ID, Keys: true})
+		if err != nil {
+			return nil, err
+		}
+		cmd := &regattapb.Command{
+			Type:  regattapb.Command_LEASE_REVOKE,
+			Table: req.Table,
+			Lease: &regattapb.Lease{ID: req.ID},
+		}
+		for _, k := range lease.Keys {
+			cmd.Batch = append(cmd.Batch, &regattapb.KeyValue{Key: k})
+		}
+		result, res, err := propose(t, ctx, cmd)
+		if err != nil {
+			return nil, err
+		}
+		if result == fsm.ResultSuccess {
+			return &regattapb.LeaseRevokeResponse{Header: &regattapb.ResponseHeader{Revision: res.Revision}}, nil
+		}
+	}
+}
+
+// LeaseKeepAlive performs a LeaseKeepAlive proposal into the Raft, supplied context must have a deadline set.
+func (t *ActiveTable) LeaseKeepAlive(ctx context.Context, req *regattapb.LeaseKeepAliveRequest) (*regattapb.LeaseKeepAliveResponse, error) {
+	ctx, span := tracing.Start(ctx, "ActiveTable.LeaseKeepAlive", tracing.Attr("table", t.Name))
+	defer span.End()
+	cmd := &regattapb.Command{
+		Type:      regattapb.Command_LEASE_KEEP_ALIVE,
+		Table:     req.Table,
+		Lease:     &regattapb.Lease{ID: req.ID},
+		Timestamp: time.Now().UnixNano(),
+	}
+	_, res, err := propose(t, ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	return &regattapb.LeaseKeepAliveResponse{ID: res.Lease.ID, TTL: res.Lease.TTL, Header: &regattapb.ResponseHeader{Revision: res.Revision}}, nil
+}
+
+// LeaseTimeToLive reads the lease using the linearizable read, supplied context must have a deadline set.
+func (t *ActiveTable) LeaseTimeToLive(ctx context.Context, req *regattapb.LeaseTimeToLiveRequest) (*regattapb.LeaseTimeToLiveResponse, error) {
+	ctx, span := tracing.Start(ctx, "ActiveTable.LeaseTimeToLive", tracing.Attr("table", t.Name))
+	defer span.End()
+	lease, err := readTable[*fsm.LeaseResponse](t, ctx, true, fsm.LeaseRequest{ID: req.ID, Keys: req.Keys})
+	if err != nil {
+		return nil, err
+	}
+	return &regattapb.LeaseTimeToLiveResponse{
+		Header:     &regattapb.ResponseHeader{Revision: lease.Revision},
+		ID:         lease.Lease.ID,
+		TTL:        remainingTTL(lease.Lease, time.Now()),
+		GrantedTTL: lease.Lease.TTL,
+		Keys:       lease.Keys,
+	}, nil
+}
+
+// remainingTTL computes the remaining time to live of the lease in whole seconds.
+func remainingTTL(lease *regattapb.Lease, now time.Time) int64 {
+	remaining := time.Duration(lease.ExpiresAt - now.UnixNano())
+	if remaining < 0 {
+		return 0
+	}
+	return int64(remaining / time.Second)
+}
+
+// ExpireLeases revokes the leases expired at the given time, it should be called only on the leader of the table.
+func (t *ActiveTable) ExpireLeases(ctx context.Context, now time.Time) error {
+	expired, err := readTable[*fsm.ExpiredLeasesResponse](t, ctx, false, fsm.ExpiredLeasesRequest{Now: now})
+	if err != nil {
+		return err
+	}
+	for _, id := range expired.IDs {
+		if _, err := t.LeaseRevoke(ctx, &regattapb.LeaseRevokeRequest{Table: []byte(t.Name), ID: id}); err != nil && !errors.Is(err, serrors.ErrLeaseNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
 // Snapshot streams snapshot to the provided writer.
 func (t *ActiveTable) Snapshot(ctx context.Context, writer io.Writer) (*fsm.SnapshotResponse, error) {
 	return readTable[*fsm.SnapshotResponse](t, ctx, true, fsm.SnapshotRequest{Writer: writer, Stopper: ctx.Done()})
 }
 
+// Compact discards the history of the keys older than the revision, supplied context must have a deadline set.
+// serrors.ErrFutureRevision is returned if the revision is newer than the current revision of the table
+// and serrors.ErrCompacted if the table is already compacted to the revision.
+func (t *ActiveTable) Compact(ctx context.Context, revision uint64) error {
+	rev, err := t.Revision(ctx, true)
+	if err != nil {
+		return err
+	}
+	if revision > rev.Revision {
+		return serrors.ErrFutureRevision
+	}
+	if revision <= rev.CompactRevision {
+		return serrors.ErrCompacted
+	}
+	res, _, err := propose(t, ctx, &regattapb.Command{
+		Type:            regattapb.Command_COMPACT,
+		Table:           []byte(t.Name),
+		CompactRevision: int64(revision),
+	})
+	if err != nil {
+		return err
+	}
+	if res != fsm.ResultSuccess {
+		// The table was compacted concurrently.
+		return serrors.ErrCompacted
+	}
+	return nil
+}
+
+// Revision returns the current revision of the table and the revision its history is compacted to.
+func (t *ActiveTable) Revision(ctx context.Context, linearizable bool) (*fsm.RevisionResponse, error) {
+	return readTable[*fsm.RevisionResponse](t, ctx, linearizable, fsm.RevisionRequest{})
+}
+
+// Size returns the disk space used by the local replica of the table in bytes.
+func (t *ActiveTable) Size(ctx context.Context) (*fsm.SizeResponse, error) {
+	return readTable[*fsm.SizeResponse](t, ctx, false, fsm.SizeRequest{})
+}
+
+// Leader returns the node ID of the table leader, the ok flag is false if the leader is not known.
+func (t *ActiveTable) Leader() (uint64, bool, error) {
+	leaderID, _, ok, err := t.nh.GetLeaderID(t.ClusterID)
+	return leaderID, ok, err
+}
+
 // LocalIndex returns local index.
 func (t *ActiveTable) LocalIndex(ctx context.Context, linearizable bool) (*fsm.IndexResponse, error) {
 	return readTable[*fsm.IndexResponse](t, ctx, linearizable, fsm.LocalIndexRequest{})
@@ -226,6 +792,26 @@ func (t *ActiveTable) LeaderIndex(ctx context.Context, linearizable bool) (*fsm.
 	return readTable[*fsm.IndexResponse](t, ctx, linearizable, fsm.LeaderIndexRequest{})
 }
 
+// TxnResult returns the stored result of a conditional transaction applied at the given index.
+func (t *ActiveTable) TxnResult(ctx context.Context, index uint64) (*fsm.TxnResultResponse, error) {
+	return readTable[*fsm.TxnResultResponse](t, ctx, false, fsm.TxnResultRequest{Index: index})
+}
+
+// Heartbeat proposes the heartbeat with the current time into the Raft, the heartbeat bounds the staleness of the replicas.
+func (t *ActiveTable) Heartbeat(ctx context.Context) error {
+	_, _, err := propose(t, ctx, &regattapb.Command{
+		Type:      regattapb.Command_DUMMY,
+		Table:     []byte(t.Name),
+		Timestamp: time.Now().UnixNano(),
+	})
+	return err
+}
+
+// LastHeartbeat returns the timestamp of the last heartbeat applied by the replica.
+func (t *ActiveTable) LastHeartbeat(ctx context.Context) (*fsm.HeartbeatResponse, error) {
+	return readTable[*fsm.HeartbeatResponse](t, ctx, false, fsm.HeartbeatRequest{})
+}
+
 // Reset resets the leader index to 0.
 func (t *ActiveTable) Reset(ctx context.Context) error {
 	li := uint64(0)
@@ -5,11 +5,15 @@ package table
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/jamf/regatta/regattapb"
 	serrors "github.com/jamf/regatta/storage/errors"
+	"github.com/jamf/regatta/storage/table/fsm"
 	"github.com/jamf/regatta/storage/table/key"
+	"github.com/jamf/regatta/tracing"
 	"github.com/jamf/regatta/util"
 	"github.com/lni/dragonboat/v4/client"
 	sm "github.com/lni/dragonboat/v4/statemachine"
@@ -47,7 +51,14 @@ func (m *mockRaftHandler) GetNoOPSession(id uint64) *client.Session {
 	return &client.Session{}
 }
 
+func (m *mockRaftHandler) GetLeaderID(id uint64) (uint64, uint64, bool, error) {
+	args := m.Called(id)
+	return args.Get(0).(uint64), args.Get(1).(uint64), args.Bool(2), args.Error(3)
+}
+
 func TestActiveTable_Range(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
 	type args struct {
 		ctx context.Context
 		req *regattapb.RangeRequest
@@ -65,20 +76,20 @@ func TestActiveTable_Range(t *testing.T) {
 			on: func(handler *mockRaftHandler) {
 				handler.
 					On("StaleRead", mock.Anything, mock.Anything).
-					Return(&regattapb.ResponseOp_Range{}, nil)
+					Return(&fsm.RangeResponse{Range: &regattapb.ResponseOp_Range{}, Revision: 5}, nil)
 			},
 			args: args{
 				ctx: context.TODO(),
 				req: &regattapb.RangeRequest{Key: []byte("missing")},
 			},
-			want: &regattapb.RangeResponse{},
+			want: &regattapb.RangeResponse{Header: &regattapb.ResponseHeader{Revision: 5}},
 		},
 		{
 			name: "Query key found",
 			on: func(handler *mockRaftHandler) {
 				handler.
 					On("StaleRead", mock.Anything, mock.Anything).
-					Return(&regattapb.ResponseOp_Range{
+					Return(&fsm.RangeResponse{Revision: 5, Range: &regattapb.ResponseOp_Range{
 						Kvs: []*regattapb.KeyValue{
 							{
 								Key:   []byte("foo"),
@@ -86,14 +97,15 @@ func TestActiveTable_Range(t *testing.T) {
 							},
 						},
 						Count: 1,
-					}, nil)
+					}}, nil)
 			},
 			args: args{
 				ctx: context.TODO(),
 				req: &regattapb.RangeRequest{Key: []byte("foo")},
 			},
 			want: &regattapb.RangeResponse{
-				Count: 1,
+				Header: &regattapb.ResponseHeader{Revision: 5},
+				Count:  1,
 				Kvs: []*regattapb.KeyValue{
 					{
 						Key:   []byte("foo"),
@@ -107,7 +119,7 @@ func TestActiveTable_Range(t *testing.T) {
 			on: func(handler *mockRaftHandler) {
 				handler.
 					On("SyncRead", mock.Anything, mock.Anything, mock.Anything).
-					Return(&regattapb.ResponseOp_Range{
+					Return(&fsm.RangeResponse{Revision: 5, Range: &regattapb.ResponseOp_Range{
 						Kvs: []*regattapb.KeyValue{
 							{
 								Key:   []byte("foo"),
@@ -115,14 +127,48 @@ func TestActiveTable_Range(t *testing.T) {
 							},
 						},
 						Count: 1,
-					}, nil)
+					}}, nil)
 			},
 			args: args{
 				ctx: context.TODO(),
 				req: &regattapb.RangeRequest{Key: []byte("foo"), Linearizable: true},
 			},
 			want: &regattapb.RangeResponse{
-				Count: 1,
+				Header: &regattapb.ResponseHeader{Revision: 5},
+				Count:  1,
+				Kvs: []*regattapb.KeyValue{
+					{
+						Key:   []byte("foo"),
+						Value: []byte("bar"),
+					},
+				},
+			},
+		},
+		{
+			name: "Query key found - max staleness",
+			on: func(handler *mockRaftHandler) {
+				handler.
+					On("StaleRead", mock.Anything, fsm.HeartbeatRequest{}).
+					Return(&fsm.HeartbeatResponse{Timestamp: time.Now().UnixNano()}, nil)
+				handler.
+					On("StaleRead", mock.Anything, mock.Anything).
+					Return(&fsm.RangeResponse{Revision: 5, Range: &regattapb.ResponseOp_Range{
+						Kvs: []*regattapb.KeyValue{
+							{
+								Key:   []byte("foo"),
+								Value: []byte("bar"),
+							},
+						},
+						Count: 1,
+					}}, nil)
+			},
+			args: args{
+				ctx: context.TODO(),
+				req: &regattapb.RangeRequest{Key: []byte("foo"), MaxStalenessMs: 10_000},
+			},
+			want: &regattapb.RangeResponse{
+				Header: &regattapb.ResponseHeader{Revision: 5},
+				Count:  1,
 				Kvs: []*regattapb.KeyValue{
 					{
 						Key:   []byte("foo"),
@@ -131,6 +177,76 @@ func TestActiveTable_Range(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Query replica stale",
+			on: func(handler *mockRaftHandler) {
+				handler.
+					On("StaleRead", mock.Anything, fsm.HeartbeatRequest{}).
+					Return(&fsm.HeartbeatResponse{Timestamp: time.Now().Add(-time.Minute).UnixNano()}, nil)
+			},
+			args: args{
+				ctx: context.TODO(),
+				req: &regattapb.RangeRequest{Key: []byte("foo"), MaxStalenessMs: 10_000},
+			},
+			wantErr: serrors.ErrReplicaStale,
+		},
+		{
+			name: "Query replica without heartbeat",
+			on: func(handler *mockRaftHandler) {
+				handler.
+					On("StaleRead", mock.Anything, fsm.HeartbeatRequest{}).
+					Return(&fsm.HeartbeatResponse{}, nil)
+			},
+			args: args{
+				ctx: context.TODO(),
+				req: &regattapb.RangeRequest{Key: []byte("foo"), MaxStalenessMs: 10_000},
+			},
+			wantErr: serrors.ErrReplicaStale,
+		},
+		{
+			name: "Query max staleness canceled",
+			args: args{
+				ctx: canceled,
+				req: &regattapb.RangeRequest{Key: []byte("foo"), MaxStalenessMs: 10_000},
+			},
+			wantErr: context.Canceled,
+		},
+		{
+			name: "Query key found - min revision",
+			on: func(handler *mockRaftHandler) {
+				handler.
+					On("StaleRead", mock.Anything, fsm.RevisionRequest{}).
+					Return(&fsm.RevisionResponse{Revision: 5}, nil)
+				handler.
+					On("StaleRead", mock.Anything, mock.Anything).
+					Return(&fsm.RangeResponse{Revision: 5, Range: &regattapb.ResponseOp_Range{
+						Kvs:   []*regattapb.KeyValue{{Key: []byte("foo"), Value: []byte("bar")}},
+						Count: 1,
+					}}, nil)
+			},
+			args: args{
+				ctx: context.TODO(),
+				req: &regattapb.RangeRequest{Key: []byte("foo"), MinRevision: 5, Linearizable: true},
+			},
+			want: &regattapb.RangeResponse{
+				Header: &regattapb.ResponseHeader{Revision: 5},
+				Count:  1,
+				Kvs:    []*regattapb.KeyValue{{Key: []byte("foo"), Value: []byte("bar")}},
+			},
+		},
+		{
+			name: "Query min revision not applied",
+			on: func(handler *mockRaftHandler) {
+				handler.
+					On("StaleRead", mock.Anything, fsm.RevisionRequest{}).
+					Return(&fsm.RevisionResponse{Revision: 3}, nil)
+			},
+			args: args{
+				ctx: canceled,
+				req: &regattapb.RangeRequest{Key: []byte("foo"), MinRevision: 5},
+			},
+			wantErr: serrors.ErrRevisionNotApplied,
+		},
 		{
 			name: "Query key too long",
 			args: args{
@@ -318,6 +434,76 @@ func TestActiveTable_Put(t *testing.T) {
 	}
 }
 
+func TestActiveTable_Txn_ValueLength(t *testing.T) {
+	put := func(value string) *regattapb.RequestOp {
+		return &regattapb.RequestOp{Request: &regattapb.RequestOp_RequestPut{RequestPut: &regattapb.RequestOp_Put{Key: []byte("foo"), Value: []byte(value)}}}
+	}
+	tests := []struct {
+		name    string
+		req     *regattapb.TxnRequest
+		wantErr error
+	}{
+		{
+			name: "values within limit",
+			req:  &regattapb.TxnRequest{Success: []*regattapb.RequestOp{put("bar")}, Failure: []*regattapb.RequestOp{put("baz")}},
+		},
+		{
+			name:    "success value too long",
+			req:     &regattapb.TxnRequest{Success: []*regattapb.RequestOp{put("bar"), put("value")}},
+			wantErr: serrors.ErrValueLengthExceeded,
+		},
+		{
+			name:    "failure value too long",
+			req:     &regattapb.TxnRequest{Failure: []*regattapb.RequestOp{put("value")}},
+			wantErr: serrors.ErrValueLengthExceeded,
+		},
+		{
+			name: "nested value too long",
+			req: &regattapb.TxnRequest{Success: []*regattapb.RequestOp{{Request: &regattapb.RequestOp_RequestTxn{RequestTxn: &regattapb.Txn{
+				Failure: []*regattapb.RequestOp{put("value")},
+			}}}}},
+			wantErr: serrors.ErrValueLengthExceeded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			nh := &mockRaftHandler{}
+			nh.On("SyncPropose", mock.Anything, mock.Anything, mock.Anything).
+				Return(sm.Result{Value: uint64(fsm.ResultSuccess), Data: mustMarshallProto(&regattapb.CommandResult{})}, nil)
+			at := &ActiveTable{Table: Table{Options: Options{MaxValueSize: 3}}, nh: nh}
+			_, err := at.Txn(context.TODO(), tt.req)
+			if tt.wantErr != nil {
+				r.ErrorIs(err, tt.wantErr)
+				nh.AssertNotCalled(t, "SyncPropose", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			r.NoError(err)
+		})
+	}
+}
+
+func TestActiveTable_LeaseRevoke(t *testing.T) {
+	r := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	nh := &mockRaftHandler{}
+	nh.On("SyncRead", mock.Anything, mock.Anything, mock.Anything).
+		Return(&fsm.LeaseResponse{Lease: &regattapb.Lease{ID: 1}, Keys: [][]byte{[]byte("foo")}}, nil)
+	// The attached keys keep changing so the revocation is never applied.
+	nh.On("SyncPropose", mock.Anything, mock.Anything, mock.Anything).
+		Return(sm.Result{Value: uint64(fsm.ResultFailure), Data: mustMarshallProto(&regattapb.CommandResult{})}, nil).
+		Twice()
+	nh.On("SyncPropose", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(mock.Arguments) { cancel() }).
+		Return(sm.Result{Value: uint64(fsm.ResultFailure), Data: mustMarshallProto(&regattapb.CommandResult{})}, nil)
+	at := &ActiveTable{Table: Table{Name: "regatta-test"}, nh: nh}
+
+	_, err := at.LeaseRevoke(ctx, &regattapb.LeaseRevokeRequest{ID: 1})
+	r.ErrorIs(err, context.Canceled)
+	nh.AssertNumberOfCalls(t, "SyncPropose", 3)
+}
+
 func TestActiveTable_Delete(t *testing.T) {
 	type args struct {
 		ctx context.Context
@@ -463,6 +649,82 @@ func TestActiveTable_Delete(t *testing.T) {
 	}
 }
 
+type recordingExporter struct {
+	mu    sync.Mutex
+	spans []tracing.SpanData
+}
+
+func (e *recordingExporter) ExportSpan(span tracing.SpanData) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = append(e.spans, span)
+	return nil
+}
+
+func TestActiveTable_Tracing(t *testing.T) {
+	r := require.New(t)
+	e := &recordingExporter{}
+	tracer := tracing.NewTracer(e, 1)
+	tracing.SetTracer(tracer)
+	defer tracing.SetTracer(nil)
+	ctx, parent := tracing.Start(context.Background(), "parent")
+	traceID := parent.Context().TraceID
+
+	handler := &mockRaftHandler{}
+	handler.
+		On("SyncPropose", mock.Anything, mock.Anything, mock.MatchedBy(func(bts []byte) bool {
+			cmd := &regattapb.Command{}
+			if err := cmd.UnmarshalVT(bts); err != nil {
+				return false
+			}
+			sc, ok := tracing.ParseTraceparent(cmd.Traceparent)
+			return ok && sc.TraceID == traceID
+		})).
+		Return(sm.Result{Data: mustMarshallProto(&regattapb.CommandResult{Responses: []*regattapb.ResponseOp{{
+			Response: &regattapb.ResponseOp_ResponsePut{ResponsePut: &regattapb.ResponseOp_Put{}},
+		}}})}, nil)
+	handler.
+		On("SyncRead", mock.Anything, mock.Anything, mock.MatchedBy(func(req fsm.TracedRequest) bool {
+			_, ok := req.Request.(fsm.RangeRequest)
+			return ok && tracing.SpanContextFromContext(req.Context).TraceID == traceID
+		})).
+		Return(&fsm.RangeResponse{Range: &regattapb.ResponseOp_Range{}}, nil)
+	handler.
+		On("StaleRead", mock.Anything, mock.MatchedBy(func(req fsm.TracedRequest) bool {
+			_, ok := req.Request.(fsm.CursorRequest)
+			return ok && tracing.SpanContextFromContext(req.Context).TraceID == traceID
+		})).
+		Return(&fsm.CursorResponse{}, nil)
+	handler.
+		On("SyncRead", mock.Anything, mock.Anything, mock.MatchedBy(func(req fsm.TracedRequest) bool {
+			_, ok := req.Request.(fsm.LeaseRequest)
+			return ok && tracing.SpanContextFromContext(req.Context).TraceID == traceID
+		})).
+		Return(&fsm.LeaseResponse{Lease: &regattapb.Lease{ID: 1, TTL: 10}}, nil)
+	at := &ActiveTable{nh: handler, Table: Table{Name: "regatta-test"}}
+
+	_, err := at.Put(ctx, &regattapb.PutRequest{Key: []byte("foo"), Value: []byte("bar")})
+	r.NoError(err)
+	_, err = at.Range(ctx, &regattapb.RangeRequest{Key: []byte("foo"), Linearizable: true})
+	r.NoError(err)
+	err = at.Cursor(ctx, &regattapb.RangeRequest{Key: []byte("foo")}, func(*regattapb.RangeResponse) error { return nil })
+	r.NoError(err)
+	_, err = at.LeaseTimeToLive(ctx, &regattapb.LeaseTimeToLiveRequest{ID: 1})
+	r.NoError(err)
+	parent.End()
+	tracing.SetTracer(nil)
+	tracer.Close()
+
+	var names []string
+	for _, span := range e.spans {
+		r.Equal(traceID, span.TraceID)
+		names = append(names, span.Name)
+	}
+	r.Equal([]string{"raft.SyncPropose", "ActiveTable.Put", "raft.SyncRead", "ActiveTable.Range", "raft.StaleRead", "ActiveTable.Cursor", "raft.SyncRead", "ActiveTable.LeaseTimeToLive", "parent"}, names)
+	r.Equal("PUT", e.spans[0].Attributes["command"])
+	r.Equal(e.spans[1].SpanID, e.spans[0].ParentID)
+}
+
 func TestTable_AsActive(t *testing.T) {
 	type fields struct {
 		Name      string
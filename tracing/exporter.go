@@ -0,0 +1,37 @@
+// Copyright JAMF Software, LLC
+
+package tracing
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// Exporter exports the finished spans, it is called by a single goroutine of the Tracer.
+type Exporter interface {
+	ExportSpan(span SpanData) error
+}
+
+// WriterExporter writes the spans into the writer as the newline delimited JSON, e.g. into the stdout or a file.
+type WriterExporter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriterExporter returns the exporter writing into the writer.
+func NewWriterExporter(w io.Writer) *WriterExporter {
+	return &WriterExporter{w: w}
+}
+
+// ExportSpan implements Exporter interface.
+func (e *WriterExporter) ExportSpan(span SpanData) error {
+	bts, err := json.Marshal(span)
+	if err != nil {
+		return err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, err = e.w.Write(append(bts, '\n'))
+	return err
+}
@@ -0,0 +1,83 @@
+// Copyright JAMF Software, LLC
+
+package tracing
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// traceparentHeader name of the metadata carrying the W3C trace context.
+const traceparentHeader = "traceparent"
+
+// Extract returns the context continuing the trace of the incoming traceparent metadata if present.
+func Extract(ctx context.Context) context.Context {
+	values := metadata.ValueFromIncomingContext(ctx, traceparentHeader)
+	if len(values) == 0 {
+		return ctx
+	}
+	sc, ok := ParseTraceparent(values[0])
+	if !ok {
+		return ctx
+	}
+	return ContextWithRemoteSpanContext(ctx, sc)
+}
+
+// Inject returns the context passing the trace context of the span of the context along in the outgoing traceparent metadata.
+func Inject(ctx context.Context) context.Context {
+	sc := SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, traceparentHeader, sc.Traceparent())
+}
+
+// UnaryServerInterceptor returns the interceptor starting the server span of the unary calls.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, span := Start(Extract(ctx), info.FullMethod)
+		defer span.End()
+		res, err := handler(ctx, req)
+		endRPC(span, err)
+		return res, err
+	}
+}
+
+// StreamServerInterceptor returns the interceptor starting the server span of the streaming calls.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, span := Start(Extract(ss.Context()), info.FullMethod)
+		defer span.End()
+		err := handler(srv, &tracedStream{ServerStream: ss, ctx: ctx})
+		endRPC(span, err)
+		return err
+	}
+}
+
+// UnaryClientInterceptor returns the interceptor starting the client span of the unary calls and passing the trace context along.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, span := Start(ctx, method)
+		defer span.End()
+		err := invoker(Inject(ctx), method, req, reply, cc, opts...)
+		endRPC(span, err)
+		return err
+	}
+}
+
+func endRPC(span *Span, err error) {
+	span.SetAttributes(Attr("grpc.code", status.Code(err).String()))
+	span.SetError(err)
+}
+
+type tracedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *tracedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,78 @@
+// Copyright JAMF Software, LLC
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context { return s.ctx }
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	r := require.New(t)
+	finish := startTestTracer(t, 0)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(traceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"))
+	_, err := UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/regatta.v1.KV/Put"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		r.True(SpanFromContext(ctx).IsRecording())
+		return nil, status.Error(codes.NotFound, "table not found")
+	})
+	r.Equal(codes.NotFound, status.Code(err))
+
+	t.Log("request without the trace context is sampled")
+	_, err = UnaryServerInterceptor()(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/regatta.v1.KV/Put"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		r.Nil(SpanFromContext(ctx))
+		return nil, nil
+	})
+	r.NoError(err)
+
+	spans := finish()
+	r.Len(spans, 1)
+	r.Equal("/regatta.v1.KV/Put", spans[0].Name)
+	r.Equal("4bf92f3577b34da6a3ce929d0e0e4736", spans[0].TraceID.String())
+	r.Equal("00f067aa0ba902b7", spans[0].ParentID.String())
+	r.Equal("NotFound", spans[0].Attributes["grpc.code"])
+	r.Equal("rpc error: code = NotFound desc = table not found", spans[0].Error)
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	r := require.New(t)
+	finish := startTestTracer(t, 1)
+	err := StreamServerInterceptor()(nil, &testServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/regatta.v1.KV/Cursor"}, func(srv interface{}, ss grpc.ServerStream) error {
+		r.True(SpanFromContext(ss.Context()).IsRecording())
+		return nil
+	})
+	r.NoError(err)
+	spans := finish()
+	r.Len(spans, 1)
+	r.Equal("OK", spans[0].Attributes["grpc.code"])
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	r := require.New(t)
+	finish := startTestTracer(t, 1)
+	ctx, parent := Start(context.Background(), "parent")
+	var sent string
+	err := UnaryClientInterceptor()(ctx, "/regatta.v1.KV/Put", nil, nil, nil, func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		sent = md.Get(traceparentHeader)[0]
+		return nil
+	})
+	r.NoError(err)
+	parent.End()
+	spans := finish()
+	r.Len(spans, 2)
+	r.Equal(SpanContext{TraceID: spans[0].TraceID, SpanID: spans[0].SpanID, Sampled: true}.Traceparent(), sent)
+	r.Equal(parent.Context().SpanID, spans[0].ParentID)
+}
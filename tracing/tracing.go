@@ -0,0 +1,315 @@
+// Copyright JAMF Software, LLC
+
+// Package tracing records the spans of the requests along their path through the servers, the storage engine, the Raft and the state machine.
+// The trace context is propagated in the W3C Trace Context format (the traceparent header) and the finished spans are handed over
+// to the Exporter of the Tracer set by SetTracer. Nothing is recorded unless the tracer is set, Start then returns a nil span
+// whose methods are no-ops.
+package tracing
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// spansBufferSize number of the finished spans buffered for the exporter, the spans are dropped once the buffer is full.
+const spansBufferSize = 4096
+
+// TraceID identifies the trace.
+type TraceID [16]byte
+
+// IsValid returns true if the trace ID is not all zeros.
+func (t TraceID) IsValid() bool { return t != TraceID{} }
+
+// String returns the hex encoded trace ID.
+func (t TraceID) String() string { return hex.EncodeToString(t[:]) }
+
+// MarshalText implements encoding.TextMarshaler interface.
+func (t TraceID) MarshalText() ([]byte, error) { return []byte(t.String()), nil }
+
+// SpanID identifies the span within the trace.
+type SpanID [8]byte
+
+// IsValid returns true if the span ID is not all zeros.
+func (s SpanID) IsValid() bool { return s != SpanID{} }
+
+// String returns the hex encoded span ID.
+func (s SpanID) String() string { return hex.EncodeToString(s[:]) }
+
+// MarshalText implements encoding.TextMarshaler interface.
+func (s SpanID) MarshalText() ([]byte, error) { return []byte(s.String()), nil }
+
+// SpanContext identifies the span across the process boundaries.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	// Sampled is set if the spans of the trace are recorded.
+	Sampled bool
+}
+
+// IsValid returns true if both the trace and the span IDs are set.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// Traceparent returns the span context encoded as the W3C traceparent header value, empty if the span context is not valid.
+func (sc SpanContext) Traceparent() string {
+	if !sc.IsValid() {
+		return ""
+	}
+	flags := 0
+	if sc.Sampled {
+		flags = 1
+	}
+	return fmt.Sprintf("00-%s-%s-%02x", sc.TraceID, sc.SpanID, flags)
+}
+
+// ParseTraceparent decodes the W3C traceparent header value, false is returned if the value is malformed.
+func ParseTraceparent(s string) (SpanContext, bool) {
+	// version-traceid-spanid-flags, e.g. 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+	if len(s) < 55 || s[2] != '-' || s[35] != '-' || s[52] != '-' || (len(s) > 55 && s[55] != '-') {
+		return SpanContext{}, false
+	}
+	var (
+		sc      SpanContext
+		version [1]byte
+		flags   [1]byte
+	)
+	if _, err := hex.Decode(version[:], []byte(s[0:2])); err != nil || version[0] == 0xff || (version[0] == 0 && len(s) != 55) {
+		return SpanContext{}, false
+	}
+	if _, err := hex.Decode(sc.TraceID[:], []byte(s[3:35])); err != nil {
+		return SpanContext{}, false
+	}
+	if _, err := hex.Decode(sc.SpanID[:], []byte(s[36:52])); err != nil {
+		return SpanContext{}, false
+	}
+	if _, err := hex.Decode(flags[:], []byte(s[53:55])); err != nil {
+		return SpanContext{}, false
+	}
+	sc.Sampled = flags[0]&1 == 1
+	return sc, sc.IsValid()
+}
+
+// Attribute is a key-value pair describing the span.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Attr returns the attribute, the value should be JSON encodable.
+func Attr(key string, value interface{}) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// SpanData is the finished span handed over to the Exporter.
+type SpanData struct {
+	TraceID    TraceID                `json:"trace_id"`
+	SpanID     SpanID                 `json:"span_id"`
+	ParentID   SpanID                 `json:"parent_id"`
+	Name       string                 `json:"name"`
+	Start      time.Time              `json:"start"`
+	End        time.Time              `json:"end"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+}
+
+// Span is the recorded operation, the methods of the nil span are no-ops. Span must not be used concurrently.
+type Span struct {
+	tracer *Tracer
+	data   SpanData
+	ended  bool
+}
+
+// Context returns the span context of the span, zero if the span is nil.
+func (s *Span) Context() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return SpanContext{TraceID: s.data.TraceID, SpanID: s.data.SpanID, Sampled: true}
+}
+
+// IsRecording returns true if the span is recorded.
+func (s *Span) IsRecording() bool {
+	return s != nil && !s.ended
+}
+
+// SetAttributes sets the attributes of the span.
+func (s *Span) SetAttributes(attrs ...Attribute) {
+	if !s.IsRecording() {
+		return
+	}
+	if s.data.Attributes == nil {
+		s.data.Attributes = make(map[string]interface{}, len(attrs))
+	}
+	for _, a := range attrs {
+		s.data.Attributes[a.Key] = a.Value
+	}
+}
+
+// SetError marks the span as failed by the error, nil error is ignored.
+func (s *Span) SetError(err error) {
+	if err == nil || !s.IsRecording() {
+		return
+	}
+	s.data.Error = err.Error()
+}
+
+// End finishes the span and hands it over to the exporter, the span is ended only once.
+func (s *Span) End() {
+	if !s.IsRecording() {
+		return
+	}
+	s.ended = true
+	s.data.End = time.Now()
+	s.tracer.export(s.data)
+}
+
+// Tracer samples the traces and exports their spans by the exporter in the background.
+type Tracer struct {
+	exporter    Exporter
+	sampleRatio float64
+	spans       chan SpanData
+	done        chan struct{}
+	dropped     atomic.Uint64
+	log         *zap.SugaredLogger
+
+	// mu guards the spans channel from being written into once closed.
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewTracer returns the Tracer exporting the spans by the exporter. The sampleRatio is the fraction of the traces started
+// by this process that are recorded, the traces continued from the incoming trace context follow its sampled flag.
+func NewTracer(exporter Exporter, sampleRatio float64) *Tracer {
+	t := &Tracer{
+		exporter:    exporter,
+		sampleRatio: sampleRatio,
+		spans:       make(chan SpanData, spansBufferSize),
+		done:        make(chan struct{}),
+		log:         zap.S().Named("tracing"),
+	}
+	go t.run()
+	return t
+}
+
+func (t *Tracer) run() {
+	defer close(t.done)
+	for span := range t.spans {
+		if err := t.exporter.ExportSpan(span); err != nil {
+			t.log.Warnf("cannot export span %s: %v", span.Name, err)
+		}
+	}
+}
+
+func (t *Tracer) export(span SpanData) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.closed {
+		return
+	}
+	select {
+	case t.spans <- span:
+	default:
+		if t.dropped.Add(1)%spansBufferSize == 1 {
+			t.log.Warnf("exporter is falling behind, %d spans dropped", t.dropped.Load())
+		}
+	}
+}
+
+// Close exports the buffered spans and stops the tracer, the spans ended afterwards are dropped.
+func (t *Tracer) Close() {
+	t.mu.Lock()
+	if !t.closed {
+		t.closed = true
+		close(t.spans)
+	}
+	t.mu.Unlock()
+	<-t.done
+}
+
+var tracer atomic.Pointer[Tracer]
+
+// SetTracer sets the tracer recording the spans, nil disables the tracing.
+func SetTracer(t *Tracer) {
+	tracer.Store(t)
+}
+
+type spanKey struct{}
+
+type remoteKey struct{}
+
+// SpanFromContext returns the current span of the context, nil if there is none.
+func SpanFromContext(ctx context.Context) *Span {
+	s, _ := ctx.Value(spanKey{}).(*Span)
+	return s
+}
+
+// ContextWithRemoteSpanContext returns the context continuing the trace of the remote span.
+func ContextWithRemoteSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, remoteKey{}, sc)
+}
+
+// SpanContextFromContext returns the span context of the current span of the context or the remote span context if there is no current span.
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	if s := SpanFromContext(ctx); s != nil {
+		return s.Context()
+	}
+	sc, _ := ctx.Value(remoteKey{}).(SpanContext)
+	return sc
+}
+
+// Start starts the span as a child of the span of the context, the returned context carries the new span.
+// The nil span is returned if the tracing is disabled or the trace is not sampled.
+func Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, *Span) {
+	t := tracer.Load()
+	if t == nil {
+		return ctx, nil
+	}
+	parent := SpanContextFromContext(ctx)
+	if parent.IsValid() && !parent.Sampled {
+		return ctx, nil
+	}
+	traceID := parent.TraceID
+	if !parent.IsValid() {
+		traceID = newTraceID()
+		if rand.Float64() >= t.sampleRatio {
+			// Remember the decision so that the rest of the trace is not sampled either.
+			return ContextWithRemoteSpanContext(ctx, SpanContext{TraceID: traceID, SpanID: newSpanID()}), nil
+		}
+	}
+	s := &Span{
+		tracer: t,
+		data: SpanData{
+			TraceID:  traceID,
+			SpanID:   newSpanID(),
+			ParentID: parent.SpanID,
+			Name:     name,
+			Start:    time.Now(),
+		},
+	}
+	s.SetAttributes(attrs...)
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+func newTraceID() TraceID {
+	var id TraceID
+	binary.BigEndian.PutUint64(id[:8], rand.Uint64())
+	binary.BigEndian.PutUint64(id[8:], rand.Uint64())
+	return id
+}
+
+func newSpanID() SpanID {
+	var id SpanID
+	for !id.IsValid() {
+		binary.BigEndian.PutUint64(id[:], rand.Uint64())
+	}
+	return id
+}
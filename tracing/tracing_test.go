@@ -0,0 +1,183 @@
+// Copyright JAMF Software, LLC
+
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type recordingExporter struct {
+	mu    sync.Mutex
+	spans []SpanData
+}
+
+func (e *recordingExporter) ExportSpan(span SpanData) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = append(e.spans, span)
+	return nil
+}
+
+// startTestTracer sets the tracer recording the spans, the spans are exported once the returned function is called.
+func startTestTracer(t *testing.T, sampleRatio float64) func() []SpanData {
+	e := &recordingExporter{}
+	tr := NewTracer(e, sampleRatio)
+	SetTracer(tr)
+	t.Cleanup(func() {
+		SetTracer(nil)
+		tr.Close()
+	})
+	return func() []SpanData {
+		SetTracer(nil)
+		tr.Close()
+		return e.spans
+	}
+}
+
+func TestParseTraceparent(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		want   SpanContext
+		wantOK bool
+	}{
+		{
+			name:  "sampled",
+			value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			want: SpanContext{
+				TraceID: TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+				SpanID:  SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+				Sampled: true,
+			},
+			wantOK: true,
+		},
+		{
+			name:  "not sampled",
+			value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00",
+			want: SpanContext{
+				TraceID: TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+				SpanID:  SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+			},
+			wantOK: true,
+		},
+		{
+			name:  "future version with extra fields",
+			value: "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+			want: SpanContext{
+				TraceID: TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+				SpanID:  SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+				Sampled: true,
+			},
+			wantOK: true,
+		},
+		{name: "empty", value: ""},
+		{name: "version 00 with extra fields", value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra"},
+		{name: "invalid version", value: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+		{name: "zero trace ID", value: "00-00000000000000000000000000000000-00f067aa0ba902b7-01"},
+		{name: "zero span ID", value: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01"},
+		{name: "not hex", value: "00-4bf92f3577b34da6a3ce929d0e0e473x-00f067aa0ba902b7-01"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := require.New(t)
+			sc, ok := ParseTraceparent(tt.value)
+			r.Equal(tt.wantOK, ok)
+			if !tt.wantOK {
+				return
+			}
+			r.Equal(tt.want, sc)
+			if strings.HasPrefix(tt.value, "00-") {
+				r.Equal(tt.value, sc.Traceparent())
+			}
+		})
+	}
+}
+
+func TestStart(t *testing.T) {
+	r := require.New(t)
+
+	t.Log("tracing disabled")
+	ctx, span := Start(context.Background(), "disabled")
+	r.Nil(span)
+	r.False(span.IsRecording())
+	span.SetAttributes(Attr("key", "value"))
+	span.SetError(errors.New("error"))
+	span.End()
+	r.False(SpanContextFromContext(ctx).IsValid())
+
+	finish := startTestTracer(t, 1)
+	remote, _ := ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	t.Log("child spans continue the remote trace")
+	ctx, root := Start(ContextWithRemoteSpanContext(context.Background(), remote), "root", Attr("table", "regatta-test"))
+	r.True(root.IsRecording())
+	_, child := Start(ctx, "child")
+	child.SetError(errors.New("failed"))
+	child.End()
+	root.End()
+	root.End()
+
+	t.Log("not sampled remote trace is not recorded")
+	remote.Sampled = false
+	_, span = Start(ContextWithRemoteSpanContext(context.Background(), remote), "not sampled")
+	r.Nil(span)
+
+	_, late := Start(ctx, "late")
+	spans := finish()
+	r.Len(spans, 2)
+	r.Equal("child", spans[0].Name)
+	r.Equal(remote.TraceID, spans[0].TraceID)
+	r.Equal(root.Context().SpanID, spans[0].ParentID)
+	r.Equal("failed", spans[0].Error)
+	r.Equal("root", spans[1].Name)
+	r.Equal(remote.SpanID, spans[1].ParentID)
+	r.Equal(map[string]interface{}{"table": "regatta-test"}, spans[1].Attributes)
+	r.False(spans[1].End.Before(spans[1].Start))
+
+	t.Log("spans ended after the tracer is closed are dropped")
+	r.True(late.IsRecording())
+	late.End()
+}
+
+func TestStart_Sampling(t *testing.T) {
+	r := require.New(t)
+	finish := startTestTracer(t, 0)
+
+	ctx, span := Start(context.Background(), "root")
+	r.Nil(span)
+	r.True(SpanContextFromContext(ctx).IsValid())
+	_, span = Start(ctx, "child")
+	r.Nil(span)
+	r.Empty(finish())
+}
+
+func TestWriterExporter_ExportSpan(t *testing.T) {
+	r := require.New(t)
+	buf := &bytes.Buffer{}
+	e := NewWriterExporter(buf)
+	span := SpanData{
+		TraceID:    TraceID{1},
+		SpanID:     SpanID{2},
+		Name:       "span",
+		Attributes: map[string]interface{}{"index": 1},
+	}
+	r.NoError(e.ExportSpan(span))
+	r.NoError(e.ExportSpan(span))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	r.Len(lines, 2)
+	var decoded map[string]interface{}
+	r.NoError(json.Unmarshal([]byte(lines[0]), &decoded))
+	r.Equal("01000000000000000000000000000000", decoded["trace_id"])
+	r.Equal("0200000000000000", decoded["span_id"])
+	r.Equal("0000000000000000", decoded["parent_id"])
+	r.Equal("span", decoded["name"])
+	r.Equal(map[string]interface{}{"index": float64(1)}, decoded["attributes"])
+}